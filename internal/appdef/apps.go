@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"strings"
 
+	"golang.org/x/net/publicsuffix"
+
 	"github.com/ainsleydev/webkit/pkg/util/ptr"
 )
 
@@ -27,6 +29,7 @@ type (
 		UsesNPM          *bool       `json:"usesNPM" description:"Whether this app should be included in the pnpm workspace (auto-detected if not set)"`
 		TerraformManaged *bool       `json:"terraformManaged,omitempty" description:"Whether this app's infrastructure is managed by Terraform (defaults to true)"`
 		Domains          []Domain    `json:"domains,omitzero" description:"Domain configurations for accessing this app"`
+		Network          Network     `json:"network,omitzero" description:"Firewall rules and private network placement for VM apps"`
 		Toolset
 	}
 	// Build defines Docker build configuration for containerised applications.
@@ -49,10 +52,12 @@ type (
 	// Domain represents a domain name configuration for accessing an app.
 	// Domains can be primary, aliases, or unmanaged depending on your DNS setup.
 	Domain struct {
-		Name     string     `json:"name" validate:"required" description:"Domain name without protocol (e.g., 'example.com' or 'api.example.com')"`
-		Type     DomainType `json:"type" validate:"omitempty,oneof=primary alias unmanaged" description:"Domain type (primary, alias, unmanaged)"`
-		Zone     string     `json:"zone,omitempty" description:"DNS zone for the domain if different from default"`
-		Wildcard bool       `json:"wildcard,omitempty" description:"Whether this is a wildcard domain (e.g., '*.example.com')"`
+		Name     string      `json:"name" validate:"required" description:"Domain name without protocol (e.g., 'example.com' or 'api.example.com')"`
+		Type     DomainType  `json:"type" validate:"omitempty,oneof=primary alias unmanaged" description:"Domain type (primary, alias, unmanaged)"`
+		Zone     string      `json:"zone,omitempty" description:"DNS zone for the domain if different from default"`
+		Wildcard bool        `json:"wildcard,omitempty" description:"Whether this is a wildcard domain (e.g., '*.example.com')"`
+		DNS      DNSProvider `json:"dns,omitempty" validate:"omitempty,oneof=digitalocean hetzner cloudflare" description:"DNS provider managing the domain's zone (digitalocean, hetzner, cloudflare), defaults to the app's infra provider"`
+		Proxied  bool        `json:"proxied,omitempty" description:"Whether traffic is proxied through Cloudflare, only applies when dns is cloudflare"`
 	}
 )
 
@@ -98,6 +103,51 @@ func (d DomainType) Normalise() DomainType {
 	return DomainType(strings.ToLower(string(d)))
 }
 
+// DNSProvider defines the provider that hosts a domain's DNS zone
+// and that webkit creates records in.
+type DNSProvider string
+
+// DNSProvider constants.
+const (
+	DNSProviderDigitalOcean DNSProvider = "digitalocean"
+	DNSProviderHetzner      DNSProvider = "hetzner"
+	DNSProviderCloudflare   DNSProvider = "cloudflare"
+)
+
+// String implements fmt.Stringer on the DNSProvider.
+func (d DNSProvider) String() string {
+	return string(d)
+}
+
+// IsManaged returns whether webkit should create and own DNS
+// records for the domain, which is any domain not marked as
+// unmanaged.
+func (d Domain) IsManaged() bool {
+	return d.Type.Normalise() != DomainTypeUnmanaged
+}
+
+// ZoneName returns the DNS zone the domain belongs to. It's the
+// explicit Zone when set, otherwise the registrable domain according
+// to the public suffix list (e.g. "cms.example.com" -> "example.com"
+// and "shop.example.co.uk" -> "example.co.uk").
+//
+// Returns an empty string if the zone cannot be determined, such as
+// when the domain is itself a public suffix.
+func (d Domain) ZoneName() string {
+	if d.Zone != "" {
+		return strings.TrimSuffix(d.Zone, ".")
+	}
+	name := strings.ToLower(strings.TrimPrefix(strings.TrimSuffix(d.Name, "."), "*."))
+	if !strings.Contains(name, ".") {
+		return ""
+	}
+	zone, err := publicsuffix.EffectiveTLDPlusOne(name)
+	if err != nil {
+		return ""
+	}
+	return zone
+}
+
 // MergeEnvironments merges the shared env with the apps,
 // with the app specific variables taking precedence.
 func (a *App) MergeEnvironments(shared Environment) Environment {
@@ -126,6 +176,15 @@ func (a *App) IsTerraformManaged() bool {
 	return *a.TerraformManaged
 }
 
+// IsNetworkVM returns whether the app runs on a VM that network
+// rules can be applied to (DigitalOcean droplets or Hetzner servers).
+func (a *App) IsNetworkVM() bool {
+	if a.Infra.Type != "vm" {
+		return false
+	}
+	return a.Infra.Provider == ResourceProviderDigitalOcean || a.Infra.Provider == ResourceProviderHetzner
+}
+
 // IsMonitoringEnabled returns whether monitoring is enabled for this app.
 // It defaults to true when the field is nil or explicitly set to true.
 func (a *App) IsMonitoringEnabled() bool {
@@ -246,11 +305,28 @@ func (a *App) applyDefaults() error {
 	// our lowercase constants.
 	for i := range a.Domains {
 		a.Domains[i].Type = a.Domains[i].Type.Normalise()
+		if a.Domains[i].DNS == "" && a.Domains[i].IsManaged() {
+			a.Domains[i].DNS = a.defaultDNSProvider()
+		}
 	}
 
 	return nil
 }
 
+// defaultDNSProvider returns the DNS provider used for managed
+// domains when one isn't set, which is the app's infra provider
+// if it hosts DNS.
+func (a *App) defaultDNSProvider() DNSProvider {
+	switch a.Infra.Provider {
+	case ResourceProviderDigitalOcean:
+		return DNSProviderDigitalOcean
+	case ResourceProviderHetzner:
+		return DNSProviderHetzner
+	default:
+		return ""
+	}
+}
+
 // defaultPort returns the default port for the app based on its type.
 // - Payload CMS: 3000
 // - SvelteKit: 3001
@@ -1035,3 +1035,95 @@ func TestApp_GenerateMaintenanceMonitor(t *testing.T) {
 		})
 	}
 }
+
+func TestDomain_IsManaged(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Domain{Name: "example.com", Type: DomainTypePrimary}.IsManaged())
+	assert.True(t, Domain{Name: "www.example.com", Type: DomainTypeAlias}.IsManaged())
+	assert.True(t, Domain{Name: "example.com"}.IsManaged())
+	assert.False(t, Domain{Name: "example.com", Type: DomainTypeUnmanaged}.IsManaged())
+	assert.False(t, Domain{Name: "example.com", Type: "UNMANAGED"}.IsManaged())
+}
+
+func TestDomain_ZoneName(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]struct {
+		input Domain
+		want  string
+	}{
+		"Apex":               {input: Domain{Name: "example.com"}, want: "example.com"},
+		"Subdomain":          {input: Domain{Name: "cms.example.com"}, want: "example.com"},
+		"Wildcard":           {input: Domain{Name: "*.example.com"}, want: "example.com"},
+		"Explicit Zone":      {input: Domain{Name: "api.example.co.uk", Zone: "example.co.uk"}, want: "example.co.uk"},
+		"Trailing Dot Zone":  {input: Domain{Name: "api.example.com", Zone: "example.com."}, want: "example.com"},
+		"Single Label":       {input: Domain{Name: "localhost"}, want: ""},
+		"Multi-label Suffix": {input: Domain{Name: "shop.example.co.uk"}, want: "example.co.uk"},
+		"Private Suffix":     {input: Domain{Name: "app.my-site.pages.dev"}, want: "my-site.pages.dev"},
+		"Upper Case":         {input: Domain{Name: "CMS.Example.COM"}, want: "example.com"},
+		"Public Suffix":      {input: Domain{Name: "co.uk"}, want: ""},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.want, test.input.ZoneName())
+		})
+	}
+}
+
+func TestApp_ApplyDefaults_DNS(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]struct {
+		app  App
+		want []DNSProvider
+	}{
+		"DigitalOcean App": {
+			app: App{
+				Name:  "web",
+				Type:  AppTypeSvelteKit,
+				Infra: Infra{Provider: ResourceProviderDigitalOcean, Type: "container"},
+				Domains: []Domain{
+					{Name: "example.com", Type: DomainTypePrimary},
+					{Name: "legacy.com", Type: DomainTypeUnmanaged},
+				},
+			},
+			want: []DNSProvider{DNSProviderDigitalOcean, ""},
+		},
+		"Hetzner App": {
+			app: App{
+				Name:    "api",
+				Type:    AppTypeGoLang,
+				Infra:   Infra{Provider: ResourceProviderHetzner, Type: "vm"},
+				Domains: []Domain{{Name: "api.example.com", Type: DomainTypePrimary}},
+			},
+			want: []DNSProvider{DNSProviderHetzner},
+		},
+		"Explicit Provider Preserved": {
+			app: App{
+				Name:    "web",
+				Type:    AppTypeSvelteKit,
+				Infra:   Infra{Provider: ResourceProviderDigitalOcean, Type: "container"},
+				Domains: []Domain{{Name: "example.com", Type: DomainTypePrimary, DNS: DNSProviderCloudflare}},
+			},
+			want: []DNSProvider{DNSProviderCloudflare},
+		},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			app := test.app
+			require.NoError(t, app.applyDefaults())
+
+			got := make([]DNSProvider, len(app.Domains))
+			for i, d := range app.Domains {
+				got[i] = d.DNS
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
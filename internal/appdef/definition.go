@@ -20,14 +20,15 @@ type (
 	// It defines the structure of the app.json file used to configure
 	// all aspects of a webkit project including apps, resources, and infrastructure.
 	Definition struct {
-		Schema        string     `json:"$schema,omitempty" jsonschema:"-" description:"JSON Schema reference for IDE validation and autocomplete"`
-		WebkitVersion string     `json:"webkit_version" required:"true" validate:"required" description:"The version of webkit used to generate this configuration"`
-		Project       Project    `json:"project" required:"true" validate:"required" description:"Project metadata including name, title, and repository information"`
-		Monitoring    Monitoring `json:"monitoring,omitempty" description:"Monitoring configuration including status page and custom monitors"`
-		Shared        Shared     `json:"shared" description:"Shared configuration that applies to all apps"`
-		Resources     []Resource `json:"resources" description:"Infrastructure resources such as databases and storage buckets"`
-		Apps          []App      `json:"apps" required:"true" validate:"required,min=1,dive" minItems:"1" description:"Application definitions for all apps in the project"`
-		Utilities     []Utility  `json:"utilities,omitempty" validate:"omitempty,dive" description:"Non-deployed workspace members such as E2E tests, shared libraries, and CLI tools"`
+		Schema        string       `json:"$schema,omitempty" jsonschema:"-" description:"JSON Schema reference for IDE validation and autocomplete"`
+		WebkitVersion string       `json:"webkit_version" required:"true" validate:"required" description:"The version of webkit used to generate this configuration"`
+		Project       Project      `json:"project" required:"true" validate:"required" description:"Project metadata including name, title, and repository information"`
+		Monitoring    Monitoring   `json:"monitoring,omitempty" description:"Monitoring configuration including status page and custom monitors"`
+		Infra         ProjectInfra `json:"infra,omitempty" description:"Infrastructure configuration such as the provisioning backend"`
+		Shared        Shared       `json:"shared" description:"Shared configuration that applies to all apps"`
+		Resources     []Resource   `json:"resources" description:"Infrastructure resources such as databases and storage buckets"`
+		Apps          []App        `json:"apps" required:"true" validate:"required,min=1,dive" minItems:"1" description:"Application definitions for all apps in the project"`
+		Utilities     []Utility    `json:"utilities,omitempty" validate:"omitempty,dive" description:"Non-deployed workspace members such as E2E tests, shared libraries, and CLI tools"`
 	}
 	// Shared contains configuration that is shared across all applications
 	// in the project, such as common environment variables.
@@ -109,6 +110,7 @@ func (d *Definition) ApplyDefaults() error {
 	}
 
 	d.Monitoring.applyDefaults()
+	d.Infra.applyDefaults()
 
 	return nil
 }
@@ -122,6 +124,7 @@ func (d *Definition) FilterTerraformManaged() (*Definition, SkippedItems) {
 		WebkitVersion: d.WebkitVersion,
 		Project:       d.Project,
 		Monitoring:    d.Monitoring,
+		Infra:         d.Infra,
 		Shared:        d.Shared,
 		Apps:          make([]App, 0, len(d.Apps)),
 		Resources:     make([]Resource, 0, len(d.Resources)),
@@ -2,6 +2,7 @@ package appdef
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/ainsleydev/webkit/pkg/env"
@@ -24,11 +25,12 @@ type (
 	// EnvValue represents a single environment variable configuration.
 	// It specifies both the source type and the value/reference for the variable.
 	EnvValue struct {
-		Source EnvSource `json:"source" required:"true" validate:"required,oneof=value resource sops" description:"Source type for the variable value (value, resource, sops)"`
+		Source EnvSource `json:"source" required:"true" validate:"required,oneof=value resource sops 1password doppler vault github" description:"Source type for the variable value (value, resource, sops, 1password, doppler, vault, github)"`
 		// Value holds the actual value or reference depending on the source type:
 		// - "value": A static string (e.g., "https://api.example.com")
 		// - "resource": A Terraform resource reference (e.g., "db.connection_url")
 		// - "sops": The variable name/key to lookup in the SOPS file (e.g., "API_KEY")
+		// - "1password", "doppler", "vault", "github": A secret reference (e.g., "secret/data/web#api_key")
 		Value any `json:"value,omitempty" description:"The value or reference for this variable (format depends on source type)"`
 	}
 )
@@ -48,13 +50,47 @@ const (
 	// EnvSourceSOPS is an encrypted secret stored in a SOPS file.
 	// Example: "secrets/production.yaml:API_KEY"
 	EnvSourceSOPS EnvSource = "sops"
+
+	// EnvSourceOnePassword is a secret stored in 1Password, read through
+	// a 1Password Connect server.
+	// Example: "production/stripe#secret_key" (vault/item#field)
+	EnvSourceOnePassword EnvSource = "1password"
+
+	// EnvSourceDoppler is a secret stored in Doppler, optionally prefixed
+	// with the project and config when the token isn't scoped to one.
+	// Example: "STRIPE_KEY" or "website/prd#STRIPE_KEY"
+	EnvSourceDoppler EnvSource = "doppler"
+
+	// EnvSourceVault is a secret stored in HashiCorp Vault.
+	// Example: "secret/data/website#stripe_key" (path#key)
+	EnvSourceVault EnvSource = "vault"
+
+	// EnvSourceGitHub is a GitHub Actions secret, which can only be read
+	// in workflows that expose it as an environment variable.
+	// Example: "STRIPE_KEY"
+	EnvSourceGitHub EnvSource = "github"
 )
 
+// SecretBackendSources are the env sources that are read from an
+// external secret manager.
+var SecretBackendSources = []EnvSource{
+	EnvSourceOnePassword,
+	EnvSourceDoppler,
+	EnvSourceVault,
+	EnvSourceGitHub,
+}
+
 // String implements fmt.Stringer on the EnvSource.
 func (e EnvSource) String() string {
 	return string(e)
 }
 
+// IsSecretBackend returns true if the value is read from an external
+// secret manager rather than app.json, Terraform or a SOPS file.
+func (e EnvSource) IsSecretBackend() bool {
+	return slices.Contains(SecretBackendSources, e)
+}
+
 // EnvWalkEntry holds the details of a single env variable during iteration.
 type EnvWalkEntry struct {
 	Environment env.Environment
@@ -174,6 +210,30 @@ func ParseResourceReference(value any) (resourceName, outputName string, ok bool
 	return parts[0], parts[1], true
 }
 
+// ParseSecretReference parses a secret backend reference in the
+// "path#key" format (e.g., "secret/data/website#stripe_key").
+//
+// References without a "#" are returned as a key with an empty path,
+// which is valid for backends that don't need one, such as GitHub.
+func ParseSecretReference(value any) (path, key string, ok bool) {
+	valueStr, isString := value.(string)
+	if !isString || valueStr == "" {
+		return "", "", false
+	}
+
+	i := strings.LastIndex(valueStr, "#")
+	if i == -1 {
+		return "", valueStr, true
+	}
+
+	path, key = valueStr[:i], valueStr[i+1:]
+	if path == "" || key == "" {
+		return "", "", false
+	}
+
+	return path, key, true
+}
+
 // CloneEnvVar creates a shallow copy of an EnvVar map.
 // This prevents mutation of the original map.
 // Returns nil if the source is nil.
@@ -192,6 +192,48 @@ func TestMergeVars(t *testing.T) {
 	}
 }
 
+func TestEnvSource_IsSecretBackend(t *testing.T) {
+	t.Parallel()
+
+	for _, source := range SecretBackendSources {
+		assert.True(t, source.IsSecretBackend(), source)
+	}
+	for _, source := range []EnvSource{EnvSourceValue, EnvSourceResource, EnvSourceSOPS} {
+		assert.False(t, source.IsSecretBackend(), source)
+	}
+}
+
+func TestParseSecretReference(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]struct {
+		input    any
+		wantPath string
+		wantKey  string
+		wantOk   bool
+	}{
+		"Path And Key":     {input: "secret/data/web#api_key", wantPath: "secret/data/web", wantKey: "api_key", wantOk: true},
+		"Key Only":         {input: "API_KEY", wantKey: "API_KEY", wantOk: true},
+		"Hash In Path":     {input: "a#b#c", wantPath: "a#b", wantKey: "c", wantOk: true},
+		"Empty Path":       {input: "#api_key"},
+		"Empty Key":        {input: "secret/data/web#"},
+		"Empty String":     {input: ""},
+		"Invalid Not Text": {input: 123},
+		"Invalid Nil":      {input: nil},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path, key, ok := ParseSecretReference(test.input)
+			assert.Equal(t, test.wantOk, ok)
+			assert.Equal(t, test.wantPath, path)
+			assert.Equal(t, test.wantKey, key)
+		})
+	}
+}
+
 func TestParseResourceReference(t *testing.T) {
 	t.Parallel()
 
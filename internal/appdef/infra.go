@@ -0,0 +1,32 @@
+package appdef
+
+type (
+	// ProjectInfra is the project-level infrastructure configuration which
+	// determines how the apps and resources in app.json are provisioned.
+	ProjectInfra struct {
+		Backend  InfraBackend `json:"backend,omitempty" validate:"omitempty,oneof=terraform opentofu" description:"Backend used to provision infrastructure (terraform, opentofu). Defaults to terraform."`
+		Registry Registry     `json:"registry,omitzero" description:"Container registry that app images are pushed to and pulled from"`
+	}
+	// InfraBackend defines the tool used to provision infrastructure.
+	InfraBackend string
+)
+
+// InfraBackend constants.
+const (
+	InfraBackendTerraform InfraBackend = "terraform"
+	InfraBackendOpenTofu  InfraBackend = "opentofu"
+)
+
+// String implements fmt.Stringer on InfraBackend.
+func (b InfraBackend) String() string {
+	return string(b)
+}
+
+// applyDefaults sets Terraform as the backend and GHCR as the
+// registry when none are defined.
+func (i *ProjectInfra) applyDefaults() {
+	if i.Backend == "" {
+		i.Backend = InfraBackendTerraform
+	}
+	i.Registry.applyDefaults()
+}
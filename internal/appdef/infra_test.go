@@ -0,0 +1,41 @@
+package appdef
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInfraBackend_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "opentofu", InfraBackendOpenTofu.String())
+}
+
+func TestProjectInfra_ApplyDefaults(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]struct {
+		input ProjectInfra
+		want  InfraBackend
+	}{
+		"Defaults to Terraform": {
+			input: ProjectInfra{},
+			want:  InfraBackendTerraform,
+		},
+		"Preserves OpenTofu": {
+			input: ProjectInfra{Backend: InfraBackendOpenTofu},
+			want:  InfraBackendOpenTofu,
+		},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			test.input.applyDefaults()
+			assert.Equal(t, test.want, test.input.Backend)
+			assert.Equal(t, RegistryTypeGHCR, test.input.Registry.Type)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package appdef
+
+import (
+	"net"
+	"slices"
+)
+
+type (
+	// Network declares who can reach an app or resource and whether
+	// it's placed in the project's private network (VPC).
+	//
+	// Inbound rules apply to VM apps and replace the default firewall,
+	// trusted sources apply to Postgres resources. Sources are either
+	// the name of another app in app.json or an IP/CIDR block.
+	Network struct {
+		Inbound        []NetworkRule `json:"inbound,omitempty" validate:"omitempty,dive" description:"Inbound firewall rules for VM apps, replacing the default of SSH, HTTP and HTTPS open to anyone"`
+		TrustedSources []string      `json:"trustedSources,omitempty" description:"Apps or CIDR blocks allowed to connect to a database resource, anything else is refused"`
+		VPC            bool          `json:"vpc,omitempty" description:"Place the app or resource in the project's private network (VPC)"`
+	}
+	// NetworkRule opens a single port to a set of trusted sources.
+	NetworkRule struct {
+		Port     int      `json:"port" validate:"required,min=1,max=65535" description:"Port to allow inbound traffic on"`
+		Protocol string   `json:"protocol,omitempty" validate:"omitempty,oneof=tcp udp" description:"Protocol of the port (tcp, udp), defaults to tcp"`
+		Sources  []string `json:"sources,omitempty" description:"Apps or CIDR blocks allowed to connect, defaults to anyone"`
+	}
+)
+
+// NetworkAnywhere are the CIDR blocks that match every IPv4 and
+// IPv6 address, used when a rule doesn't declare any sources.
+var NetworkAnywhere = []string{"0.0.0.0/0", "::/0"}
+
+// DefaultInboundRules are the rules applied to VMs when an app doesn't
+// declare its own, matching the firewall provisioned by default.
+func DefaultInboundRules() []NetworkRule {
+	return []NetworkRule{
+		{Port: 22, Protocol: "tcp"},
+		{Port: 80, Protocol: "tcp"},
+		{Port: 443, Protocol: "tcp"},
+	}
+}
+
+// IsZero returns true if nothing has been declared, meaning the
+// provider defaults are used.
+func (n Network) IsZero() bool {
+	return len(n.Inbound) == 0 && len(n.TrustedSources) == 0 && !n.VPC
+}
+
+// ProtocolOrDefault returns the protocol of the rule, defaulting to tcp.
+func (r NetworkRule) ProtocolOrDefault() string {
+	if r.Protocol == "" {
+		return "tcp"
+	}
+	return r.Protocol
+}
+
+// SplitNetworkSources separates sources into CIDR blocks and app
+// names. Single IP addresses are returned as /32 or /128 blocks.
+func SplitNetworkSources(sources []string) (cidrs, apps []string) {
+	for _, source := range sources {
+		if cidr, ok := ParseNetworkCIDR(source); ok {
+			cidrs = append(cidrs, cidr)
+			continue
+		}
+		if !slices.Contains(apps, source) {
+			apps = append(apps, source)
+		}
+	}
+	return cidrs, apps
+}
+
+// ParseNetworkCIDR returns the source as a CIDR block if it's an
+// IP address or CIDR block, otherwise false.
+func ParseNetworkCIDR(source string) (string, bool) {
+	if _, block, err := net.ParseCIDR(source); err == nil {
+		return block.String(), true
+	}
+	ip := net.ParseIP(source)
+	if ip == nil {
+		return "", false
+	}
+	if ip.To4() != nil {
+		return ip.String() + "/32", true
+	}
+	return ip.String() + "/128", true
+}
@@ -0,0 +1,63 @@
+package appdef
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetwork_IsZero(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Network{}.IsZero())
+	assert.False(t, Network{VPC: true}.IsZero())
+	assert.False(t, Network{Inbound: []NetworkRule{{Port: 22}}}.IsZero())
+	assert.False(t, Network{TrustedSources: []string{"web"}}.IsZero())
+}
+
+func TestNetworkRule_ProtocolOrDefault(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "tcp", NetworkRule{Port: 22}.ProtocolOrDefault())
+	assert.Equal(t, "udp", NetworkRule{Port: 53, Protocol: "udp"}.ProtocolOrDefault())
+}
+
+func TestParseNetworkCIDR(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		"IPv4 CIDR":       {input: "10.0.0.0/8", want: "10.0.0.0/8", ok: true},
+		"Unmasked CIDR":   {input: "10.1.2.3/8", want: "10.0.0.0/8", ok: true},
+		"IPv4 Address":    {input: "203.0.113.10", want: "203.0.113.10/32", ok: true},
+		"IPv6 Address":    {input: "2001:db8::1", want: "2001:db8::1/128", ok: true},
+		"IPv6 CIDR":       {input: "::/0", want: "::/0", ok: true},
+		"App Name":        {input: "web", ok: false},
+		"Invalid Address": {input: "300.0.0.1", ok: false},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := ParseNetworkCIDR(test.input)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestSplitNetworkSources(t *testing.T) {
+	t.Parallel()
+
+	cidrs, apps := SplitNetworkSources([]string{"web", "10.0.0.1", "worker", "web", "192.168.0.0/16"})
+	assert.Equal(t, []string{"10.0.0.1/32", "192.168.0.0/16"}, cidrs)
+	assert.Equal(t, []string{"web", "worker"}, apps)
+
+	cidrs, apps = SplitNetworkSources(nil)
+	assert.Nil(t, cidrs)
+	assert.Nil(t, apps)
+}
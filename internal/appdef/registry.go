@@ -0,0 +1,80 @@
+package appdef
+
+import "strings"
+
+type (
+	// Registry defines the container registry that app images are
+	// pushed to by the release workflow and pulled from when deployed.
+	Registry struct {
+		Type      RegistryType `json:"type,omitempty" validate:"omitempty,oneof=ghcr docr dockerhub custom" description:"Container registry to push images to (ghcr, docr, dockerhub, custom). Defaults to ghcr."`
+		Host      string       `json:"host,omitempty" description:"Hostname of a self-hosted registry, e.g. registry.example.com (custom only)"`
+		Namespace string       `json:"namespace,omitempty" description:"Registry name (docr), user or organisation (dockerhub) or path prefix (custom) images are stored under. Defaults to the repo owner for ghcr."`
+	}
+	// RegistryType defines the container registry provider.
+	RegistryType string
+)
+
+// RegistryType constants.
+const (
+	RegistryTypeGHCR      RegistryType = "ghcr"
+	RegistryTypeDOCR      RegistryType = "docr"
+	RegistryTypeDockerHub RegistryType = "dockerhub"
+	RegistryTypeCustom    RegistryType = "custom"
+)
+
+// String implements fmt.Stringer on RegistryType.
+func (r RegistryType) String() string {
+	return string(r)
+}
+
+// Hostname returns the host images are pushed to, e.g. ghcr.io.
+func (r Registry) Hostname() string {
+	switch r.Type {
+	case RegistryTypeDOCR:
+		return "registry.digitalocean.com"
+	case RegistryTypeDockerHub:
+		return "docker.io"
+	case RegistryTypeCustom:
+		return r.Host
+	default:
+		return "ghcr.io"
+	}
+}
+
+// NamespaceOrOwner returns the namespace images are stored under,
+// defaulting to the repo owner for GHCR.
+func (r Registry) NamespaceOrOwner(owner string) string {
+	if r.Namespace == "" && (r.Type == "" || r.Type == RegistryTypeGHCR) {
+		return owner
+	}
+	return strings.Trim(r.Namespace, "/")
+}
+
+// ImagePrefix returns the host and namespace that every image is
+// pushed under, e.g. ghcr.io/ainsleydev.
+func (r Registry) ImagePrefix(owner string) string {
+	if namespace := r.NamespaceOrOwner(owner); namespace != "" {
+		return r.Hostname() + "/" + namespace
+	}
+	return r.Hostname()
+}
+
+// Image returns the fully qualified image name of an app without a
+// tag, e.g. ghcr.io/ainsleydev/my-website-web.
+func (r Registry) Image(repo GitHubRepo, app string) string {
+	return r.ImagePrefix(repo.Owner) + "/" + ImageName(repo, app)
+}
+
+// ImageName returns the name of an app's image without the registry
+// or namespace. Images are named after the repository and app so that
+// several projects can share one namespace.
+func ImageName(repo GitHubRepo, app string) string {
+	return repo.Name + "-" + app
+}
+
+// applyDefaults sets GHCR as the registry when none is defined.
+func (r *Registry) applyDefaults() {
+	if r.Type == "" {
+		r.Type = RegistryTypeGHCR
+	}
+}
@@ -0,0 +1,50 @@
+package appdef
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Image(t *testing.T) {
+	t.Parallel()
+
+	repo := GitHubRepo{Owner: "ainsleydev", Name: "website"}
+
+	tt := map[string]struct {
+		input Registry
+		want  string
+	}{
+		"Default": {
+			input: Registry{},
+			want:  "ghcr.io/ainsleydev/website-web",
+		},
+		"GHCR With Namespace": {
+			input: Registry{Type: RegistryTypeGHCR, Namespace: "acme"},
+			want:  "ghcr.io/acme/website-web",
+		},
+		"DOCR": {
+			input: Registry{Type: RegistryTypeDOCR, Namespace: "acme"},
+			want:  "registry.digitalocean.com/acme/website-web",
+		},
+		"Docker Hub": {
+			input: Registry{Type: RegistryTypeDockerHub, Namespace: "acme"},
+			want:  "docker.io/acme/website-web",
+		},
+		"Custom": {
+			input: Registry{Type: RegistryTypeCustom, Host: "registry.example.com:5000", Namespace: "team/"},
+			want:  "registry.example.com:5000/team/website-web",
+		},
+		"Custom Without Namespace": {
+			input: Registry{Type: RegistryTypeCustom, Host: "registry.example.com"},
+			want:  "registry.example.com/website-web",
+		},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.want, test.input.Image(repo, "web"))
+		})
+	}
+}
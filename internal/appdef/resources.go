@@ -18,11 +18,12 @@ type (
 		Title            string               `json:"title" required:"true" validate:"required" description:"Human-readable resource name for display purposes"`
 		Type             ResourceType         `json:"type" required:"true" validate:"required,oneof=postgres s3 sqlite" description:"Type of resource to provision (postgres, s3, sqlite)"`
 		Description      string               `json:"description,omitempty" validate:"omitempty,max=200" description:"Brief description of the resource's purpose and functionality"`
-		Provider         ResourceProvider     `json:"provider" required:"true" validate:"required,oneof=digitalocean hetzner backblaze turso" description:"Cloud provider hosting this resource (digitalocean, hetzner, backblaze, turso)"`
+		Provider         ResourceProvider     `json:"provider" required:"true" validate:"required,oneof=digitalocean hetzner backblaze turso cloudflare aws" description:"Cloud provider hosting this resource (digitalocean, hetzner, backblaze, turso, cloudflare, aws)"`
 		Config           Config               `json:"config" description:"Provider-specific resource configuration (e.g., size, region, version)"`
 		Backup           ResourceBackupConfig `json:"backup,omitempty" description:"Backup configuration for the resource"`
 		Monitoring       *bool                `json:"monitoring,omitempty" description:"Whether to enable uptime monitoring for this resource (defaults to true)"`
 		TerraformManaged *bool                `json:"terraformManaged,omitempty" description:"Whether this resource is managed by Terraform (defaults to true)"`
+		Network          Network              `json:"network,omitzero" description:"Trusted sources and private network placement for the resource"`
 	}
 	// ResourceBackupConfig defines backup behaviour for a resource.
 	// Backups are enabled by default for all resources that support them.
@@ -55,6 +56,8 @@ const (
 	ResourceProviderHetzner      ResourceProvider = "hetzner"
 	ResourceProviderBackBlaze    ResourceProvider = "backblaze"
 	ResourceProviderTurso        ResourceProvider = "turso"
+	ResourceProviderCloudflare   ResourceProvider = "cloudflare"
+	ResourceProviderAWS          ResourceProvider = "aws"
 )
 
 // String implements fmt.Stringer on the ResourceProvider.
@@ -38,6 +38,8 @@ func (d *Definition) Validate(fs afero.Fs) []error {
 	errs = append(errs, d.validateTerraformManagedVMs()...)
 	errs = append(errs, d.validateEnvReferences()...)
 	errs = append(errs, d.validateMonitors()...)
+	errs = append(errs, d.validateNetwork()...)
+	errs = append(errs, d.validateRegistry()...)
 
 	// Return nil if no errors
 	if len(errs) == 0 {
@@ -85,7 +87,9 @@ func validateAlphanumDash(fl validator.FieldLevel) bool {
 	return matched
 }
 
-// validateDomains ensures that domain names do not contain protocol prefixes.
+// validateDomains ensures that domain names do not contain protocol prefixes
+// and that every managed domain belongs to a DNS zone that records can be
+// created in.
 func (d *Definition) validateDomains() []error {
 	var errs []error
 
@@ -97,6 +101,37 @@ func (d *Definition) validateDomains() []error {
 					app.Name,
 					domain.Name,
 				))
+				continue
+			}
+
+			if !domain.IsManaged() {
+				continue
+			}
+
+			zone := domain.ZoneName()
+			name := strings.TrimPrefix(strings.TrimSuffix(domain.Name, "."), "*.")
+			switch {
+			case zone == "":
+				errs = append(errs, fmt.Errorf(
+					"app %q: unable to determine DNS zone for managed domain %q, set a zone or mark it as unmanaged",
+					app.Name,
+					domain.Name,
+				))
+			case name != zone && !strings.HasSuffix(name, "."+zone):
+				errs = append(errs, fmt.Errorf(
+					"app %q: managed domain %q is not within DNS zone %q",
+					app.Name,
+					domain.Name,
+					zone,
+				))
+			}
+
+			if domain.Proxied && domain.DNS != DNSProviderCloudflare {
+				errs = append(errs, fmt.Errorf(
+					"app %q: domain %q can only be proxied when its DNS is managed by cloudflare",
+					app.Name,
+					domain.Name,
+				))
 			}
 		}
 	}
@@ -191,6 +226,13 @@ func (d *Definition) validateEnvVarReferences(
 
 	// Walk through all env vars
 	err := env.WalkE(func(entry EnvWalkEntry) error {
+		if entry.Source.IsSecretBackend() {
+			if err := validateSecretReference(entry); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", context, err))
+			}
+			return nil
+		}
+
 		// Only validate resource references
 		if entry.Source != EnvSourceResource {
 			return nil
@@ -256,6 +298,41 @@ func (d *Definition) validateEnvVarReferences(
 	return errs
 }
 
+// validateSecretReference checks that a secret backend reference is in
+// the format its backend expects.
+func validateSecretReference(entry EnvWalkEntry) error {
+	path, _, ok := ParseSecretReference(entry.Value)
+
+	expected := ""
+	switch entry.Source {
+	case EnvSourceVault:
+		ok = ok && path != ""
+		expected = "path#key"
+	case EnvSourceOnePassword:
+		ok = ok && strings.Count(path, "/") == 1 && !strings.HasPrefix(path, "/") && !strings.HasSuffix(path, "/")
+		expected = "vault/item#field"
+	case EnvSourceDoppler:
+		ok = ok && (path == "" || strings.Count(path, "/") == 1)
+		expected = "NAME or project/config#NAME"
+	case EnvSourceGitHub:
+		ok = ok && path == ""
+		expected = "the secret name"
+	}
+
+	if !ok {
+		return fmt.Errorf(
+			"env var %q in %s has invalid %s reference %q (expected %s)",
+			entry.Key,
+			entry.Environment,
+			entry.Source,
+			entry.Value,
+			expected,
+		)
+	}
+
+	return nil
+}
+
 // validateUniqueNames ensures that all app and utility names are unique within
 // the definition, preventing ambiguity and duplicate CI job generation.
 func (d *Definition) validateUniqueNames() []error {
@@ -305,3 +382,109 @@ func (d *Definition) validateMonitors() []error {
 
 	return errs
 }
+
+// validateNetwork ensures that network blocks are only declared where
+// firewall rules can be generated, and that every source is either a
+// CIDR block or an app that can be identified as a source.
+func (d *Definition) validateNetwork() []error {
+	var errs []error
+
+	apps := make(map[string]App, len(d.Apps))
+	for _, app := range d.Apps {
+		apps[app.Name] = app
+	}
+
+	// validateSources checks each source of an app or resource, allowing
+	// App Platform apps only where they can be identified (databases).
+	validateSources := func(kind, name string, sources []string, allowContainers bool) {
+		_, names := SplitNetworkSources(sources)
+		for _, source := range names {
+			app, ok := apps[source]
+			switch {
+			case !ok:
+				errs = append(errs, fmt.Errorf(
+					"%s %q: network source %q is not an app in app.json or a valid CIDR block", kind, name, source))
+			case kind == "app" && source == name:
+				errs = append(errs, fmt.Errorf(
+					"app %q: network source %q cannot be the app itself", name, source))
+			case app.IsNetworkVM():
+			case allowContainers && app.Infra.Provider == ResourceProviderDigitalOcean && app.Infra.Type == "container":
+			default:
+				errs = append(errs, fmt.Errorf(
+					"%s %q: network source %q must be a digitalocean or hetzner VM app", kind, name, source))
+			}
+		}
+	}
+
+	for _, app := range d.Apps {
+		if app.Network.IsZero() {
+			continue
+		}
+		if !app.IsNetworkVM() {
+			errs = append(errs, fmt.Errorf(
+				"app %q: network is only supported for digitalocean and hetzner VM apps", app.Name))
+			continue
+		}
+		if len(app.Network.TrustedSources) > 0 {
+			errs = append(errs, fmt.Errorf(
+				"app %q: network.trustedSources only applies to resources, use network.inbound for apps", app.Name))
+		}
+		for _, rule := range app.Network.Inbound {
+			validateSources("app", app.Name, rule.Sources, false)
+		}
+	}
+
+	for _, res := range d.Resources {
+		if res.Network.IsZero() {
+			continue
+		}
+		if res.Provider != ResourceProviderDigitalOcean || res.Type != ResourceTypePostgres {
+			errs = append(errs, fmt.Errorf(
+				"resource %q: network is only supported for digitalocean postgres resources", res.Name))
+			continue
+		}
+		if len(res.Network.Inbound) > 0 {
+			errs = append(errs, fmt.Errorf(
+				"resource %q: network.inbound only applies to apps, use network.trustedSources for resources", res.Name))
+		}
+		_, hasIPs := res.Config["allowed_ips_addr"]
+		_, hasDroplets := res.Config["allowed_droplet_ips"]
+		if len(res.Network.TrustedSources) > 0 && (hasIPs || hasDroplets) {
+			errs = append(errs, fmt.Errorf(
+				"resource %q: network.trustedSources replaces config.allowed_ips_addr and config.allowed_droplet_ips, remove them", res.Name))
+		}
+		validateSources("resource", res.Name, res.Network.TrustedSources, true)
+	}
+
+	return errs
+}
+
+// validateRegistry ensures the container registry has everything needed
+// to name images, and that App Platform apps can pull from it.
+func (d *Definition) validateRegistry() []error {
+	var errs []error
+
+	registry := d.Infra.Registry
+	switch registry.Type {
+	case RegistryTypeDOCR, RegistryTypeDockerHub:
+		if registry.Namespace == "" {
+			errs = append(errs, fmt.Errorf(
+				"infra.registry: namespace is required for %s registries", registry.Type))
+		}
+	case RegistryTypeCustom:
+		if registry.Host == "" {
+			errs = append(errs, errors.New("infra.registry: host is required for custom registries"))
+		} else if strings.Contains(registry.Host, "://") || strings.Contains(registry.Host, "/") {
+			errs = append(errs, fmt.Errorf(
+				"infra.registry: host %q should be a hostname without a protocol or path", registry.Host))
+		}
+		for _, app := range d.Apps {
+			if app.Infra.Provider == ResourceProviderDigitalOcean && app.Infra.Type == "container" {
+				errs = append(errs, fmt.Errorf(
+					"app %q: digitalocean App Platform can only pull from ghcr, docr or dockerhub registries", app.Name))
+			}
+		}
+	}
+
+	return errs
+}
@@ -311,6 +311,119 @@ func TestDefinition_ValidateDomains(t *testing.T) {
 				`domain "ftp://files.example.com" should not contain protocol prefix`,
 			},
 		},
+		"Managed Domain Without Zone": {
+			input: &Definition{
+				Apps: []App{
+					{
+						Name: "test-app",
+						Domains: []Domain{
+							{Name: "localhost", Type: DomainTypePrimary},
+						},
+					},
+				},
+			},
+			wantErrs: []string{
+				`app "test-app": unable to determine DNS zone for managed domain "localhost"`,
+			},
+		},
+		"Managed Domain Outside Zone": {
+			input: &Definition{
+				Apps: []App{
+					{
+						Name: "test-app",
+						Domains: []Domain{
+							{Name: "api.example.com", Type: DomainTypePrimary, Zone: "other.com"},
+						},
+					},
+				},
+			},
+			wantErrs: []string{
+				`app "test-app": managed domain "api.example.com" is not within DNS zone "other.com"`,
+			},
+		},
+		"Managed Domains Within Explicit Zone": {
+			input: &Definition{
+				Apps: []App{
+					{
+						Name: "test-app",
+						Domains: []Domain{
+							{Name: "api.example.co.uk", Type: DomainTypePrimary, Zone: "example.co.uk"},
+							{Name: "*.staging.example.co.uk", Type: DomainTypeAlias, Zone: "example.co.uk", Wildcard: true},
+						},
+					},
+				},
+			},
+			wantErrs: []string{},
+		},
+		"Managed Domain Under Multi-label Suffix": {
+			input: &Definition{
+				Apps: []App{
+					{
+						Name: "test-app",
+						Domains: []Domain{
+							{Name: "shop.example.co.uk", Type: DomainTypePrimary},
+						},
+					},
+				},
+			},
+			wantErrs: []string{},
+		},
+		"Managed Public Suffix": {
+			input: &Definition{
+				Apps: []App{
+					{
+						Name: "test-app",
+						Domains: []Domain{
+							{Name: "co.uk", Type: DomainTypePrimary},
+						},
+					},
+				},
+			},
+			wantErrs: []string{
+				`app "test-app": unable to determine DNS zone for managed domain "co.uk"`,
+			},
+		},
+		"Proxied Domain With Cloudflare DNS": {
+			input: &Definition{
+				Apps: []App{
+					{
+						Name: "test-app",
+						Domains: []Domain{
+							{Name: "example.com", Type: DomainTypePrimary, DNS: DNSProviderCloudflare, Proxied: true},
+						},
+					},
+				},
+			},
+			wantErrs: []string{},
+		},
+		"Proxied Domain Without Cloudflare DNS": {
+			input: &Definition{
+				Apps: []App{
+					{
+						Name: "test-app",
+						Domains: []Domain{
+							{Name: "example.com", Type: DomainTypePrimary, DNS: DNSProviderHetzner, Proxied: true},
+						},
+					},
+				},
+			},
+			wantErrs: []string{
+				`app "test-app": domain "example.com" can only be proxied when its DNS is managed by cloudflare`,
+			},
+		},
+		"Unmanaged Domain Skips Zone Check": {
+			input: &Definition{
+				Apps: []App{
+					{
+						Name: "test-app",
+						Domains: []Domain{
+							{Name: "localhost", Type: DomainTypeUnmanaged},
+						},
+					},
+				},
+			},
+			wantErrs: []string{},
+		},
 		"Empty Domains List": {
 			input: &Definition{
 				Apps: []App{
@@ -841,6 +954,42 @@ func TestDefinition_ValidateEnvReferences(t *testing.T) {
 				`env var "DB_URL" in staging references non-existent resource "missing"`,
 			},
 		},
+		"Valid Secret Backend References": {
+			input: &Definition{
+				Shared: Shared{
+					Env: Environment{
+						Production: EnvVar{
+							"VAULT":   EnvValue{Source: EnvSourceVault, Value: "secret/data/web#api_key"},
+							"OP":      EnvValue{Source: EnvSourceOnePassword, Value: "production/stripe#secret_key"},
+							"DOPPLER": EnvValue{Source: EnvSourceDoppler, Value: "website/prd#STRIPE_KEY"},
+							"TOKEN":   EnvValue{Source: EnvSourceDoppler, Value: "STRIPE_KEY"},
+							"GITHUB":  EnvValue{Source: EnvSourceGitHub, Value: "STRIPE_KEY"},
+						},
+					},
+				},
+			},
+			wantErrs: []string{},
+		},
+		"Invalid Secret Backend References": {
+			input: &Definition{
+				Shared: Shared{
+					Env: Environment{
+						Production: EnvVar{
+							"VAULT":   EnvValue{Source: EnvSourceVault, Value: "api_key"},
+							"OP":      EnvValue{Source: EnvSourceOnePassword, Value: "stripe#secret_key"},
+							"DOPPLER": EnvValue{Source: EnvSourceDoppler, Value: "website#STRIPE_KEY"},
+							"GITHUB":  EnvValue{Source: EnvSourceGitHub, Value: "repo#STRIPE_KEY"},
+						},
+					},
+				},
+			},
+			wantErrs: []string{
+				`shared: env var "VAULT" in production has invalid vault reference "api_key" (expected path#key)`,
+				`env var "OP" in production has invalid 1password reference "stripe#secret_key" (expected vault/item#field)`,
+				`env var "DOPPLER" in production has invalid doppler reference`,
+				`env var "GITHUB" in production has invalid github reference`,
+			},
+		},
 	}
 
 	for name, test := range tt {
@@ -1256,3 +1405,157 @@ func TestDefinition_ValidateMonitors_IntervalValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestDefinition_ValidateNetwork(t *testing.T) {
+	t.Parallel()
+
+	doVM := func(name string, network Network) App {
+		return App{Name: name, Infra: Infra{Provider: ResourceProviderDigitalOcean, Type: "vm"}, Network: network}
+	}
+	doContainer := App{Name: "api", Infra: Infra{Provider: ResourceProviderDigitalOcean, Type: "container"}}
+	db := func(network Network) Resource {
+		return Resource{Name: "db", Type: ResourceTypePostgres, Provider: ResourceProviderDigitalOcean, Network: network}
+	}
+
+	tt := map[string]struct {
+		input    *Definition
+		wantErrs []string
+	}{
+		"No Network": {
+			input:    &Definition{Apps: []App{doVM("web", Network{})}, Resources: []Resource{db(Network{})}},
+			wantErrs: nil,
+		},
+		"Valid": {
+			input: &Definition{
+				Apps: []App{
+					doVM("web", Network{
+						Inbound: []NetworkRule{
+							{Port: 22, Sources: []string{"203.0.113.0/24"}},
+							{Port: 443},
+							{Port: 8080, Sources: []string{"worker"}},
+						},
+						VPC: true,
+					}),
+					doVM("worker", Network{}),
+					doContainer,
+				},
+				Resources: []Resource{db(Network{TrustedSources: []string{"web", "api", "10.0.0.1"}, VPC: true})},
+			},
+			wantErrs: nil,
+		},
+		"Unsupported App": {
+			input: &Definition{Apps: []App{
+				{Name: "api", Infra: Infra{Provider: ResourceProviderDigitalOcean, Type: "container"}, Network: Network{VPC: true}},
+			}},
+			wantErrs: []string{`app "api": network is only supported for digitalocean and hetzner VM apps`},
+		},
+		"Trusted Sources On App": {
+			input:    &Definition{Apps: []App{doVM("web", Network{TrustedSources: []string{"10.0.0.0/8"}})}},
+			wantErrs: []string{`app "web": network.trustedSources only applies to resources`},
+		},
+		"Unknown Source": {
+			input: &Definition{Apps: []App{doVM("web", Network{Inbound: []NetworkRule{{Port: 22, Sources: []string{"office"}}}})}},
+			wantErrs: []string{
+				`app "web": network source "office" is not an app in app.json or a valid CIDR block`,
+			},
+		},
+		"Self Source": {
+			input:    &Definition{Apps: []App{doVM("web", Network{Inbound: []NetworkRule{{Port: 22, Sources: []string{"web"}}}})}},
+			wantErrs: []string{`app "web": network source "web" cannot be the app itself`},
+		},
+		"Container Source For VM": {
+			input: &Definition{Apps: []App{
+				doVM("web", Network{Inbound: []NetworkRule{{Port: 8080, Sources: []string{"api"}}}}),
+				doContainer,
+			}},
+			wantErrs: []string{`app "web": network source "api" must be a digitalocean or hetzner VM app`},
+		},
+		"Unsupported Resource": {
+			input: &Definition{Resources: []Resource{
+				{Name: "files", Type: ResourceTypeS3, Provider: ResourceProviderBackBlaze, Network: Network{VPC: true}},
+			}},
+			wantErrs: []string{`resource "files": network is only supported for digitalocean postgres resources`},
+		},
+		"Inbound On Resource": {
+			input:    &Definition{Resources: []Resource{db(Network{Inbound: []NetworkRule{{Port: 5432}}})}},
+			wantErrs: []string{`resource "db": network.inbound only applies to apps`},
+		},
+		"Trusted Sources With Allowed IPs": {
+			input: &Definition{Resources: []Resource{{
+				Name:     "db",
+				Type:     ResourceTypePostgres,
+				Provider: ResourceProviderDigitalOcean,
+				Config:   Config{"allowed_ips_addr": []any{"10.0.0.1"}},
+				Network:  Network{TrustedSources: []string{"10.0.0.2"}},
+			}}},
+			wantErrs: []string{`resource "db": network.trustedSources replaces config.allowed_ips_addr`},
+		},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			errs := test.input.validateNetwork()
+			require.Len(t, errs, len(test.wantErrs), "unexpected errors: %v", errs)
+			for i, want := range test.wantErrs {
+				assert.Contains(t, errs[i].Error(), want)
+			}
+		})
+	}
+}
+
+func TestDefinition_ValidateRegistry(t *testing.T) {
+	t.Parallel()
+
+	container := App{Name: "web", Infra: Infra{Provider: ResourceProviderDigitalOcean, Type: "container"}}
+
+	tt := map[string]struct {
+		input    *Definition
+		wantErrs []string
+	}{
+		"Default": {
+			input:    &Definition{Apps: []App{container}},
+			wantErrs: nil,
+		},
+		"GHCR": {
+			input:    &Definition{Infra: ProjectInfra{Registry: Registry{Type: RegistryTypeGHCR}}},
+			wantErrs: nil,
+		},
+		"DOCR Without Namespace": {
+			input:    &Definition{Infra: ProjectInfra{Registry: Registry{Type: RegistryTypeDOCR}}},
+			wantErrs: []string{"infra.registry: namespace is required for docr registries"},
+		},
+		"Docker Hub": {
+			input:    &Definition{Infra: ProjectInfra{Registry: Registry{Type: RegistryTypeDockerHub, Namespace: "ainsleydev"}}},
+			wantErrs: nil,
+		},
+		"Custom Without Host": {
+			input:    &Definition{Infra: ProjectInfra{Registry: Registry{Type: RegistryTypeCustom}}},
+			wantErrs: []string{"infra.registry: host is required for custom registries"},
+		},
+		"Custom Host With Protocol": {
+			input:    &Definition{Infra: ProjectInfra{Registry: Registry{Type: RegistryTypeCustom, Host: "https://registry.example.com"}}},
+			wantErrs: []string{`infra.registry: host "https://registry.example.com" should be a hostname`},
+		},
+		"Custom With App Platform": {
+			input: &Definition{
+				Infra: ProjectInfra{Registry: Registry{Type: RegistryTypeCustom, Host: "registry.example.com:5000"}},
+				Apps:  []App{container},
+			},
+			wantErrs: []string{`app "web": digitalocean App Platform can only pull from ghcr, docr or dockerhub registries`},
+		},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			errs := test.input.validateRegistry()
+			require.Len(t, errs, len(test.wantErrs), "unexpected errors: %v", errs)
+			for i, want := range test.wantErrs {
+				assert.Contains(t, errs[i].Error(), want)
+			}
+		})
+	}
+}
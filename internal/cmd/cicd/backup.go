@@ -32,8 +32,9 @@ func filterBackupableResources(resources []appdef.Resource) []appdef.Resource {
 		// Check provider compatibility.
 		switch resource.Type {
 		case appdef.ResourceTypeS3:
-			// S3 backup only compatible with DigitalOcean Spaces.
-			if resource.Provider != appdef.ResourceProviderDigitalOcean {
+			// S3 backup only compatible with DigitalOcean Spaces and AWS S3.
+			if resource.Provider != appdef.ResourceProviderDigitalOcean &&
+				resource.Provider != appdef.ResourceProviderAWS {
 				continue
 			}
 		case appdef.ResourceTypeSQLite:
@@ -75,6 +76,7 @@ func BackupWorkflow(_ context.Context, input cmdtools.CommandInput) error {
 		case appdef.ResourceTypePostgres:
 			resourceSecrets["DatabaseURL"] = resource.GitHubSecretName(enviro, "connection_url")
 			resourceSecrets["DatabaseID"] = resource.GitHubSecretName(enviro, "id")
+			resourceSecrets["DatabaseURN"] = resource.GitHubSecretName(enviro, "urn")
 
 		case appdef.ResourceTypeS3:
 			resourceSecrets["AccessKey"] = resource.GitHubSecretName(enviro, "access_key")
@@ -119,6 +119,55 @@ func TestBackupWorkflow(t *testing.T) {
 		assert.NotContains(t, content, "${{ vars._CODEBASE_BACKUP_PING_URL }}")
 	})
 
+	t.Run("AWS", func(t *testing.T) {
+		t.Parallel()
+
+		appDef := &appdef.Definition{
+			Project: appdef.Project{
+				Name: "test-project",
+			},
+			Resources: []appdef.Resource{
+				{
+					Name:     "db",
+					Type:     appdef.ResourceTypePostgres,
+					Provider: appdef.ResourceProviderAWS,
+					Backup: appdef.ResourceBackupConfig{
+						Enabled: ptr.BoolPtr(true),
+					},
+				},
+				{
+					Name:     "store",
+					Type:     appdef.ResourceTypeS3,
+					Provider: appdef.ResourceProviderAWS,
+					Backup: appdef.ResourceBackupConfig{
+						Enabled: ptr.BoolPtr(true),
+					},
+				},
+			},
+		}
+
+		input := setup(t, afero.NewMemMapFs(), appDef)
+
+		got := BackupWorkflow(t.Context(), input)
+		assert.NoError(t, got)
+
+		file, err := afero.ReadFile(input.FS, filepath.Join(workflowsPath, "backup.yaml"))
+		require.NoError(t, err)
+
+		err = validateGithubYaml(t, file, false)
+		assert.NoError(t, err)
+
+		content := string(file)
+		assert.Contains(t, content, "backup-resource-db:")
+		assert.Contains(t, content, "backup-resource-store:")
+		assert.Contains(t, content, "aws ec2 authorize-security-group-ingress")
+		assert.Contains(t, content, "${{ secrets.TF_PROD_DB_URN }}")
+		assert.Contains(t, content, "provider AWS")
+		assert.Contains(t, content, "rclone sync aws_s3:")
+		assert.NotContains(t, content, "doctl")
+		assert.NotContains(t, content, "digitaloceanspaces.com")
+	})
+
 	t.Run("S3 Non DigitalOcean Skipped", func(t *testing.T) {
 		t.Parallel()
 
@@ -15,8 +15,10 @@ var Command = &cli.Command{
 	Commands: []*cli.Command{
 		ActionsCmd,
 		BackupCmd,
+		InfraDriftCmd,
 		PRCmd,
 		ReleaseCmd,
+		RollbackCmd,
 		VMMaintenanceCmd,
 	},
 }
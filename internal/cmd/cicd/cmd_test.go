@@ -1,6 +1,7 @@
 package cicd
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/spf13/afero"
@@ -43,3 +44,14 @@ func validateGithubYaml(t *testing.T, file []byte, isAction bool) error {
 
 	return nil
 }
+
+// ageKeyEnv returns the multi-line SOPS_AGE_KEY env entry holding every
+// age key secret, indented to the given depth.
+func ageKeyEnv(indent int) string {
+	pad := strings.Repeat(" ", indent)
+	return pad + "SOPS_AGE_KEY: |\n" +
+		pad + "  ${{ secrets.REPO_AGE_SECRET_DEVELOPMENT }}\n" +
+		pad + "  ${{ secrets.REPO_AGE_SECRET_STAGING }}\n" +
+		pad + "  ${{ secrets.REPO_AGE_SECRET_PRODUCTION }}\n" +
+		pad + "  ${{ secrets.ORG_AGE_SECRET }}\n"
+}
@@ -0,0 +1,48 @@
+package cicd
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/infra"
+	"github.com/ainsleydev/webkit/internal/scaffold"
+	"github.com/ainsleydev/webkit/internal/secrets"
+	"github.com/ainsleydev/webkit/internal/state/manifest"
+	"github.com/ainsleydev/webkit/internal/templates"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+var InfraDriftCmd = &cli.Command{
+	Name:   "infra-drift",
+	Usage:  "Generate a scheduled workflow that detects infrastructure drift",
+	Action: cmdtools.Wrap(InfraDriftWorkflow),
+}
+
+// InfraDriftWorkflow creates a scheduled workflow that checks production
+// for infrastructure changed outside of Terraform and opens, or updates,
+// a GitHub issue when drift is found.
+func InfraDriftWorkflow(_ context.Context, input cmdtools.CommandInput) error {
+	appDef := input.AppDef()
+
+	// Only generate the workflow if there's Terraform state to drift from.
+	filtered, _ := appDef.FilterTerraformManaged()
+	if len(filtered.Apps) == 0 && len(filtered.Resources) == 0 {
+		return nil
+	}
+
+	tpl := templates.MustLoadTemplate(filepath.Join(workflowsPath, "infra-drift.yaml.tmpl"))
+	path := filepath.Join(workflowsPath, "infra-drift.yaml")
+
+	data := map[string]any{
+		"Env":              env.Production,
+		"TerraformVersion": infra.TerraformVersion,
+		"InfraBackend":     appDef.Infra.Backend.String(),
+		"OpenTofuVersion":  infra.OpenTofuVersion,
+		"SecretEnv":        secrets.BackendEnvVars(appDef),
+	}
+
+	return input.Generator().Template(path, tpl, data, scaffold.WithTracking(manifest.SourceProject()))
+}
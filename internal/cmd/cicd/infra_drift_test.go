@@ -0,0 +1,132 @@
+package cicd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/pkg/util/ptr"
+)
+
+func TestInfraDriftWorkflow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("No Terraform Managed Items", func(t *testing.T) {
+		t.Parallel()
+
+		appDef := &appdef.Definition{
+			Project: appdef.Project{Name: "test-project"},
+			Resources: []appdef.Resource{
+				{
+					Name:             "db",
+					Type:             appdef.ResourceTypePostgres,
+					Provider:         appdef.ResourceProviderDigitalOcean,
+					TerraformManaged: ptr.BoolPtr(false),
+				},
+			},
+		}
+
+		input := setup(t, afero.NewMemMapFs(), appDef)
+
+		got := InfraDriftWorkflow(t.Context(), input)
+		assert.NoError(t, got)
+
+		exists, err := afero.Exists(input.FS, filepath.Join(workflowsPath, "infra-drift.yaml"))
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("Terraform", func(t *testing.T) {
+		t.Parallel()
+
+		appDef := &appdef.Definition{
+			Project: appdef.Project{Name: "test-project"},
+			Resources: []appdef.Resource{
+				{Name: "db", Type: appdef.ResourceTypePostgres, Provider: appdef.ResourceProviderDigitalOcean},
+			},
+		}
+
+		input := setup(t, afero.NewMemMapFs(), appDef)
+
+		got := InfraDriftWorkflow(t.Context(), input)
+		assert.NoError(t, got)
+
+		file, err := afero.ReadFile(input.FS, filepath.Join(workflowsPath, "infra-drift.yaml"))
+		require.NoError(t, err)
+
+		err = validateGithubYaml(t, file, false)
+		assert.NoError(t, err)
+
+		content := string(file)
+		assert.Contains(t, content, "schedule:")
+		assert.Contains(t, content, "issues: write")
+		assert.Contains(t, content, "./webkit infra drift --env production --format markdown --silent")
+		assert.Contains(t, content, `if [ "$status" -eq 2 ]; then`, "Only the drift exit code should open an issue")
+		assert.Contains(t, content, "gh issue create")
+		assert.Contains(t, content, "SOPS_AGE_KEY: ${{ secrets.REPO_AGE_SECRET_PRODUCTION || secrets.ORG_AGE_SECRET }}")
+		assert.NotContains(t, content, "backend: opentofu")
+	})
+
+	t.Run("Secret Backends", func(t *testing.T) {
+		t.Parallel()
+
+		appDef := &appdef.Definition{
+			Project: appdef.Project{Name: "test-project"},
+			Shared: appdef.Shared{
+				Env: appdef.Environment{
+					Production: appdef.EnvVar{
+						"STRIPE_KEY":  {Source: appdef.EnvSourceVault, Value: "secret/data/web#stripe"},
+						"MAILGUN_KEY": {Source: appdef.EnvSourceGitHub, Value: "MAILGUN_KEY"},
+					},
+				},
+			},
+			Resources: []appdef.Resource{
+				{Name: "db", Type: appdef.ResourceTypePostgres, Provider: appdef.ResourceProviderDigitalOcean},
+			},
+		}
+
+		input := setup(t, afero.NewMemMapFs(), appDef)
+
+		got := InfraDriftWorkflow(t.Context(), input)
+		assert.NoError(t, got)
+
+		file, err := afero.ReadFile(input.FS, filepath.Join(workflowsPath, "infra-drift.yaml"))
+		require.NoError(t, err)
+
+		err = validateGithubYaml(t, file, false)
+		assert.NoError(t, err)
+
+		content := string(file)
+		assert.Contains(t, content, "          VAULT_ADDR: ${{ secrets.VAULT_ADDR }}\n")
+		assert.Contains(t, content, "          VAULT_TOKEN: ${{ secrets.VAULT_TOKEN }}\n")
+		assert.Contains(t, content, "          MAILGUN_KEY: ${{ secrets.MAILGUN_KEY }}\n")
+	})
+
+	t.Run("OpenTofu", func(t *testing.T) {
+		t.Parallel()
+
+		appDef := &appdef.Definition{
+			Project: appdef.Project{Name: "test-project"},
+			Infra:   appdef.ProjectInfra{Backend: appdef.InfraBackendOpenTofu},
+			Apps: []appdef.App{
+				{Name: "web", Type: appdef.AppTypeGoLang, Infra: appdef.Infra{Provider: appdef.ResourceProviderDigitalOcean, Type: "vm"}},
+			},
+		}
+
+		input := setup(t, afero.NewMemMapFs(), appDef)
+
+		got := InfraDriftWorkflow(t.Context(), input)
+		assert.NoError(t, got)
+
+		file, err := afero.ReadFile(input.FS, filepath.Join(workflowsPath, "infra-drift.yaml"))
+		require.NoError(t, err)
+
+		err = validateGithubYaml(t, file, false)
+		assert.NoError(t, err)
+		assert.Contains(t, string(file), "backend: opentofu")
+	})
+}
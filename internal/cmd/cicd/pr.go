@@ -10,6 +10,7 @@ import (
 	"github.com/ainsleydev/webkit/internal/cmdtools"
 	"github.com/ainsleydev/webkit/internal/infra"
 	"github.com/ainsleydev/webkit/internal/scaffold"
+	"github.com/ainsleydev/webkit/internal/secrets"
 	"github.com/ainsleydev/webkit/internal/state/manifest"
 	"github.com/ainsleydev/webkit/internal/templates"
 	"github.com/ainsleydev/webkit/pkg/env"
@@ -41,6 +42,9 @@ func PR(_ context.Context, input cmdtools.CommandInput) error {
 			"Utilities":           appDef.Utilities,
 			"PayloadPostgresApps": findPayloadAppsWithPostgres(appDef.Apps, appDef.Resources),
 			"TerraformVersion":    infra.TerraformVersion,
+			"InfraBackend":        appDef.Infra.Backend.String(),
+			"OpenTofuVersion":     infra.OpenTofuVersion,
+			"SecretEnv":           secrets.BackendEnvVars(appDef),
 		},
 		scaffold.WithTracking(manifest.SourceProject()),
 	)
@@ -55,6 +55,12 @@ func TestPR(t *testing.T) {
 			assert.Contains(t, content, "drift-detection:")
 		}
 
+		t.Log("Secrets Validation")
+		{
+			assert.Contains(t, content, "./webkit secrets validate --check-orphans --format json")
+			assert.Contains(t, content, "steps.secrets.outputs.secrets_failed == 'true'")
+		}
+
 		t.Log("Apps")
 		{
 			for _, app := range appDef.Apps {
@@ -287,6 +293,7 @@ func TestPR(t *testing.T) {
 			assert.Contains(t, content, "needs: [setup-webkit, detect-changes]")
 			assert.Contains(t, content, "<!-- terraform-plan -->")
 			assert.Contains(t, content, "Infra Plan")
+			assert.Contains(t, content, ageKeyEnv(10))
 		}
 	})
 
@@ -324,6 +331,43 @@ func TestPR(t *testing.T) {
 			assert.Contains(t, content, "TF_VERSION: '1.13.0'", "workflow should contain correct Terraform version")
 			assert.NotContains(t, content, "TF_VERSION: '<no value>'", "workflow should not contain '<no value>' placeholder")
 		}
+
+		t.Log("Defaults to Terraform")
+		{
+			assert.NotContains(t, content, "backend: opentofu")
+		}
+	})
+
+	t.Run("OpenTofu Backend", func(t *testing.T) {
+		t.Parallel()
+
+		appDef := &appdef.Definition{
+			Infra: appdef.ProjectInfra{Backend: appdef.InfraBackendOpenTofu},
+			Apps: []appdef.App{
+				{
+					Name:     "web",
+					Title:    "Web",
+					Path:     "./web",
+					Type:     appdef.AppTypeGoLang,
+					Language: "go",
+				},
+			},
+		}
+
+		input := setup(t, afero.NewMemMapFs(), appDef)
+
+		err := PR(t.Context(), input)
+		require.NoError(t, err)
+
+		file, err := afero.ReadFile(input.FS, filepath.Join(workflowsPath, "pr.yaml"))
+		require.NoError(t, err)
+
+		err = validateGithubYaml(t, file, false)
+		assert.NoError(t, err)
+
+		content := string(file)
+		assert.Contains(t, content, "backend: opentofu")
+		assert.Contains(t, content, "opentofu_version: '1.10.6'")
 	})
 
 	t.Run("Utility CI Jobs", func(t *testing.T) {
@@ -2,7 +2,12 @@ package cicd
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
 
 	"github.com/urfave/cli/v3"
 
@@ -10,6 +15,7 @@ import (
 	"github.com/ainsleydev/webkit/internal/cmdtools"
 	"github.com/ainsleydev/webkit/internal/infra"
 	"github.com/ainsleydev/webkit/internal/scaffold"
+	"github.com/ainsleydev/webkit/internal/secrets"
 	"github.com/ainsleydev/webkit/internal/state/manifest"
 	"github.com/ainsleydev/webkit/internal/templates"
 )
@@ -38,13 +44,23 @@ func ReleaseWorkflow(_ context.Context, input cmdtools.CommandInput) error {
 		return nil
 	}
 
+	ufwRules, err := releaseUFWRules(appsToRelease)
+	if err != nil {
+		return err
+	}
+
 	tpl := templates.MustLoadTemplate(filepath.Join(workflowsPath, "release.yaml.tmpl"))
 	path := filepath.Join(workflowsPath, "release.yaml")
 
 	data := map[string]any{
 		"Apps":             appsToRelease,
 		"TerraformVersion": infra.TerraformVersion,
+		"InfraBackend":     appDef.Infra.Backend.String(),
+		"OpenTofuVersion":  infra.OpenTofuVersion,
 		"ProjectName":      appDef.Project.Name,
+		"UFWRules":         ufwRules,
+		"Registry":         newReleaseRegistry(appDef.Infra.Registry),
+		"SecretEnv":        secrets.BackendEnvVars(appDef),
 	}
 
 	// Track all apps as sources for this workflow.
@@ -55,3 +71,99 @@ func ReleaseWorkflow(_ context.Context, input cmdtools.CommandInput) error {
 
 	return input.Generator().Template(path, tpl, data, trackingOptions...)
 }
+
+// releaseRegistry describes how the release workflow logs in and
+// pushes images to the registry configured in app.json. Username and
+// Password are GitHub Actions expressions.
+type releaseRegistry struct {
+	Title       string
+	Host        string
+	ImagePrefix string
+	Username    string
+	Password    string
+	// Cleanup is true when old images can be deleted with the
+	// GitHub packages API, which is only the case for GHCR images
+	// owned by the repository owner.
+	Cleanup bool
+}
+
+// newReleaseRegistry returns the login details and image prefix for
+// the registry. The GHCR namespace defaults to the repository owner at
+// runtime so the workflow keeps working if the repository is moved.
+func newReleaseRegistry(cfg appdef.Registry) releaseRegistry {
+	r := releaseRegistry{
+		Host:        cfg.Hostname(),
+		ImagePrefix: cfg.ImagePrefix("${{ github.repository_owner }}"),
+	}
+
+	switch cfg.Type {
+	case appdef.RegistryTypeDOCR:
+		r.Title = "DigitalOcean Container Registry"
+		r.Username = "${{ secrets.REPO_DO_ACCESS_TOKEN || secrets.ORG_DO_ACCESS_TOKEN }}"
+		r.Password = r.Username
+	case appdef.RegistryTypeDockerHub:
+		r.Title = "Docker Hub"
+		r.Username = "${{ secrets.DOCKERHUB_USERNAME }}"
+		r.Password = "${{ secrets.DOCKERHUB_TOKEN }}"
+	case appdef.RegistryTypeCustom:
+		r.Title = cfg.Host
+		r.Username = "${{ secrets.REGISTRY_USERNAME }}"
+		r.Password = "${{ secrets.REGISTRY_PASSWORD }}"
+	default:
+		r.Title = "GitHub Container Registry"
+		r.Username = "${{ github.actor }}"
+		r.Password = "${{ secrets.GITHUB_TOKEN }}"
+		r.Cleanup = cfg.Namespace == ""
+	}
+
+	return r
+}
+
+// releaseUFWRules returns the Ansible variables that open the inbound
+// ports of an app's network block through UFW, keyed by app name.
+//
+// Apps used as sources are resolved from the IP address secret of their
+// VM when the workflow runs. Apps without inbound rules are omitted so
+// the role falls back to its defaults.
+func releaseUFWRules(apps []appdef.App) (map[string]string, error) {
+	type ufwRule struct {
+		Port    int      `json:"port"`
+		Proto   string   `json:"proto"`
+		Sources []string `json:"sources"`
+	}
+
+	rules := make(map[string]string)
+	for _, app := range apps {
+		if !app.IsNetworkVM() || len(app.Network.Inbound) == 0 {
+			continue
+		}
+
+		vars := struct {
+			Rules []ufwRule `json:"ufw_rules"`
+		}{}
+
+		for _, rule := range app.Network.Inbound {
+			cidrs, sources := appdef.SplitNetworkSources(rule.Sources)
+			for _, source := range sources {
+				secret := fmt.Sprintf("TF_PROD_%s_IP_ADDRESS", strings.ToUpper(strings.ReplaceAll(source, "-", "_")))
+				cidrs = append(cidrs, fmt.Sprintf("${{ secrets.%s }}", secret))
+			}
+			if cidrs == nil {
+				cidrs = []string{}
+			}
+			vars.Rules = append(vars.Rules, ufwRule{
+				Port:    rule.Port,
+				Proto:   rule.ProtocolOrDefault(),
+				Sources: cidrs,
+			})
+		}
+
+		data, err := json.Marshal(vars)
+		if err != nil {
+			return nil, errors.Wrapf(err, "serializing ufw rules for %s", app.Name)
+		}
+		rules[app.Name] = string(data)
+	}
+
+	return rules, nil
+}
@@ -457,6 +457,7 @@ func TestReleaseWorkflow(t *testing.T) {
 			assert.Contains(t, content, "./.github/actions/setup-infra")
 			assert.Contains(t, content, "Send Slack Notification")
 			assert.Contains(t, content, "Infra Plan")
+			assert.Contains(t, content, ageKeyEnv(6))
 		}
 
 		t.Log("Infrastructure change detection via composite action")
@@ -474,4 +475,113 @@ func TestReleaseWorkflow(t *testing.T) {
 			assert.Contains(t, content, "needs: [build-and-push, terraform-apply-production]")
 		}
 	})
+	t.Run("VM Network Rules", func(t *testing.T) {
+		t.Parallel()
+
+		vm := func(name string, network appdef.Network) appdef.App {
+			return appdef.App{
+				Name:    name,
+				Title:   name,
+				Type:    appdef.AppTypeGoLang,
+				Path:    name,
+				Build:   appdef.Build{Dockerfile: "Dockerfile", Port: 8080},
+				Infra:   appdef.Infra{Provider: appdef.ResourceProviderHetzner, Type: "vm"},
+				Network: network,
+			}
+		}
+
+		appDef := &appdef.Definition{
+			Apps: []appdef.App{
+				vm("api", appdef.Network{
+					Inbound: []appdef.NetworkRule{
+						{Port: 22, Sources: []string{"203.0.113.0/24"}},
+						{Port: 8080, Sources: []string{"web-app"}},
+						{Port: 443},
+					},
+				}),
+				vm("web-app", appdef.Network{}),
+			},
+		}
+
+		input := setup(t, afero.NewMemMapFs(), appDef)
+
+		err := ReleaseWorkflow(t.Context(), input)
+		require.NoError(t, err)
+
+		file, err := afero.ReadFile(input.FS, filepath.Join(workflowsPath, "release.yaml"))
+		require.NoError(t, err)
+
+		err = validateGithubYaml(t, file, false)
+		assert.NoError(t, err)
+
+		content := string(file)
+		assert.Contains(t, content, "Write network rules for api")
+		assert.NotContains(t, content, "Write network rules for web-app")
+		assert.Contains(t, content, `{"port":22,"proto":"tcp","sources":["203.0.113.0/24"]}`)
+		assert.Contains(t, content, `{"port":8080,"proto":"tcp","sources":["${{ secrets.TF_PROD_WEB_APP_IP_ADDRESS }}"]}`)
+		assert.Contains(t, content, `{"port":443,"proto":"tcp","sources":[]}`)
+		assert.Contains(t, content, "-e @/tmp/api-network.json")
+		assert.NotContains(t, content, "-e @/tmp/web-app-network.json")
+
+		t.Log("Ansible gets every age key")
+		{
+			assert.Contains(t, content, "uses: dawidd6/action-ansible-playbook@v4\n        env:\n"+
+				"          # Read by age_secret_key below, options can't hold multi-line values.\n"+ageKeyEnv(10))
+			assert.Contains(t, content, `-e "age_secret_key={{ lookup('env', 'SOPS_AGE_KEY') }}"`)
+			assert.NotContains(t, content, "age_secret_key=${{ secrets.ORG_AGE_SECRET }}")
+		}
+	})
+	t.Run("Docker Hub Registry", func(t *testing.T) {
+		t.Parallel()
+
+		appDef := &appdef.Definition{
+			Infra: appdef.ProjectInfra{
+				Registry: appdef.Registry{Type: appdef.RegistryTypeDockerHub, Namespace: "acme"},
+			},
+			Apps: []appdef.App{
+				{
+					Name:  "web",
+					Title: "Web",
+					Type:  appdef.AppTypeGoLang,
+					Path:  "web",
+					Build: appdef.Build{Dockerfile: "Dockerfile", Port: 8080},
+					Infra: appdef.Infra{Provider: appdef.ResourceProviderHetzner, Type: "vm"},
+				},
+			},
+		}
+
+		input := setup(t, afero.NewMemMapFs(), appDef)
+
+		err := ReleaseWorkflow(t.Context(), input)
+		require.NoError(t, err)
+
+		file, err := afero.ReadFile(input.FS, filepath.Join(workflowsPath, "release.yaml"))
+		require.NoError(t, err)
+
+		err = validateGithubYaml(t, file, false)
+		assert.NoError(t, err)
+
+		content := string(file)
+
+		t.Log("Pushes to Docker Hub")
+		{
+			assert.Contains(t, content, "Log in to Docker Hub")
+			assert.Contains(t, content, "registry: docker.io")
+			assert.Contains(t, content, "username: ${{ secrets.DOCKERHUB_USERNAME }}")
+			assert.Contains(t, content, "password: ${{ secrets.DOCKERHUB_TOKEN }}")
+			assert.Contains(t, content, "images: docker.io/acme/${{ github.event.repository.name }}-${{ matrix.service.name }}")
+			assert.NotContains(t, content, "ghcr.io")
+		}
+
+		t.Log("Skips GHCR cleanup")
+		{
+			assert.NotContains(t, content, "cleanup-containers:")
+		}
+
+		t.Log("VM pulls from Docker Hub")
+		{
+			assert.Contains(t, content, "-e registry_url=docker.io")
+			assert.Contains(t, content, "-e docker_image_repository=docker.io/acme/${{ github.event.repository.name }}-web")
+		}
+	})
 }
@@ -0,0 +1,69 @@
+package cicd
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/infra"
+	"github.com/ainsleydev/webkit/internal/scaffold"
+	"github.com/ainsleydev/webkit/internal/secrets"
+	"github.com/ainsleydev/webkit/internal/state/manifest"
+	"github.com/ainsleydev/webkit/internal/templates"
+)
+
+var RollbackCmd = &cli.Command{
+	Name:   "rollback",
+	Usage:  "Generate rollback workflow for VM apps",
+	Action: cmdtools.Wrap(RollbackWorkflow),
+}
+
+// RollbackWorkflowFile is the name of the workflow dispatched by
+// webkit deploy rollback.
+const RollbackWorkflowFile = "rollback.yaml"
+
+// RollbackWorkflow creates a manually dispatched workflow that redeploys
+// a previously released image tag to the VM of an app.
+func RollbackWorkflow(_ context.Context, input cmdtools.CommandInput) error {
+	appDef := input.AppDef()
+
+	var vmApps []appdef.App
+	for _, app := range appDef.Apps {
+		if app.Build.Dockerfile != "" && app.ShouldRelease() && app.IsNetworkVM() {
+			vmApps = append(vmApps, app)
+		}
+	}
+
+	// Only generate the workflow if there are VM apps.
+	if len(vmApps) == 0 {
+		return nil
+	}
+
+	ufwRules, err := releaseUFWRules(vmApps)
+	if err != nil {
+		return err
+	}
+
+	tpl := templates.MustLoadTemplate(filepath.Join(workflowsPath, "rollback.yaml.tmpl"))
+	path := filepath.Join(workflowsPath, RollbackWorkflowFile)
+
+	data := map[string]any{
+		"Apps":             vmApps,
+		"TerraformVersion": infra.TerraformVersion,
+		"InfraBackend":     appDef.Infra.Backend.String(),
+		"OpenTofuVersion":  infra.OpenTofuVersion,
+		"UFWRules":         ufwRules,
+		"Registry":         newReleaseRegistry(appDef.Infra.Registry),
+		"SecretEnv":        secrets.BackendEnvVars(appDef),
+	}
+
+	var trackingOptions []scaffold.Option
+	for _, app := range vmApps {
+		trackingOptions = append(trackingOptions, scaffold.WithTracking(manifest.SourceApp(app.Name)))
+	}
+
+	return input.Generator().Template(path, tpl, data, trackingOptions...)
+}
@@ -0,0 +1,101 @@
+package cicd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+)
+
+func TestRollbackWorkflow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("No VM Apps", func(t *testing.T) {
+		t.Parallel()
+
+		appDef := &appdef.Definition{
+			Project: appdef.Project{Name: "test-project"},
+			Apps: []appdef.App{
+				{
+					Name:  "web",
+					Type:  appdef.AppTypeGoLang,
+					Build: appdef.Build{Dockerfile: "Dockerfile"},
+					Infra: appdef.Infra{Provider: appdef.ResourceProviderDigitalOcean, Type: "container"},
+				},
+			},
+		}
+
+		input := setup(t, afero.NewMemMapFs(), appDef)
+
+		err := RollbackWorkflow(t.Context(), input)
+		assert.NoError(t, err)
+
+		exists, err := afero.Exists(input.FS, filepath.Join(workflowsPath, RollbackWorkflowFile))
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("VM Apps", func(t *testing.T) {
+		t.Parallel()
+
+		appDef := &appdef.Definition{
+			Project: appdef.Project{Name: "test-project"},
+			Apps: []appdef.App{
+				{
+					Name:  "web",
+					Type:  appdef.AppTypeGoLang,
+					Build: appdef.Build{Dockerfile: "Dockerfile", Port: 8080},
+					Infra: appdef.Infra{Provider: appdef.ResourceProviderDigitalOcean, Type: "vm"},
+					Network: appdef.Network{
+						Inbound: []appdef.NetworkRule{{Port: 443}},
+					},
+				},
+				{
+					Name:  "api-server",
+					Type:  appdef.AppTypeGoLang,
+					Build: appdef.Build{Dockerfile: "Dockerfile", Port: 3000},
+					Infra: appdef.Infra{Provider: appdef.ResourceProviderHetzner, Type: "vm"},
+				},
+				{
+					Name:  "cms",
+					Type:  appdef.AppTypePayload,
+					Build: appdef.Build{Dockerfile: "Dockerfile"},
+					Infra: appdef.Infra{Provider: appdef.ResourceProviderDigitalOcean, Type: "container"},
+				},
+			},
+		}
+
+		input := setup(t, afero.NewMemMapFs(), appDef)
+
+		err := RollbackWorkflow(t.Context(), input)
+		require.NoError(t, err)
+
+		file, err := afero.ReadFile(input.FS, filepath.Join(workflowsPath, RollbackWorkflowFile))
+		require.NoError(t, err)
+
+		err = validateGithubYaml(t, file, false)
+		assert.NoError(t, err)
+
+		content := string(file)
+		assert.Contains(t, content, "workflow_dispatch:")
+		assert.Contains(t, content, "rollback-web:")
+		assert.Contains(t, content, "if: inputs.app == 'web'")
+		assert.Contains(t, content, "rollback-api-server:")
+		assert.NotContains(t, content, "rollback-cms:")
+		assert.Contains(t, content, "-e docker_image_tag=${{ inputs.tag }}")
+		assert.Contains(t, content, "-e rollback=true")
+		assert.Contains(t, content, "required: false")
+		assert.Contains(t, content, "to ${{ inputs.tag || 'the previous release' }}")
+		assert.Contains(t, content, "-e docker_port=8080")
+		assert.Contains(t, content, "secrets.TF_PROD_API_SERVER_IP_ADDRESS")
+		assert.Contains(t, content, "-e @/tmp/web-network.json")
+		assert.NotContains(t, content, "-e @/tmp/api-server-network.json")
+		assert.Contains(t, content, ageKeyEnv(10))
+		assert.Contains(t, content, `-e "age_secret_key={{ lookup('env', 'SOPS_AGE_KEY') }}"`)
+		assert.NotContains(t, content, "age_secret_key=${{ secrets.ORG_AGE_SECRET }}")
+	})
+}
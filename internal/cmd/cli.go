@@ -9,6 +9,8 @@ import (
 	"github.com/urfave/cli/v3"
 
 	"github.com/ainsleydev/webkit/internal/cmd/cicd"
+	"github.com/ainsleydev/webkit/internal/cmd/deploy"
+	"github.com/ainsleydev/webkit/internal/cmd/dev"
 	"github.com/ainsleydev/webkit/internal/cmd/docs"
 	"github.com/ainsleydev/webkit/internal/cmd/env"
 	"github.com/ainsleydev/webkit/internal/cmd/infra"
@@ -32,7 +34,10 @@ func Run() {
 			scaffoldCmd,
 			secrets.Command,
 			env.Command,
+			env.ExecCmd,
 			infra.Command,
+			deploy.Command,
+			dev.Command,
 			cicd.Command,
 			docs.Command,
 			payload.Command,
@@ -0,0 +1,17 @@
+package deploy
+
+import (
+	"github.com/urfave/cli/v3"
+)
+
+// Command defines the deploy commands for managing releases
+// of the apps defined in app.json.
+var Command = &cli.Command{
+	Name:        "deploy",
+	Usage:       "Manage deployments of apps",
+	Description: "Commands for managing releases of the apps defined in app.json",
+	Commands: []*cli.Command{
+		RollbackCmd,
+		PreviousCmd,
+	},
+}
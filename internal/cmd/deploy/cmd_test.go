@@ -0,0 +1,47 @@
+package deploy
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/state/manifest"
+)
+
+func setup(t *testing.T, command *cli.Command) (cmdtools.CommandInput, *bytes.Buffer) {
+	t.Helper()
+
+	def := &appdef.Definition{
+		Project: appdef.Project{
+			Name: "website",
+			Repo: appdef.GitHubRepo{Owner: "ainsleydev", Name: "website"},
+		},
+		Apps: []appdef.App{
+			{
+				Name:  "web",
+				Build: appdef.Build{Dockerfile: "Dockerfile", Port: 3000},
+				Infra: appdef.Infra{Provider: appdef.ResourceProviderDigitalOcean, Type: "vm"},
+			},
+			{
+				Name:  "cms",
+				Build: appdef.Build{Dockerfile: "Dockerfile", Port: 3000},
+				Infra: appdef.Infra{Provider: appdef.ResourceProviderDigitalOcean, Type: "container"},
+			},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	input := cmdtools.CommandInput{
+		FS:          afero.NewMemMapFs(),
+		AppDefCache: def,
+		Command:     command,
+		Manifest:    manifest.NewTracker(),
+	}
+	input.Printer().SetWriter(buf)
+
+	return input, buf
+}
@@ -0,0 +1,113 @@
+package deploy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+)
+
+var PreviousCmd = &cli.Command{
+	Name:  "previous",
+	Usage: "Print the image tag of the release before the current one",
+	Description: "Reads the release history recorded on a VM by the server playbook and prints the tag " +
+		"a rollback redeploys. Run by the playbook when the rollback workflow is dispatched without a tag.",
+	Hidden: true,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "history",
+			Usage:    "Path to the release history (e.g. /etc/webkit/web/releases)",
+			Required: true,
+		},
+	},
+	Action: cmdtools.Wrap(Previous),
+}
+
+// Previous prints the tag of the release that was live before the
+// current one. Only the tag is written to stdout so the playbook can
+// read it, errors are written to stderr with a non-zero exit code.
+func Previous(_ context.Context, input cmdtools.CommandInput) error {
+	tag, err := previousRelease(input.FS, input.Command.String("history"))
+	if err != nil {
+		input.Printer().SetWriter(os.Stderr)
+		input.Printer().Error(err.Error())
+		return cmdtools.ExitWithCode(1)
+	}
+
+	fmt.Println(tag) //nolint:forbidigo
+
+	return nil
+}
+
+func previousRelease(fs afero.Fs, path string) (string, error) {
+	content, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return "", errors.Wrap(err, "reading release history")
+	}
+
+	live := liveReleases(parseReleaseHistory(content))
+	if len(live) < 2 {
+		return "", errors.New("no previous release is recorded to roll back to")
+	}
+
+	return live[len(live)-2], nil
+}
+
+// releaseEntry is a line of the release history written by the server
+// playbook once a release passes its health check, in the format
+// "<time> <tag> <colour>", followed by "rollback" for rollbacks.
+type releaseEntry struct {
+	Tag      string
+	Rollback bool
+}
+
+func parseReleaseHistory(content []byte) []releaseEntry {
+	var entries []releaseEntry
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		entries = append(entries, releaseEntry{
+			Tag:      fields[1],
+			Rollback: len(fields) > 3 && fields[3] == "rollback",
+		})
+	}
+
+	return entries
+}
+
+// liveReleases replays the release history and returns the releases
+// that can be rolled back through, oldest first, with the current one
+// last. Rolling back drops the releases after the tag rolled back to,
+// so a later rollback never redeploys a release that was rolled back.
+func liveReleases(history []releaseEntry) []string {
+	var live []string
+
+	for _, entry := range history {
+		if entry.Rollback {
+			for i := len(live) - 1; i >= 0; i-- {
+				if live[i] == entry.Tag {
+					live = live[:i+1]
+					break
+				}
+			}
+		}
+		if len(live) > 0 && live[len(live)-1] == entry.Tag {
+			continue
+		}
+		live = append(live, entry.Tag)
+	}
+
+	return live
+}
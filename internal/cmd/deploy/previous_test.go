@@ -0,0 +1,86 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviousRelease(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]struct {
+		history string
+		want    string
+		wantErr string
+	}{
+		"Previous Release": {
+			history: "2026-01-01T00:00:00Z sha-a green\n" +
+				"2026-01-02T00:00:00Z sha-b blue\n",
+			want: "sha-a",
+		},
+		"Two Rollbacks In A Row": {
+			// The second rollback must not redeploy sha-c, which
+			// the first one rolled back from.
+			history: "2026-01-01T00:00:00Z sha-a green\n" +
+				"2026-01-02T00:00:00Z sha-b blue\n" +
+				"2026-01-03T00:00:00Z sha-c green\n" +
+				"2026-01-04T00:00:00Z sha-b blue rollback\n",
+			want: "sha-a",
+		},
+		"Rolled Back To First Release": {
+			history: "2026-01-01T00:00:00Z sha-a green\n" +
+				"2026-01-02T00:00:00Z sha-b blue\n" +
+				"2026-01-03T00:00:00Z sha-a green rollback\n",
+			wantErr: "no previous release",
+		},
+		"Release After Rollback": {
+			history: "2026-01-01T00:00:00Z sha-a green\n" +
+				"2026-01-02T00:00:00Z sha-b blue\n" +
+				"2026-01-03T00:00:00Z sha-a green rollback\n" +
+				"2026-01-04T00:00:00Z sha-c blue\n",
+			want: "sha-a",
+		},
+		"Rollback To Explicit Tag": {
+			history: "2026-01-01T00:00:00Z sha-a green\n" +
+				"2026-01-02T00:00:00Z sha-b blue\n" +
+				"2026-01-03T00:00:00Z sha-old green rollback\n",
+			want: "sha-b",
+		},
+		"Redeployed Release": {
+			history: "2026-01-01T00:00:00Z sha-a green\n" +
+				"2026-01-02T00:00:00Z sha-b blue\n" +
+				"2026-01-03T00:00:00Z sha-b green\n",
+			want: "sha-a",
+		},
+		"Single Release": {
+			history: "2026-01-01T00:00:00Z sha-a green\n",
+			wantErr: "no previous release",
+		},
+		"Missing History": {
+			wantErr: "reading release history",
+		},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := afero.NewMemMapFs()
+			if test.history != "" {
+				require.NoError(t, afero.WriteFile(fs, "/etc/webkit/web/releases", []byte(test.history), 0o644))
+			}
+
+			got, err := previousRelease(fs, "/etc/webkit/web/releases")
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/cmd/cicd"
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/ghapi"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+var RollbackCmd = &cli.Command{
+	Name:  "rollback",
+	Usage: "Redeploy the previous image of a VM app",
+	Description: "Dispatches the rollback workflow to redeploy the release that was live before the current " +
+		"one, read from the release history on the VM (or the tag passed with --tag). The VM only switches " +
+		"traffic to the redeployed container once it passes its health check.",
+	Flags:  rollbackFlags(),
+	Action: cmdtools.Wrap(Rollback),
+}
+
+func rollbackFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "app",
+			Aliases:  []string{"a"},
+			Usage:    "Name of the app to roll back",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:    "env",
+			Aliases: []string{"e"},
+			Usage:   "Environment to roll back",
+			Value:   env.Production.String(),
+		},
+		&cli.StringFlag{
+			Name:    "tag",
+			Aliases: []string{"t"},
+			Usage:   "Image tag to redeploy instead of the previous release (e.g. sha-abc123)",
+		},
+		&cli.StringFlag{
+			Name:  "ref",
+			Usage: "Git ref the rollback workflow is dispatched on",
+			Value: "main",
+		},
+	}
+}
+
+// Rollback redeploys the previous image of a VM app by dispatching the
+// rollback workflow generated by webkit cicd rollback. Unless a tag is
+// passed, the workflow is dispatched without one and the playbook reads
+// the previous tag from the releases the VM has recorded, so only tags
+// that passed their health check are redeployed.
+//
+// GITHUB_TOKEN (or GITHUB_TOKEN_CLASSIC) must be set with permission to
+// run workflows in the repository.
+func Rollback(ctx context.Context, input cmdtools.CommandInput) error {
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN_CLASSIC")
+	}
+	if token == "" {
+		return errors.New("GITHUB_TOKEN must be set to dispatch the rollback workflow")
+	}
+
+	return rollback(ctx, input, ghapi.New(token))
+}
+
+func rollback(ctx context.Context, input cmdtools.CommandInput, client ghapi.Client) error {
+	appDef := input.AppDef()
+	printer := input.Printer()
+	cmd := input.Command
+
+	appName := cmd.String("app")
+	idx := slices.IndexFunc(appDef.Apps, func(a appdef.App) bool {
+		return a.Name == appName
+	})
+	if idx == -1 {
+		return fmt.Errorf("app '%s' not found in app.json", appName)
+	}
+
+	app := appDef.Apps[idx]
+	if !app.IsNetworkVM() {
+		return fmt.Errorf("app '%s' is not deployed to a VM, only VM apps can be rolled back", appName)
+	}
+
+	// VMs are only deployed to by the release workflow in production.
+	environment := env.Environment(cmd.String("env"))
+	if environment != env.Production {
+		return fmt.Errorf("invalid environment %q: VM apps are only deployed to production", environment)
+	}
+
+	tag := cmd.String("tag")
+
+	repo := appDef.Project.Repo
+	err := client.DispatchWorkflow(ctx, repo.Owner, repo.Name, cicd.RollbackWorkflowFile, cmd.String("ref"), map[string]any{
+		"app":         appName,
+		"tag":         tag,
+		"environment": environment.String(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "dispatching rollback workflow")
+	}
+
+	target := tag
+	if target == "" {
+		target = "the previous release"
+	}
+
+	printer.Success(fmt.Sprintf("Rolling back %s (%s) to %s", appName, environment, target))
+	printer.Printf("Follow the rollback at https://github.com/%s/%s/actions/workflows/%s\n", repo.Owner, repo.Name, cicd.RollbackWorkflowFile)
+
+	return nil
+}
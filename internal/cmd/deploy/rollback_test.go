@@ -0,0 +1,109 @@
+package deploy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ainsleydev/webkit/internal/mocks"
+)
+
+// rollbackCmd returns a command with the rollback flags set,
+// so tests don't share flag state.
+func rollbackCmd(t *testing.T, flags map[string]string) *cli.Command {
+	t.Helper()
+
+	cmd := &cli.Command{Flags: rollbackFlags()}
+	for name, value := range flags {
+		require.NoError(t, cmd.Set(name, value))
+	}
+
+	return cmd
+}
+
+func TestRollback(t *testing.T) {
+	t.Parallel()
+
+	inputs := func(tag string) map[string]any {
+		return map[string]any{"app": "web", "tag": tag, "environment": "production"}
+	}
+
+	tt := map[string]struct {
+		flags   map[string]string
+		mock    func(gh *mocks.GHClient)
+		want    string
+		wantErr string
+	}{
+		"Previous Release": {
+			flags: map[string]string{"app": "web"},
+			mock: func(gh *mocks.GHClient) {
+				gh.EXPECT().DispatchWorkflow(gomock.Any(), "ainsleydev", "website", "rollback.yaml", "main", inputs("")).Return(nil)
+			},
+			want: "Rolling back web (production) to the previous release",
+		},
+		"Explicit Tag": {
+			flags: map[string]string{"app": "web", "tag": "sha-abc", "ref": "release"},
+			mock: func(gh *mocks.GHClient) {
+				gh.EXPECT().DispatchWorkflow(gomock.Any(), "ainsleydev", "website", "rollback.yaml", "release", inputs("sha-abc")).Return(nil)
+			},
+			want: "Rolling back web (production) to sha-abc",
+		},
+		"App Not Found": {
+			flags:   map[string]string{"app": "api"},
+			wantErr: "app 'api' not found in app.json",
+		},
+		"Not A VM": {
+			flags:   map[string]string{"app": "cms"},
+			wantErr: "only VM apps can be rolled back",
+		},
+		"Not Production": {
+			flags:   map[string]string{"app": "web", "env": "staging"},
+			wantErr: "VM apps are only deployed to production",
+		},
+		"Dispatch Error": {
+			flags: map[string]string{"app": "web", "tag": "sha-abc"},
+			mock: func(gh *mocks.GHClient) {
+				gh.EXPECT().DispatchWorkflow(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("workflow not found"))
+			},
+			wantErr: "dispatching rollback workflow",
+		},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			gh := mocks.NewGHClient(ctrl)
+			if test.mock != nil {
+				test.mock(gh)
+			}
+
+			input, buf := setup(t, rollbackCmd(t, test.flags))
+
+			err := rollback(t.Context(), input, gh)
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Contains(t, buf.String(), test.want)
+		})
+	}
+}
+
+func TestRollback_NoToken(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN_CLASSIC", "")
+
+	input, _ := setup(t, rollbackCmd(t, map[string]string{"app": "web"}))
+
+	err := Rollback(t.Context(), input)
+	assert.ErrorContains(t, err, "GITHUB_TOKEN must be set")
+}
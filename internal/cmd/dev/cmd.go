@@ -0,0 +1,17 @@
+package dev
+
+import (
+	"github.com/urfave/cli/v3"
+)
+
+// Command defines the dev commands for running the apps and
+// resources defined in app.json on the local machine.
+var Command = &cli.Command{
+	Name:        "dev",
+	Usage:       "Run the app.json topology locally with Docker Compose",
+	Description: "Commands for starting and stopping local containers for the apps and resources defined in app.json",
+	Commands: []*cli.Command{
+		UpCmd,
+		DownCmd,
+	},
+}
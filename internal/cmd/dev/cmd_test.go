@@ -0,0 +1,57 @@
+package dev
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/secrets/age"
+	"github.com/ainsleydev/webkit/internal/state/manifest"
+	"github.com/ainsleydev/webkit/internal/util/executil"
+)
+
+func setup(t *testing.T, command *cli.Command) (cmdtools.CommandInput, *executil.MemRunner, *bytes.Buffer) {
+	t.Helper()
+
+	ageIdentity, err := age.NewIdentity()
+	require.NoError(t, err)
+	t.Setenv(age.KeyEnvVar, ageIdentity.String())
+
+	def := &appdef.Definition{
+		Project: appdef.Project{Name: "my-website"},
+		Resources: []appdef.Resource{
+			{Name: "db", Type: appdef.ResourceTypePostgres},
+		},
+		Apps: []appdef.App{
+			{
+				Name:  "web",
+				Path:  "web",
+				Build: appdef.Build{Context: "web", Dockerfile: "Dockerfile", Port: 3000},
+				Env: appdef.Environment{
+					Dev: appdef.EnvVar{
+						"DATABASE_URL": {Source: appdef.EnvSourceResource, Value: "db.connection_url"},
+					},
+				},
+			},
+		},
+	}
+
+	runner := executil.NewMemRunner()
+	buf := &bytes.Buffer{}
+	input := cmdtools.CommandInput{
+		FS:          afero.NewMemMapFs(),
+		BaseDir:     t.TempDir(),
+		AppDefCache: def,
+		Command:     command,
+		Manifest:    manifest.NewTracker(),
+		Runner:      runner,
+	}
+	input.Printer().SetWriter(buf)
+
+	return input, runner, buf
+}
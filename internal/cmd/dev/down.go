@@ -0,0 +1,45 @@
+package dev
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/infra"
+)
+
+var DownCmd = &cli.Command{
+	Name:        "down",
+	Usage:       "Stop the local apps and resources started with webkit dev up",
+	Description: "Stops and removes the containers and networks of the local stack, along with the rendered Docker Compose file",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "volumes",
+			Usage: "Also remove the volumes, deleting all local data",
+		},
+	},
+	Action: cmdtools.Wrap(Down),
+}
+
+// Down stops the local stack and removes the rendered Compose file.
+func Down(ctx context.Context, input cmdtools.CommandInput) error {
+	printer := input.Printer()
+
+	compose := infra.NewCompose(input.AppDef(), input.BaseDir, input.Runner)
+
+	// Render the file again so the stack can be torn down
+	// even if it was removed or app.json has changed.
+	if err := compose.Init(ctx); err != nil {
+		return err
+	}
+
+	printer.Println("Stopping containers...")
+	if _, err := compose.Down(ctx, input.Command.Bool("volumes")); err != nil {
+		return err
+	}
+
+	printer.Success("Local stack has been torn down")
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package dev
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/internal/infra"
+	"github.com/ainsleydev/webkit/internal/util/executil"
+)
+
+func TestDown(t *testing.T) {
+	t.Run("Compose Error", func(t *testing.T) {
+		input, runner, _ := setup(t, DownCmd)
+		runner.AddStub("docker compose version", executil.Result{}, nil)
+		runner.AddStub("docker compose --project-name", executil.Result{}, errors.New("daemon not running"))
+
+		err := Down(t.Context(), input)
+		assert.ErrorContains(t, err, "docker compose down failed")
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		input, runner, buf := setup(t, DownCmd)
+		runner.AddStub("docker compose", executil.Result{}, nil)
+
+		err := Down(t.Context(), input)
+		require.NoError(t, err)
+
+		calls := runner.Calls()
+		require.Len(t, calls, 2)
+		assert.Contains(t, calls[1].String(), "down --remove-orphans")
+		assert.NotContains(t, calls[1].String(), "--volumes")
+		assert.NoDirExists(t, filepath.Join(input.BaseDir, infra.ComposeDir))
+		assert.Contains(t, buf.String(), "torn down")
+	})
+
+	t.Run("Volumes", func(t *testing.T) {
+		input, runner, _ := setup(t, DownCmd)
+		runner.AddStub("docker compose", executil.Result{}, nil)
+		require.NoError(t, input.Command.Set("volumes", "true"))
+		t.Cleanup(func() { _ = input.Command.Set("volumes", "false") })
+
+		err := Down(t.Context(), input)
+		require.NoError(t, err)
+		assert.Contains(t, runner.Calls()[1].String(), "--volumes")
+	})
+}
@@ -0,0 +1,125 @@
+package dev
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cast"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/infra"
+	"github.com/ainsleydev/webkit/internal/secrets"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+var UpCmd = &cli.Command{
+	Name:  "up",
+	Usage: "Start the apps and resources defined in app.json locally",
+	Description: "Renders a Docker Compose file from app.json and starts Postgres for postgres resources, " +
+		"MinIO for s3 resources and libsql-server for sqlite resources, alongside each app built from its Dockerfile",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "resources-only",
+			Usage: "Only start the resources, useful when running the apps natively",
+		},
+	},
+	Action: cmdtools.Wrap(Up),
+}
+
+// Up renders the Docker Compose file and starts the local stack.
+//
+// Resource references in the development environment are resolved
+// against the containers, so apps receive the same variables they
+// would in production, pointing at the local resources instead.
+func Up(ctx context.Context, input cmdtools.CommandInput) error {
+	printer := input.Printer()
+	spinner := input.Spinner()
+
+	appDef := input.AppDef()
+	if input.Command.Bool("resources-only") {
+		def := *appDef
+		def.Apps = nil
+		appDef = &def
+	}
+
+	compose := infra.NewCompose(appDef, input.BaseDir, input.Runner)
+
+	if len(appDef.Apps) > 0 {
+		printer.Println("Resolving development environment...")
+		spinner.Start()
+
+		outputs := secrets.TransformOutputs(compose.ServiceOutputs(), env.Development)
+		err := secrets.ResolveForEnvironment(ctx, appDef, env.Development, secrets.ResolveConfig{
+			SOPSClient:      input.SOPSClient(),
+			BaseDir:         input.BaseDir,
+			TerraformOutput: &outputs,
+		})
+		spinner.Stop()
+		if err != nil {
+			return err
+		}
+	}
+
+	printer.Println("Rendering Docker Compose file...")
+	if err := compose.Init(ctx); err != nil {
+		return err
+	}
+
+	printer.Println("Starting containers...")
+	if _, err := compose.Apply(ctx, env.Development, false); err != nil {
+		return err
+	}
+
+	result, err := compose.Output(ctx, env.Development)
+	if err != nil {
+		return err
+	}
+
+	printConnections(input, result)
+	printer.Success("Local stack is running, stop it with: webkit dev down")
+
+	return nil
+}
+
+// printConnections prints the host-facing connection details
+// of every resource and app in the local stack.
+func printConnections(input cmdtools.CommandInput, result infra.OutputResult) {
+	printer := input.Printer()
+
+	if len(result.Resources) > 0 {
+		rows := make([][]string, 0, len(result.Resources))
+		for _, name := range sortedKeys(result.Resources) {
+			outputs := result.Resources[name]
+			connection := outputs["connection_url"]
+			if connection == nil {
+				connection = outputs["endpoint"]
+			}
+			rows = append(rows, []string{name, cast.ToString(outputs["platform_type"]), cast.ToString(connection)})
+		}
+		printer.LineBreak()
+		printer.Table([]string{"Resource", "Type", "Connection"}, rows)
+	}
+
+	if len(result.Apps) > 0 {
+		rows := make([][]string, 0, len(result.Apps))
+		for _, name := range sortedKeys(result.Apps) {
+			rows = append(rows, []string{name, cast.ToString(result.Apps[name]["url"])})
+		}
+		printer.LineBreak()
+		printer.Table([]string{"App", "URL"}, rows)
+	}
+
+	printer.LineBreak()
+	printer.Info(fmt.Sprintf("Resource references in the development environment resolve to the containers above, see %s", infra.ComposeDir))
+}
+
+func sortedKeys(m map[string]map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
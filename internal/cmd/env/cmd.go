@@ -1,22 +1,19 @@
 package env
 
 import (
-	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 
-	"github.com/pkg/errors"
 	"github.com/spf13/cast"
 	"github.com/urfave/cli/v3"
 
 	"github.com/ainsleydev/webkit/internal/appdef"
 	"github.com/ainsleydev/webkit/internal/cmdtools"
-	"github.com/ainsleydev/webkit/internal/infra"
 	"github.com/ainsleydev/webkit/internal/scaffold"
-	"github.com/ainsleydev/webkit/internal/secrets"
 	"github.com/ainsleydev/webkit/internal/state/manifest"
 	"github.com/ainsleydev/webkit/pkg/env"
 )
@@ -48,32 +45,38 @@ type writeArgs struct {
 	Environment      env.Environment
 	IsScaffold       bool
 	CustomOutputPath string
+	// Format is the format to write the variables in, defaults
+	// to dotenv.
+	Format Format
+	// Stdout is written to instead of a file if set.
+	Stdout io.Writer
 }
 
-// writeMapToFile writes environment variables to dotenv file.
+// writeMapToFile writes environment variables to a file in the format
+// of the args, dotenv by default.
 func writeMapToFile(args writeArgs) error {
 	envMap := make(map[string]string)
 	for k, v := range args.Vars {
 		envMap[k] = cast.ToString(v.Value)
 	}
 
-	// Use custom marshaller that doesn't quote unnecessarily
-	// This prevents Docker Swarm from including quotes in the actual env var values
-	buf := marshalEnvWithoutQuotes(envMap)
-
-	var envPath string
-	if args.CustomOutputPath != "" {
-		envPath = args.CustomOutputPath
-	} else {
-		file := ".env"
-		if args.Environment != env.Development {
-			file = fmt.Sprintf(".env.%s", args.Environment)
-		}
-		envPath = filepath.Join(args.App.Path, file)
+	buf, err := marshalEnv(args.Format, envMap, fmt.Sprintf("%s-%s", args.App.Name, args.Environment))
+	if err != nil {
+		return err
+	}
+
+	if args.Stdout != nil {
+		_, err = args.Stdout.Write(buf)
+		return err
+	}
+
+	envPath := args.CustomOutputPath
+	if envPath == "" {
+		envPath = defaultOutputPath(args.App, args.Environment, args.Format)
 	}
 
 	outputDir := filepath.Dir(envPath)
-	err := args.Input.FS.MkdirAll(outputDir, os.ModePerm)
+	err = args.Input.FS.MkdirAll(outputDir, os.ModePerm)
 	if err != nil {
 		return err
 	}
@@ -84,7 +87,13 @@ func writeMapToFile(args writeArgs) error {
 	}
 	opts = append(opts, scaffold.WithTracking(manifest.SourceProject()))
 
-	return args.Input.Generator().Bytes(envPath, []byte(buf), opts...)
+	return args.Input.Generator().Bytes(envPath, buf, opts...)
+}
+
+// defaultOutputPath returns the path of the env file in the app's
+// directory, e.g. .env.production or .env.production.json.
+func defaultOutputPath(app appdef.App, environment env.Environment, format Format) string {
+	return filepath.Join(app.Path, ".env"+envSuffix(environment)+format.Extension())
 }
 
 // envSuffix returns the .env file suffix for an environment.
@@ -95,39 +104,9 @@ func envSuffix(environment env.Environment) string {
 	return fmt.Sprintf(".%s", environment)
 }
 
-// fetchTerraformOutputs fetches Terraform outputs for the specified environment.
-// Returns a TerraformOutputProvider containing resource outputs.
-//
-// This function manages the full Terraform lifecycle (create, init, output, cleanup).
-// See also: infra/cmd.go:fetchTerraformOutputs for a similar function that uses
-// an existing Terraform instance.
-func fetchTerraformOutputs(
-	ctx context.Context,
-	input cmdtools.CommandInput,
-	environment env.Environment,
-) (*secrets.TerraformOutputProvider, error) {
-	tf, err := infra.NewTerraform(ctx, input.AppDef(), input.Manifest)
-	if err != nil {
-		return nil, errors.Wrap(err, "creating terraform manager")
-	}
-
-	if err := tf.Init(ctx); err != nil {
-		return nil, errors.Wrap(err, "initialising terraform")
-	}
-	defer tf.Cleanup()
-
-	result, err := tf.Output(ctx, environment)
-	if err != nil {
-		return nil, errors.Wrap(err, "retrieving terraform outputs")
-	}
-
-	provider := secrets.TransformOutputs(result, environment)
-	return &provider, nil
-}
-
 // marshalEnvWithoutQuotes marshals environment variables without adding quotes.
 // This is necessary for Docker Swarm env_files which don't strip quotes like docker-compose does.
-// Only adds quotes when the value contains spaces, newlines, or is empty.
+// Only adds quotes when the value contains spaces, newlines, dollar signs or is empty.
 // Keys are sorted alphabetically for consistent output.
 func marshalEnvWithoutQuotes(envMap map[string]string) string {
 	// Extract and sort keys alphabetically.
@@ -140,13 +119,20 @@ func marshalEnvWithoutQuotes(envMap map[string]string) string {
 	var builder strings.Builder
 	for _, key := range keys {
 		value := envMap[key]
-		// Only quote if value contains spaces, newlines, or is empty.
-		// Docker Swarm env_files doesn't handle quotes like docker-compose.
-		if strings.ContainsAny(value, " \n\t") || value == "" {
-			// Escape any quotes in the value.
-			escapedValue := strings.ReplaceAll(value, `"`, `\"`)
+		hasDollar := strings.Contains(value, "$")
+		switch {
+		case hasDollar && !strings.Contains(value, "'"):
+			// Docker compose expands variables in unquoted and double
+			// quoted values, but takes single quoted values literally.
+			builder.WriteString(fmt.Sprintf("%s='%s'\n", key, value))
+		case hasDollar || strings.ContainsAny(value, " \r\n\t") || value == "":
+			// Only quote if value contains spaces, newlines, or is empty.
+			// Docker Swarm env_files doesn't handle quotes like docker-compose.
+			// Escape any backslashes and quotes in the value, and dollar
+			// signs so they aren't expanded.
+			escapedValue := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `$$`).Replace(value)
 			builder.WriteString(fmt.Sprintf("%s=\"%s\"\n", key, escapedValue))
-		} else {
+		default:
 			builder.WriteString(fmt.Sprintf("%s=%s\n", key, value))
 		}
 	}
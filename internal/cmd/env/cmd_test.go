@@ -127,6 +127,18 @@ func TestMarshalEnvWithoutQuotes(t *testing.T) {
 				"EMPTY": "EMPTY=\"\"",
 			},
 		},
+		"Dollar signs are single quoted": {
+			input: map[string]string{
+				"PASSWORD": "pa$$word",
+				"TEMPLATE": "${HOST}:80",
+				"BOTH":     "it's $5",
+			},
+			want: map[string]string{
+				"PASSWORD": "PASSWORD='pa$$word'",
+				"TEMPLATE": "TEMPLATE='${HOST}:80'",
+				"BOTH":     "BOTH=\"it's $$5\"",
+			},
+		},
 		"Mixed values": {
 			input: map[string]string{
 				"SIMPLE":     "value",
@@ -0,0 +1,106 @@
+package env
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"github.com/spf13/cast"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/util/executil"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+var ExecCmd = &cli.Command{
+	Name:  "exec",
+	Usage: "Run a command with the resolved environment of an app",
+	Description: "Resolves the environment variables of an app (shared and app variables, SOPS secrets and " +
+		"Terraform outputs) in memory and runs the command with them, without writing a .env file. " +
+		"Exits with the exit code of the command.",
+	ArgsUsage: "-- <command> [args...]",
+	Flags:     execFlags(),
+	Action:    cmdtools.Wrap(Exec),
+}
+
+func execFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "app",
+			Usage:    "Name of the app to resolve the environment of",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "environment",
+			Aliases:  []string{"env"},
+			Usage:    "Target environment (development, staging, production)",
+			Required: true,
+		},
+		&cli.BoolFlag{
+			Name:  "refresh",
+			Usage: "Fetch Terraform outputs instead of using the cache in .webkit",
+		},
+	}
+}
+
+// Exec runs a command with the environment of an app injected, for
+// example `webkit exec --app web --env staging -- pnpm migrate`. The
+// variables are only held in memory and passed to the child process,
+// which is connected to the terminal.
+func Exec(ctx context.Context, input cmdtools.CommandInput) error {
+	args := input.Command.Args().Slice()
+	if len(args) == 0 {
+		return errors.New("a command must be provided, e.g. webkit exec --app web --env staging -- pnpm migrate")
+	}
+
+	environment := env.Environment(input.Command.String("environment"))
+	if !slices.Contains(env.All, environment) {
+		return fmt.Errorf("invalid environment: %s", environment)
+	}
+
+	// Webkit's own output goes to stderr so the output of the
+	// command can be piped.
+	spinner := input.Spinner()
+	useStderr(&input, spinner)
+
+	_, vars, err := resolveAppVars(ctx, input, spinner, input.Command.String("app"), environment)
+	if err != nil {
+		return err
+	}
+
+	cmd := executil.NewCommand(args[0], args[1:]...)
+	cmd.Env = make(map[string]string, len(vars))
+	for k, v := range vars {
+		cmd.Env[k] = cast.ToString(v.Value)
+	}
+	cmd.Interactive = true
+	// Terminations and hang ups, for example from a process manager
+	// or a closed SSH session, are passed on to the command so it can
+	// shut down gracefully instead of webkit being killed with it
+	// still running.
+	cmd.Signals = []os.Signal{syscall.SIGTERM, syscall.SIGHUP}
+
+	// The terminal sends interrupts to the command and to webkit, so
+	// they're ignored to let the command decide when to exit.
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	_, err = input.Runner.Run(ctx, cmd)
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		// The exit code is -1 if the command was killed by a signal.
+		return cmdtools.ExitWithCode(max(exitErr.ExitCode(), 1))
+	} else if err != nil {
+		return fmt.Errorf("running %s: %w", args[0], err)
+	}
+
+	return nil
+}
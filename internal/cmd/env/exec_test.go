@@ -0,0 +1,127 @@
+package env
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/secrets/sops"
+	"github.com/ainsleydev/webkit/internal/util/executil"
+)
+
+func TestExec(t *testing.T) {
+	t.Parallel()
+
+	def := &appdef.Definition{
+		Shared: appdef.Shared{
+			Env: appdef.Environment{
+				Default: appdef.EnvVar{
+					"SHARED": {Source: appdef.EnvSourceValue, Value: "shared"},
+				},
+			},
+		},
+		Apps: []appdef.App{
+			{
+				Name: "web",
+				Path: "./web",
+				Env: appdef.Environment{
+					Staging: appdef.EnvVar{
+						"API_URL": {Source: appdef.EnvSourceValue, Value: "https://staging.example.com"},
+						"PORT":    {Source: appdef.EnvSourceValue, Value: 3000},
+					},
+				},
+			},
+		},
+	}
+
+	// run parses the arguments like the CLI would before running Exec.
+	run := func(t *testing.T, runner executil.Runner, args ...string) (cmdtools.CommandInput, error) {
+		t.Helper()
+
+		input := setup(t, def)
+		input.SOPSCache = sops.NewNativeClient(nil, nil)
+		input.Runner = runner
+
+		cmd := &cli.Command{
+			Name:  "exec",
+			Flags: execFlags(),
+			Action: func(ctx context.Context, c *cli.Command) error {
+				input.Command = c
+				return Exec(ctx, input)
+			},
+		}
+
+		return input, cmd.Run(t.Context(), append([]string{"exec"}, args...))
+	}
+
+	t.Run("Injects Environment", func(t *testing.T) {
+		t.Parallel()
+
+		runner := executil.NewMemRunner()
+		runner.AddStub("pnpm migrate", executil.Result{}, nil)
+
+		input, err := run(t, runner, "--app", "web", "--env", "staging", "--", "pnpm", "migrate", "--force")
+		require.NoError(t, err)
+
+		calls := runner.Calls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, "pnpm migrate --force", calls[0].String())
+		assert.True(t, calls[0].Interactive)
+		assert.Equal(t, []os.Signal{syscall.SIGTERM, syscall.SIGHUP}, calls[0].Signals, "Terminations should be passed on to the command")
+		assert.Equal(t, map[string]string{
+			"SHARED":  "shared",
+			"API_URL": "https://staging.example.com",
+			"PORT":    "3000",
+		}, calls[0].Env)
+
+		files, err := afero.Glob(input.FS, "web/.env*")
+		require.NoError(t, err)
+		assert.Empty(t, files, "Nothing should be written to disk")
+	})
+
+	t.Run("Exit Code", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := run(t, executil.DefaultRunner(), "--app", "web", "--env", "staging", "--", "sh", "-c", `test "$PORT" = 3000 && exit 3`)
+		assert.Equal(t, cmdtools.ExitWithCode(3), err)
+	})
+
+	t.Run("Command Not Found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := run(t, executil.DefaultRunner(), "--app", "web", "--env", "staging", "--", "this-command-does-not-exist")
+		assert.ErrorContains(t, err, "running this-command-does-not-exist")
+	})
+
+	t.Run("Invalid Arguments", func(t *testing.T) {
+		t.Parallel()
+
+		tt := map[string]struct {
+			args []string
+			want string
+		}{
+			"No Command":          {args: []string{"--app", "web", "--env", "staging"}, want: "a command must be provided"},
+			"Invalid Environment": {args: []string{"--app", "web", "--env", "preview", "--", "ls"}, want: "invalid environment: preview"},
+			"Unknown App":         {args: []string{"--app", "api", "--env", "staging", "--", "ls"}, want: "app 'api' not found in app.json"},
+		}
+
+		for name, test := range tt {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				runner := executil.NewMemRunner()
+				_, err := run(t, runner, test.args...)
+				assert.ErrorContains(t, err, test.want)
+				assert.Empty(t, runner.Calls())
+			})
+		}
+	})
+}
@@ -0,0 +1,238 @@
+package env
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the output format of a generated env file.
+type Format string
+
+// Formats that env files can be generated in.
+const (
+	// FormatDotenv writes KEY=value lines, quoting values only when
+	// needed so Docker Swarm doesn't include the quotes in the value.
+	// Values with a $ are single quoted so docker compose doesn't
+	// expand them.
+	FormatDotenv Format = "dotenv"
+	// FormatJSON writes a JSON object of keys to values.
+	FormatJSON Format = "json"
+	// FormatYAML writes a YAML mapping of keys to values.
+	FormatYAML Format = "yaml"
+	// FormatShellExport writes `export KEY='value'` lines that can be
+	// sourced by a POSIX shell.
+	FormatShellExport Format = "shell-export"
+	// FormatDockerEnv writes KEY=value lines for `docker run --env-file`,
+	// which reads values literally and can't hold multi-line values.
+	FormatDockerEnv Format = "docker-env"
+	// FormatK8sSecret writes a Kubernetes Secret manifest.
+	FormatK8sSecret Format = "k8s-secret"
+	// FormatGitHubEnv writes the format of the $GITHUB_ENV file, with
+	// multi-line values written as heredocs.
+	FormatGitHubEnv Format = "github-env"
+)
+
+// Formats are all the formats env files can be generated in.
+var Formats = []Format{
+	FormatDotenv,
+	FormatJSON,
+	FormatYAML,
+	FormatShellExport,
+	FormatDockerEnv,
+	FormatK8sSecret,
+	FormatGitHubEnv,
+}
+
+// ParseFormat returns the format with the given name.
+func ParseFormat(s string) (Format, error) {
+	f := Format(s)
+	if !slices.Contains(Formats, f) {
+		names := make([]string, len(Formats))
+		for i, format := range Formats {
+			names[i] = string(format)
+		}
+		return "", fmt.Errorf("invalid format %q, must be one of: %s", s, strings.Join(names, ", "))
+	}
+	return f, nil
+}
+
+// Extension returns the file extension of the format, used for the
+// default output path.
+func (f Format) Extension() string {
+	switch f {
+	case FormatJSON:
+		return ".json"
+	case FormatYAML, FormatK8sSecret:
+		return ".yaml"
+	default:
+		return ""
+	}
+}
+
+// marshalEnv encodes the environment variables in the format, sorted
+// by key. The name is used for formats that need one, such as the
+// name of a Kubernetes Secret.
+func marshalEnv(format Format, envMap map[string]string, name string) ([]byte, error) {
+	switch format {
+	case FormatDotenv, "":
+		return []byte(marshalEnvWithoutQuotes(envMap)), nil
+	case FormatJSON:
+		b, err := json.MarshalIndent(envMap, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(b, '\n'), nil
+	case FormatYAML:
+		if len(envMap) == 0 {
+			return []byte("{}\n"), nil
+		}
+		return yaml.Marshal(envMap)
+	case FormatShellExport:
+		return []byte(marshalShellExport(envMap)), nil
+	case FormatDockerEnv:
+		return marshalDockerEnv(envMap)
+	case FormatK8sSecret:
+		return marshalK8sSecret(envMap, name)
+	case FormatGitHubEnv:
+		return marshalGitHubEnv(envMap)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// marshalShellExport single quotes every value, so nothing in it is
+// expanded by the shell. Single quotes in the value are closed,
+// escaped and reopened.
+func marshalShellExport(envMap map[string]string) string {
+	var builder strings.Builder
+	for _, key := range sortedKeys(envMap) {
+		value := strings.ReplaceAll(envMap[key], `'`, `'\''`)
+		builder.WriteString(fmt.Sprintf("export %s='%s'\n", key, value))
+	}
+	return builder.String()
+}
+
+// marshalDockerEnv writes values as they are, since Docker doesn't
+// strip quotes or interpret escapes in env files.
+func marshalDockerEnv(envMap map[string]string) ([]byte, error) {
+	var builder strings.Builder
+	for _, key := range sortedKeys(envMap) {
+		value := envMap[key]
+		if strings.ContainsAny(value, "\r\n") {
+			return nil, fmt.Errorf("%s has a multi-line value, which docker-env files can't hold", key)
+		}
+		builder.WriteString(fmt.Sprintf("%s=%s\n", key, value))
+	}
+	return []byte(builder.String()), nil
+}
+
+// k8sSecret is the Kubernetes Secret manifest written by the
+// k8s-secret format.
+type k8sSecret struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   k8sMetadata       `yaml:"metadata"`
+	Type       string            `yaml:"type"`
+	Data       map[string]string `yaml:"data"`
+}
+
+type k8sMetadata struct {
+	Name string `yaml:"name"`
+}
+
+// marshalK8sSecret writes an Opaque Secret with base64 encoded values,
+// so any value, including multi-line ones, is kept exactly.
+func marshalK8sSecret(envMap map[string]string, name string) ([]byte, error) {
+	data := make(map[string]string, len(envMap))
+	for key, value := range envMap {
+		data[key] = base64.StdEncoding.EncodeToString([]byte(value))
+	}
+
+	return yaml.Marshal(k8sSecret{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata:   k8sMetadata{Name: k8sName(name)},
+		Type:       "Opaque",
+		Data:       data,
+	})
+}
+
+// k8sNameMaxLength is the maximum length of a DNS-1123 subdomain.
+const k8sNameMaxLength = 253
+
+// k8sName returns the name as a DNS-1123 subdomain, which Kubernetes
+// requires object names to be: at most 253 lowercase alphanumeric
+// characters, '-' or '.', starting and ending with an alphanumeric.
+func k8sName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		default:
+			return '-'
+		}
+	}, strings.ToLower(name))
+
+	if len(name) > k8sNameMaxLength {
+		name = name[:k8sNameMaxLength]
+	}
+
+	name = strings.TrimFunc(name, func(r rune) bool {
+		return r == '-' || r == '.'
+	})
+	if name == "" {
+		return "env"
+	}
+
+	return name
+}
+
+// marshalGitHubEnv writes single line values as KEY=value and multi-line
+// values with a random delimiter that can't appear in the value, as
+// recommended by GitHub.
+func marshalGitHubEnv(envMap map[string]string) ([]byte, error) {
+	var builder strings.Builder
+	for _, key := range sortedKeys(envMap) {
+		value := envMap[key]
+		if !strings.ContainsAny(value, "\r\n") {
+			builder.WriteString(fmt.Sprintf("%s=%s\n", key, value))
+			continue
+		}
+
+		delimiter, err := githubDelimiter(value)
+		if err != nil {
+			return nil, err
+		}
+		builder.WriteString(fmt.Sprintf("%s<<%s\n%s\n%s\n", key, delimiter, value, delimiter))
+	}
+	return []byte(builder.String()), nil
+}
+
+func githubDelimiter(value string) (string, error) {
+	for {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		delimiter := "ghadelimiter_" + hex.EncodeToString(b)
+		if !strings.Contains(value, delimiter) {
+			return delimiter, nil
+		}
+	}
+}
+
+func sortedKeys(envMap map[string]string) []string {
+	keys := make([]string, 0, len(envMap))
+	for key := range envMap {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,225 @@
+package env
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range Formats {
+		got, err := ParseFormat(string(format))
+		require.NoError(t, err)
+		assert.Equal(t, format, got)
+	}
+
+	_, err := ParseFormat("toml")
+	assert.ErrorContains(t, err, `invalid format "toml", must be one of: dotenv, json, yaml`)
+}
+
+func TestMarshalEnv(t *testing.T) {
+	t.Parallel()
+
+	vars := map[string]string{
+		"PORT":  "3000",
+		"QUOTE": `it's "quoted" \ $HOME`,
+		"KEY":   "-----BEGIN KEY-----\nabc\n-----END KEY-----",
+	}
+
+	t.Run("Dotenv", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := marshalEnv(FormatDotenv, vars, "web-production")
+		require.NoError(t, err)
+		assert.Equal(t, "KEY=\"-----BEGIN KEY-----\nabc\n-----END KEY-----\"\n"+
+			"PORT=3000\n"+
+			`QUOTE="it's \"quoted\" \\ $$HOME"`+"\n", string(got))
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := marshalEnv(FormatJSON, vars, "web-production")
+		require.NoError(t, err)
+
+		decoded := map[string]string{}
+		require.NoError(t, json.Unmarshal(got, &decoded))
+		assert.Equal(t, vars, decoded)
+		assert.True(t, bytes.HasPrefix(got, []byte("{\n  \"KEY\"")), "Keys should be sorted and indented")
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := marshalEnv(FormatYAML, vars, "web-production")
+		require.NoError(t, err)
+
+		decoded := map[string]string{}
+		require.NoError(t, yaml.Unmarshal(got, &decoded))
+		assert.Equal(t, vars, decoded)
+		assert.Contains(t, string(got), `PORT: "3000"`, "Numbers should stay strings")
+	})
+
+	t.Run("YAML Empty", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := marshalEnv(FormatYAML, map[string]string{}, "web-production")
+		require.NoError(t, err)
+		assert.Equal(t, "{}\n", string(got))
+	})
+
+	t.Run("Shell Export", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := marshalEnv(FormatShellExport, vars, "web-production")
+		require.NoError(t, err)
+		assert.Equal(t, "export KEY='-----BEGIN KEY-----\nabc\n-----END KEY-----'\n"+
+			"export PORT='3000'\n"+
+			`export QUOTE='it'\''s "quoted" \ $HOME'`+"\n", string(got))
+	})
+
+	t.Run("Docker Env", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := marshalEnv(FormatDockerEnv, map[string]string{"PORT": "3000", "QUOTE": `a "b" c`}, "web-production")
+		require.NoError(t, err)
+		assert.Equal(t, "PORT=3000\nQUOTE=a \"b\" c\n", string(got))
+	})
+
+	t.Run("Docker Env Multi-line", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := marshalEnv(FormatDockerEnv, vars, "web-production")
+		assert.ErrorContains(t, err, "KEY has a multi-line value, which docker-env files can't hold")
+	})
+
+	t.Run("Kubernetes Secret", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := marshalEnv(FormatK8sSecret, vars, "web-production")
+		require.NoError(t, err)
+
+		var secret k8sSecret
+		require.NoError(t, yaml.Unmarshal(got, &secret))
+		assert.Equal(t, "v1", secret.APIVersion)
+		assert.Equal(t, "Secret", secret.Kind)
+		assert.Equal(t, "web-production", secret.Metadata.Name)
+		assert.Equal(t, "Opaque", secret.Type)
+
+		for key, value := range vars {
+			decoded, err := base64.StdEncoding.DecodeString(secret.Data[key])
+			require.NoError(t, err)
+			assert.Equal(t, value, string(decoded))
+		}
+	})
+
+	t.Run("Kubernetes Secret Name", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := marshalEnv(FormatK8sSecret, vars, "My_App-production")
+		require.NoError(t, err)
+
+		var secret k8sSecret
+		require.NoError(t, yaml.Unmarshal(got, &secret))
+		assert.Equal(t, "my-app-production", secret.Metadata.Name)
+	})
+
+	t.Run("GitHub Env", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := marshalEnv(FormatGitHubEnv, vars, "web-production")
+		require.NoError(t, err)
+		assert.Regexp(t, regexp.MustCompile(`^KEY<<(ghadelimiter_[0-9a-f]{32})\n`+
+			`-----BEGIN KEY-----\nabc\n-----END KEY-----\n`+
+			`(ghadelimiter_[0-9a-f]{32})\n`+
+			`PORT=3000\n`+
+			`QUOTE=it's "quoted" \\ \$HOME\n$`), string(got))
+
+		delimiters := regexp.MustCompile(`ghadelimiter_[0-9a-f]{32}`).FindAllString(string(got), -1)
+		require.Len(t, delimiters, 2)
+		assert.Equal(t, delimiters[0], delimiters[1])
+	})
+}
+
+func TestK8sName(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]struct {
+		input string
+		want  string
+	}{
+		"Valid":              {input: "web-production", want: "web-production"},
+		"Uppercase":          {input: "Web-Production", want: "web-production"},
+		"Invalid Characters": {input: "my_app@v2-staging", want: "my-app-v2-staging"},
+		"Dots Kept":          {input: "web.app-production", want: "web.app-production"},
+		"Trimmed":            {input: "_web-production.", want: "web-production"},
+		"Too Long":           {input: strings.Repeat("a", 300), want: strings.Repeat("a", 253)},
+		"Nothing Valid":      {input: "__", want: "env"},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.want, k8sName(test.input))
+		})
+	}
+}
+
+func TestWriteMapToFileFormat(t *testing.T) {
+	t.Parallel()
+
+	app := appdef.App{Name: "web", Path: "./web"}
+	vars := appdef.EnvVar{"FOO": {Value: "bar", Source: appdef.EnvSourceValue}}
+
+	t.Run("Default Path", func(t *testing.T) {
+		t.Parallel()
+
+		input := setup(t, &appdef.Definition{})
+		err := writeMapToFile(writeArgs{
+			Input:       input,
+			Vars:        vars,
+			App:         app,
+			Environment: env.Production,
+			Format:      FormatJSON,
+		})
+		require.NoError(t, err)
+
+		content, err := afero.ReadFile(input.FS, "web/.env.production.json")
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"FOO": "bar"}`, string(content))
+	})
+
+	t.Run("Stdout", func(t *testing.T) {
+		t.Parallel()
+
+		input := setup(t, &appdef.Definition{})
+		buf := &bytes.Buffer{}
+		err := writeMapToFile(writeArgs{
+			Input:       input,
+			Vars:        vars,
+			App:         app,
+			Environment: env.Production,
+			Format:      FormatShellExport,
+			Stdout:      buf,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "export FOO='bar'\n", buf.String())
+
+		exists, err := afero.Exists(input.FS, "web/.env.production")
+		require.NoError(t, err)
+		assert.False(t, exists, "Nothing should be written to disk")
+	})
+}
@@ -3,7 +3,10 @@ package env
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 
+	"github.com/briandowns/spinner"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v3"
 
@@ -14,9 +17,11 @@ import (
 )
 
 var GenerateCmd = &cli.Command{
-	Name:        "generate",
-	Usage:       "Generate env file for a specific app and environment",
-	Description: "Generates a .env file for a specific app and environment with a custom output path. Useful for VM deployments.",
+	Name:  "generate",
+	Usage: "Generate env file for a specific app and environment",
+	Description: "Generates a .env file for a specific app and environment with a custom output path. Useful for VM deployments. " +
+		"Pass --format to write the variables as JSON, YAML, shell exports, a Docker env file, a Kubernetes Secret " +
+		"or $GITHUB_ENV lines, and --output - to write them to stdout.",
 	Flags: []cli.Flag{
 		&cli.StringFlag{
 			Name:     "app",
@@ -31,24 +36,100 @@ var GenerateCmd = &cli.Command{
 		},
 		&cli.StringFlag{
 			Name:  "output",
-			Usage: "Output path for the .env file (defaults to {app.Path}/.env.{environment})",
+			Usage: "Output path for the .env file, or - for stdout (defaults to {app.Path}/.env.{environment})",
+		},
+		&cli.StringFlag{
+			Name:    "format",
+			Aliases: []string{"f"},
+			Usage:   "Output format (dotenv, json, yaml, shell-export, docker-env, k8s-secret, github-env)",
+			Value:   string(FormatDotenv),
+		},
+		&cli.BoolFlag{
+			Name:  "refresh",
+			Usage: "Fetch Terraform outputs instead of using the cache in .webkit",
 		},
 	},
 	Action: cmdtools.Wrap(Generate),
 }
 
 // Generate creates a .env file for a specific app and environment.
+//
+// With --output -, the variables are written to stdout so they can be
+// piped, e.g. `webkit env generate --format github-env --output - >>
+// "$GITHUB_ENV"`, and any other output is written to stderr.
 func Generate(ctx context.Context, input cmdtools.CommandInput) error {
-	appDef := input.AppDef()
-	printer := input.Printer()
-	spinner := input.Spinner()
+	format, err := ParseFormat(input.Command.String("format"))
+	if err != nil {
+		return err
+	}
 
 	appName := input.Command.String("app")
 	environmentStr := input.Command.String("environment")
 	outputPath := input.Command.String("output")
 
+	printer := input.Printer()
+	spinner := input.Spinner()
+
+	var stdout io.Writer
+	if outputPath == "-" {
+		stdout = os.Stdout
+		useStderr(&input, spinner)
+	}
+
 	environment := env.Environment(environmentStr)
 
+	targetApp, vars, err := resolveAppVars(ctx, input, spinner, appName, environment)
+	if err != nil {
+		return err
+	}
+
+	if len(vars) == 0 {
+		printer.Warn(fmt.Sprintf("No environment variables defined for app '%s' in environment '%s'", appName, environment))
+		return nil
+	}
+
+	err = writeMapToFile(writeArgs{
+		Input:            input,
+		Vars:             vars,
+		App:              targetApp,
+		Environment:      environment,
+		CustomOutputPath: outputPath,
+		Format:           format,
+		Stdout:           stdout,
+	})
+	if err != nil {
+		return err
+	}
+
+	if stdout != nil {
+		return nil
+	}
+
+	if outputPath == "" {
+		outputPath = defaultOutputPath(targetApp, environment, format)
+	}
+
+	printer.Success(fmt.Sprintf("Generated env file for app '%s' (%s) at: %s", appName, environment, outputPath))
+
+	return nil
+}
+
+// useStderr writes the messages and spinner of the command to stderr,
+// keeping stdout for the variables or the output of a child process.
+func useStderr(input *cmdtools.CommandInput, spinner *spinner.Spinner) {
+	if input.Silent {
+		return
+	}
+	input.Printer().SetWriter(os.Stderr)
+	spinner.Writer = os.Stderr
+}
+
+// resolveAppVars resolves the variables of an app in an environment,
+// merged with the shared variables, in memory. Terraform outputs are
+// only fetched if a variable references a resource.
+func resolveAppVars(ctx context.Context, input cmdtools.CommandInput, spinner *spinner.Spinner, appName string, environment env.Environment) (appdef.App, appdef.EnvVar, error) {
+	appDef := input.AppDef()
+
 	var targetApp *appdef.App
 	for _, app := range appDef.Apps {
 		if app.Name == appName {
@@ -58,62 +139,42 @@ func Generate(ctx context.Context, input cmdtools.CommandInput) error {
 	}
 
 	if targetApp == nil {
-		return fmt.Errorf("app '%s' not found in app.json", appName)
+		return appdef.App{}, nil, fmt.Errorf("app '%s' not found in app.json", appName)
 	}
 
 	// Check if we need to fetch Terraform outputs (only if there are resource references).
 	var tfOutputs *secrets.TerraformOutputProvider
-	var err error
 	if hasResourceReferences(appDef) {
-		printer.Println("Fetching Terraform outputs...")
+		input.Printer().Println("Fetching Terraform outputs...")
 		spinner.Start()
 
-		tfOutputs, err = fetchTerraformOutputs(ctx, input, environment)
+		var err error
+		tfOutputs, err = input.TerraformOutputs(ctx, environment, cmdtools.OutputOptions{
+			Refresh: input.Command.Bool("refresh"),
+		})
 		if err != nil {
 			spinner.Stop()
-			return errors.Wrap(err, "fetching terraform outputs")
+			return appdef.App{}, nil, errors.Wrap(err, "fetching terraform outputs")
 		}
 
 		spinner.Stop()
 	}
 
-	err = secrets.ResolveForEnvironment(ctx, appDef, environment, secrets.ResolveConfig{
+	err := secrets.ResolveForEnvironment(ctx, appDef, environment, secrets.ResolveConfig{
 		SOPSClient:      input.SOPSClient(),
 		BaseDir:         input.BaseDir,
 		TerraformOutput: tfOutputs,
 	})
 	if err != nil {
-		return err
+		return appdef.App{}, nil, err
 	}
 
 	mergedApp := targetApp.MergeEnvironments(appDef.Shared.Env)
 
 	vars, err := mergedApp.GetVarsForEnvironment(environment)
 	if err != nil {
-		return err
-	}
-
-	if len(vars) == 0 {
-		printer.Warn(fmt.Sprintf("No environment variables defined for app '%s' in environment '%s'", appName, environment))
-		return nil
-	}
-
-	err = writeMapToFile(writeArgs{
-		Input:            input,
-		Vars:             vars,
-		App:              *targetApp,
-		Environment:      environment,
-		CustomOutputPath: outputPath,
-	})
-	if err != nil {
-		return err
+		return appdef.App{}, nil, err
 	}
 
-	if outputPath == "" {
-		outputPath = fmt.Sprintf("%s/.env%s", targetApp.Path, envSuffix(environment))
-	}
-
-	printer.Success(fmt.Sprintf("Generated env file for app '%s' (%s) at: %s", appName, environment, outputPath))
-
-	return nil
+	return *targetApp, vars, nil
 }
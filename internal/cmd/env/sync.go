@@ -2,20 +2,27 @@ package env
 
 import (
 	"context"
+	"maps"
 
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v3"
 
 	"github.com/ainsleydev/webkit/internal/cmdtools"
-	"github.com/ainsleydev/webkit/internal/infra"
 	"github.com/ainsleydev/webkit/internal/secrets"
+	"github.com/ainsleydev/webkit/pkg/env"
 )
 
 var SyncCmd = &cli.Command{
 	Name:        "sync",
 	Usage:       "Sync secrets to env files from app.json",
 	Description: "Reads app.json and adds creates or updates .env files in the relevant app directories",
-	Action:      cmdtools.Wrap(Sync),
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "refresh",
+			Usage: "Fetch Terraform outputs instead of using the cache in .webkit",
+		},
+	},
+	Action: cmdtools.Wrap(Sync),
 }
 
 // Sync
@@ -81,37 +88,27 @@ func Sync(ctx context.Context, input cmdtools.CommandInput) error {
 }
 
 // fetchAllTerraformOutputs fetches Terraform outputs for all environments that have .env files.
+//
+// Development resources run locally (see webkit dev) rather than through
+// Terraform, so there are no outputs to fetch for them.
 func fetchAllTerraformOutputs(
 	ctx context.Context,
 	input cmdtools.CommandInput,
 ) (*secrets.TerraformOutputProvider, error) {
 	provider := make(secrets.TerraformOutputProvider)
 
-	tf, err := infra.NewTerraform(ctx, input.AppDef(), input.Manifest)
-	if err != nil {
-		return nil, errors.Wrap(err, "creating terraform manager")
-	}
-
-	if err := tf.Init(ctx); err != nil {
-		return nil, errors.Wrap(err, "initialising terraform")
-	}
-	defer tf.Cleanup()
-
 	for _, environment := range environmentsWithDotEnv {
-		result, err := tf.Output(ctx, environment)
+		if environment == env.Development {
+			continue
+		}
+
+		outputs, err := input.TerraformOutputs(ctx, environment, cmdtools.OutputOptions{
+			Refresh: input.Command.Bool("refresh"),
+		})
 		if err != nil {
 			return nil, errors.Wrap(err, "retrieving terraform outputs for "+string(environment))
 		}
-		for resourceName, outputs := range result.Resources {
-			for outputName, value := range outputs {
-				key := secrets.OutputKey{
-					Environment:  environment,
-					ResourceName: resourceName,
-					OutputName:   outputName,
-				}
-				provider[key] = value
-			}
-		}
+		maps.Copy(provider, *outputs)
 	}
 
 	return &provider, nil
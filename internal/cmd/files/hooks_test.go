@@ -1,12 +1,21 @@
 package files
 
 import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"filippo.io/age"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 
 	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/secrets/sops"
+	"github.com/ainsleydev/webkit/internal/util/executil"
 )
 
 func TestHooks(t *testing.T) {
@@ -28,6 +37,76 @@ func TestHooks(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotEmpty(t, file)
 		assert.Contains(t, string(file), "test-project")
+		assert.Contains(t, string(file), `git show ":$file"`, "Should check the staged secret files")
+	})
+
+	t.Run("Secrets Check", func(t *testing.T) {
+		t.Parallel()
+
+		if !executil.Exists("git") {
+			t.Skip("git not found in PATH")
+		}
+
+		input := setup(t, afero.NewMemMapFs(), &appdef.Definition{})
+		require.NoError(t, Hooks(t.Context(), input))
+
+		file, err := afero.ReadFile(input.FS, "lefthook.yaml")
+		require.NoError(t, err)
+
+		var config struct {
+			PreCommit struct {
+				Commands map[string]struct {
+					Run string `yaml:"run"`
+				} `yaml:"commands"`
+			} `yaml:"pre-commit"`
+		}
+		require.NoError(t, yaml.Unmarshal(file, &config))
+		script := config.PreCommit.Commands["secrets"].Run
+		require.NotEmpty(t, script)
+
+		identity, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		encrypted, err := sops.NewNativeClient(nil, []age.Recipient{identity.Recipient()}).
+			EncryptData([]byte("API_KEY: secret\nEMPTY: \"\"\nDATABASE:\n  URL: postgres://\n"))
+		require.NoError(t, err)
+
+		tt := map[string]struct {
+			content []byte
+			wantErr bool
+		}{
+			"Encrypted":         {content: encrypted, wantErr: false},
+			"Plain Text":        {content: []byte("API_KEY: secret\n"), wantErr: true},
+			"Sops Key Only":     {content: []byte("sops: yes\nAPI_KEY: secret\n"), wantErr: true},
+			"MAC Outside Block": {content: []byte("sops: {}\nAPI_KEY:\n  mac: secret\n"), wantErr: true},
+		}
+
+		for name, test := range tt {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				dir := t.TempDir()
+				path := filepath.Join("resources", "secrets", "production.yaml")
+				require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0o755))
+				require.NoError(t, os.WriteFile(filepath.Join(dir, path), test.content, 0o644))
+
+				for _, args := range [][]string{{"init", "-q"}, {"add", path}} {
+					cmd := exec.Command("git", args...)
+					cmd.Dir = dir
+					out, err := cmd.CombinedOutput()
+					require.NoError(t, err, string(out))
+				}
+
+				cmd := exec.Command("sh", "-c", strings.ReplaceAll(script, "{staged_files}", path))
+				cmd.Dir = dir
+				out, err := cmd.CombinedOutput()
+				if test.wantErr {
+					assert.Error(t, err)
+					assert.Contains(t, string(out), "Unencrypted secrets staged in "+path)
+					return
+				}
+				assert.NoError(t, err, string(out))
+			})
+		}
 	})
 
 	t.Run("FS Failure", func(t *testing.T) {
@@ -0,0 +1,144 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/infra"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+// AdoptCmd defines the command for discovering existing infrastructure
+// by naming convention and importing it into Terraform state.
+var AdoptCmd = &cli.Command{
+	Name:  "adopt",
+	Usage: "Discover existing infrastructure and import it into Terraform state",
+	Description: `Adopt looks up every resource and app in app.json within its provider
+using webkit's naming convention (${project_name}-${name}), proposes an
+import mapping and imports everything in one go.
+
+Examples:
+  # Show what would be imported without touching state
+  webkit infra adopt --dry-run
+
+  # Import everything that was discovered without prompting
+  webkit infra adopt --yes`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "env",
+			Aliases: []string{"e"},
+			Usage:   "Environment to import into (development, staging, production)",
+			Value:   env.Production.String(),
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Only print the proposed import mapping",
+		},
+		&cli.BoolFlag{
+			Name:    "yes",
+			Aliases: []string{"y"},
+			Usage:   "Skip the confirmation prompt",
+		},
+		&cli.BoolFlag{
+			Name:    "silent",
+			Aliases: []string{"s"},
+			Usage:   "Suppress informational output (only show Terraform output)",
+		},
+	},
+	Action: cmdtools.Wrap(Adopt),
+}
+
+var newDiscoverer = infra.NewDiscoverer
+
+// Adopt discovers existing infrastructure and imports it into Terraform state.
+func Adopt(ctx context.Context, input cmdtools.CommandInput) error {
+	printer := input.Printer()
+	spinner := input.Spinner()
+	environment := env.Environment(input.Command.String("env"))
+
+	filtered, _ := input.AppDef().FilterTerraformManaged()
+
+	tfEnv, err := infra.ParseTFEnvironment()
+	if err != nil {
+		return errors.Wrap(err, "parsing terraform environment")
+	}
+
+	printer.Println("Discovering existing infrastructure...")
+	spinner.Start()
+
+	result, err := newDiscoverer(filtered, tfEnv).Discover(ctx)
+
+	spinner.Stop()
+
+	if err != nil {
+		return err
+	}
+
+	for _, miss := range result.Missing {
+		name := miss.Name
+		if name == "" {
+			name = miss.RemoteName
+		}
+		printer.Warn(fmt.Sprintf("Skipping %s %q (%s): %s", miss.Kind, name, miss.Provider, miss.Reason))
+	}
+
+	if len(result.Found) == 0 {
+		printer.Info("No existing infrastructure found to adopt")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(result.Found))
+	for _, item := range result.Found {
+		rows = append(rows, []string{item.Kind.String(), item.Name, string(item.Provider), item.RemoteName, item.ID})
+	}
+	printer.Table([]string{"Kind", "Name", "Provider", "Remote Name", "ID"}, rows)
+
+	if input.Command.Bool("dry-run") {
+		printer.Info("Dry run, no resources were imported")
+		return nil
+	}
+
+	if !input.Command.Bool("yes") && !confirm(fmt.Sprintf("Import %d item(s) into %s state?", len(result.Found), environment)) {
+		printer.Warn("Adopt aborted by user.")
+		return nil
+	}
+
+	tf, cleanup, err := initTerraformWithDefinition(ctx, input, filtered)
+	defer cleanup()
+	if err != nil {
+		return err
+	}
+
+	var imported []string
+	for _, item := range result.Found {
+		spinner.Start()
+		out, err := tf.Import(ctx, item.Input(environment))
+		spinner.Stop()
+
+		imported = append(imported, out.ImportedResources...)
+		if err != nil {
+			printer.Error(fmt.Sprintf("Import of %s %q failed", item.Kind, item.RemoteName))
+			if out.Output != "" {
+				printer.Print(out.Output)
+			}
+			return err
+		}
+	}
+
+	printer.Success(fmt.Sprintf("Successfully imported %d Terraform resource(s)", len(imported)))
+	printer.Info("Imported Terraform addresses:")
+	for _, addr := range imported {
+		printer.Print("  - " + addr)
+	}
+
+	printer.Info("Next steps:")
+	printer.Print("  1. Run 'webkit infra plan' to verify the import")
+	printer.Print("  2. If there are configuration differences, update app.json to match")
+	printer.Print("  3. Run 'webkit infra apply' to finalise any adjustments")
+
+	return nil
+}
@@ -3,7 +3,6 @@ package infra
 import (
 	"context"
 	"errors"
-	"fmt"
 
 	"github.com/urfave/cli/v3"
 
@@ -14,7 +13,7 @@ import (
 var ApplyCmd = &cli.Command{
 	Name:  "apply",
 	Usage: "Creates or updates infrastructure based off the apps and resources defined in app.json",
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		&cli.BoolFlag{
 			Name:    "silent",
 			Aliases: []string{"s"},
@@ -24,7 +23,13 @@ var ApplyCmd = &cli.Command{
 			Name:  "refresh-only",
 			Usage: "Sync Terraform state with actual infrastructure without making changes (uses 'terraform apply -refresh-only')",
 		},
-	},
+		&cli.StringFlag{
+			Name:    "env",
+			Usage:   "Environment to apply (development, staging, production or all)",
+			Aliases: []string{"e"},
+			Value:   env.Production.String(),
+		},
+	}, targetFlags()...),
 	Action: cmdtools.Wrap(Apply),
 }
 
@@ -32,6 +37,11 @@ func Apply(ctx context.Context, input cmdtools.CommandInput) error {
 	printer := input.Printer()
 	refreshOnly := input.Command.Bool("refresh-only")
 
+	envs, err := parseEnvironments(input.Command.String("env"))
+	if err != nil {
+		return err
+	}
+
 	printer.Info("Generating executive plan from app definition")
 	spinner := input.Spinner()
 
@@ -58,8 +68,16 @@ func Apply(ctx context.Context, input cmdtools.CommandInput) error {
 		printer.Print("")
 	}
 
+	targets, addresses, err := parseTargets(input, filtered)
+	if err != nil {
+		return err
+	}
+	if !targets.IsEmpty() {
+		warnTargeted(input, addresses)
+	}
+
 	// Use filtered definition for Terraform.
-	tf, cleanup, err := initTerraformWithDefinition(ctx, input, filtered)
+	managers, cleanup, err := initTerraformEnvironments(ctx, input, filtered, envs, managerOptions(targets)...)
 	defer cleanup()
 	if err != nil {
 		return err
@@ -68,17 +86,29 @@ func Apply(ctx context.Context, input cmdtools.CommandInput) error {
 	printer.Println("Applying Changes...")
 	spinner.Start()
 
-	result, err := tf.Apply(ctx, env.Production, refreshOnly)
-	if err != nil {
-		// Write error output directly to stdout (not through printer)
-		fmt.Print(result.Output) //nolint:forbidigo
-		return errors.New("executing terraform apply")
-	}
+	results := runEnvironments(ctx, envs, func(ctx context.Context, environment env.Environment) envResult {
+		result, err := managers[environment].Apply(ctx, environment, refreshOnly)
+		if err != nil {
+			return envResult{Output: result.Output, Status: "Failed", Err: errors.New("executing terraform apply")}
+		}
+		return envResult{Output: result.Output, Status: "Applied"}
+	})
 
 	spinner.Stop()
 
 	// Write output directly to stdout (not through printer)
-	fmt.Print(result.Output) //nolint:forbidigo
+	printResults(input, results)
+
+	if !targets.IsEmpty() {
+		if err = recordTargeted(input, targets, addresses, results); err != nil {
+			return err
+		}
+	}
+
+	if err = resultsError("apply", results); err != nil {
+		return err
+	}
+
 	printer.Success("Apply succeeded, see console output")
 
 	return nil
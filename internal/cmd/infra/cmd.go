@@ -2,6 +2,7 @@ package infra
 
 import (
 	"context"
+	"maps"
 	"path/filepath"
 
 	"github.com/pkg/errors"
@@ -23,9 +24,12 @@ var Command = &cli.Command{
 	Commands: []*cli.Command{
 		PlanCmd,
 		ApplyCmd,
+		CostCmd,
+		DriftCmd,
 		DestroyCmd,
 		OutputCmd,
 		ImportCmd,
+		AdoptCmd,
 		ExecCmd,
 	},
 	Before: func(ctx context.Context, command *cli.Command) (context.Context, error) {
@@ -38,13 +42,24 @@ var Command = &cli.Command{
 	},
 }
 
-var newTerraform = infra.NewTerraform
+var newManager = infra.NewManager
 
 func initTerraform(ctx context.Context, input cmdtools.CommandInput) (infra.Manager, func(), error) {
 	return initTerraformWithDefinition(ctx, input, input.AppDef())
 }
 
 func initTerraformWithDefinition(ctx context.Context, input cmdtools.CommandInput, appDef *appdef.Definition) (infra.Manager, func(), error) {
+	managers, teardown, err := initTerraformEnvironments(ctx, input, appDef, []env.Environment{env.Production})
+	if err != nil {
+		return nil, teardown, err
+	}
+	return managers[env.Production], teardown, nil
+}
+
+// initTerraformEnvironments initialises a manager for each environment,
+// each with its own work directory and state backend so that they can
+// be run concurrently. Secrets are resolved once and shared.
+func initTerraformEnvironments(ctx context.Context, input cmdtools.CommandInput, appDef *appdef.Definition, envs []env.Environment, opts ...infra.Option) (map[env.Environment]infra.Manager, func(), error) {
 	printer := input.Printer()
 	spinner := input.Spinner()
 
@@ -52,20 +67,25 @@ func initTerraformWithDefinition(ctx context.Context, input cmdtools.CommandInpu
 	printer.Println("Initializing Terraform...")
 	spinner.Start()
 
-	tf, err := newTerraform(ctx, appDef, input.Manifest)
+	managers := make(map[env.Environment]infra.Manager, len(envs))
 	teardown := func() {
-		if tf != nil {
+		for _, tf := range managers {
 			tf.Cleanup()
 		}
 	}
-	if err != nil {
-		spinner.Stop()
-		return nil, teardown, err
-	}
 
-	if err = tf.Init(ctx); err != nil {
-		spinner.Stop()
-		return nil, teardown, err
+	for _, environment := range envs {
+		tf, err := newManager(ctx, appDef, input.Manifest, append([]infra.Option{infra.WithEnvironment(environment)}, opts...)...)
+		if err != nil {
+			spinner.Stop()
+			return nil, teardown, err
+		}
+		managers[environment] = tf
+
+		if err = tf.Init(ctx); err != nil {
+			spinner.Stop()
+			return nil, teardown, err
+		}
 	}
 
 	spinner.Stop()
@@ -79,9 +99,18 @@ func initTerraformWithDefinition(ctx context.Context, input cmdtools.CommandInpu
 		printer.Println("Fetching Terraform outputs...")
 		spinner.Start()
 
-		tfOutputs, err = fetchTerraformOutputs(ctx, tf, env.Production)
-		if err != nil {
-			spinner.Stop()
+		for _, environment := range envs {
+			outputs, err := input.TerraformOutputs(ctx, environment, cmdtools.OutputOptions{
+				Manager: managers[environment],
+			})
+			if err != nil {
+				continue
+			}
+			if tfOutputs == nil {
+				tfOutputs = outputs
+				continue
+			}
+			maps.Copy(*tfOutputs, *outputs)
 		}
 
 		spinner.Stop()
@@ -102,13 +131,13 @@ func initTerraformWithDefinition(ctx context.Context, input cmdtools.CommandInpu
 		}
 	}()
 
-	err = secrets.Resolve(ctx, appDef, resolveConfig)
+	err := secrets.Resolve(ctx, appDef, resolveConfig)
 	if err != nil {
 		spinner.Stop()
 		return nil, func() {}, err
 	}
 
-	return tf, teardown, nil
+	return managers, teardown, nil
 }
 
 // hasResourceReferences checks if the definition contains any environment
@@ -139,23 +168,3 @@ func hasResourceReferences(def *appdef.Definition) bool {
 
 	return false
 }
-
-// fetchTerraformOutputs fetches Terraform outputs for the specified environment.
-// Returns a TerraformOutputProvider containing resource outputs.
-//
-// This function uses an existing, initialized Terraform instance.
-// See also: env/cmd.go:fetchTerraformOutputs for a similar function that manages
-// the full Terraform lifecycle.
-func fetchTerraformOutputs(
-	ctx context.Context,
-	tf *infra.Terraform,
-	environment env.Environment,
-) (*secrets.TerraformOutputProvider, error) {
-	result, err := tf.Output(ctx, environment)
-	if err != nil {
-		return nil, errors.Wrap(err, "retrieving terraform outputs")
-	}
-
-	provider := secrets.TransformOutputs(result, environment)
-	return &provider, nil
-}
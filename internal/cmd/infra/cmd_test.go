@@ -50,13 +50,13 @@ func setup(t *testing.T, appDef *appdef.Definition, mock *mockinfra.MockManager,
 	}
 	input.Printer().SetWriter(io.Discard)
 
-	orig := newTerraform
-	//newTerraform = func(_ context.Context, _ *appdef.Definition, _ *manifest.Tracker) (infra.Manager, error) {
+	orig := newManager
+	//newManager = func(_ context.Context, _ *appdef.Definition, _ *manifest.Tracker, _ ...infra.Option) (infra.Manager, error) {
 	//	return mock, nil
 	//}
 
 	return input, func() {
-		newTerraform = orig
+		newManager = orig
 	}
 }
 
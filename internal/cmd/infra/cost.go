@@ -0,0 +1,123 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+var CostCmd = &cli.Command{
+	Name:        "cost",
+	Usage:       "Estimate the monthly cost of the apps and resources defined in app.json",
+	Description: "Prices each app and resource from the embedded provider price tables and shows the change in cost of the current plan",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "env",
+			Usage:   "Environment to estimate (development, staging, production)",
+			Aliases: []string{"e"},
+			Value:   env.Production.String(),
+		},
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "Output the estimate as JSON",
+		},
+		&cli.BoolFlag{
+			Name:    "silent",
+			Aliases: []string{"s"},
+			Usage:   "Suppress informational output (only show the estimate)",
+		},
+	},
+	Action: cmdtools.Wrap(Cost),
+}
+
+// Cost estimates the monthly cost of the Terraform-managed apps and
+// resources, along with the change in cost that the plan introduces.
+func Cost(ctx context.Context, input cmdtools.CommandInput) error {
+	cmd := input.Command
+	printer := input.Printer()
+	spinner := input.Spinner()
+
+	environment := env.Environment(cmd.String("env"))
+
+	// Only Terraform-managed items incur a cost we can price.
+	filtered, _ := input.AppDef().FilterTerraformManaged()
+
+	tf, cleanup, err := initTerraformWithDefinition(ctx, input, filtered)
+	defer cleanup()
+	if err != nil {
+		return err
+	}
+
+	printer.Println("Estimating costs...")
+	spinner.Start()
+
+	report, err := tf.Cost(ctx, environment)
+	if err != nil {
+		spinner.Stop()
+		return errors.Wrap(err, "estimating costs")
+	}
+
+	spinner.Stop()
+
+	if cmd.Bool("json") {
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "serializing cost report")
+		}
+		fmt.Println(string(out)) //nolint:forbidigo
+		return nil
+	}
+
+	rows := make([][]string, 0, len(report.Items))
+	for _, item := range report.Items {
+		monthly := formatCost(report.Currency, item.Monthly)
+		if !item.Priced {
+			monthly = "unknown"
+		}
+		rows = append(rows, []string{item.Kind, item.Name, item.Provider, item.Size, monthly, item.Note})
+	}
+
+	printer.Print("")
+	printer.Table([]string{"Kind", "Name", "Provider", "Size", "Monthly", "Note"}, rows)
+	printer.Print("")
+	printer.Success(fmt.Sprintf("Estimated monthly cost (%s): %s", environment, formatCost(report.Currency, report.Total)))
+
+	if len(report.Changes) == 0 {
+		printer.Info("The current plan does not change the monthly cost")
+		return nil
+	}
+
+	rows = make([][]string, 0, len(report.Changes))
+	for _, change := range report.Changes {
+		rows = append(rows, []string{
+			change.Address,
+			change.Action,
+			formatCost(report.Currency, change.Before),
+			formatCost(report.Currency, change.After),
+			formatCostDelta(report.Currency, change.Delta),
+		})
+	}
+
+	printer.Print("")
+	printer.Table([]string{"Address", "Action", "Before", "After", "Delta"}, rows)
+	printer.Print("")
+	printer.Info(fmt.Sprintf("The current plan changes the monthly cost by %s", formatCostDelta(report.Currency, report.Delta)))
+
+	return nil
+}
+
+// formatCost formats a monthly cost with its currency.
+func formatCost(currency string, v float64) string {
+	return fmt.Sprintf("%.2f %s", v, currency)
+}
+
+// formatCostDelta formats a change in cost with an explicit sign.
+func formatCostDelta(currency string, v float64) string {
+	return fmt.Sprintf("%+.2f %s", v, currency)
+}
@@ -0,0 +1,178 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/infra"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+var DriftCmd = &cli.Command{
+	Name:        "drift",
+	Usage:       "Detect infrastructure that has been changed outside of Terraform",
+	Description: "Runs a refresh-only plan and lists every resource that no longer matches the Terraform state, exiting with code 2 if drift is found",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "env",
+			Usage:   "Environment to check (development, staging, production)",
+			Aliases: []string{"e"},
+			Value:   env.Production.String(),
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "Output format: text, markdown, or json",
+			Value: "text",
+		},
+		&cli.BoolFlag{
+			Name:    "silent",
+			Aliases: []string{"s"},
+			Usage:   "Suppress informational output (only show markdown or JSON reports)",
+		},
+	},
+	Action: cmdtools.Wrap(Drift),
+}
+
+// Drift detects resources that have been changed outside of Terraform
+// for a single environment and maps them back to app.json.
+func Drift(ctx context.Context, input cmdtools.CommandInput) error {
+	cmd := input.Command
+	printer := input.Printer()
+	spinner := input.Spinner()
+
+	environment := env.Environment(cmd.String("env"))
+	format := cmd.String("format")
+	if !slices.Contains(driftFormats, format) {
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+
+	// Only Terraform-managed items have state that can drift.
+	filtered, _ := input.AppDef().FilterTerraformManaged()
+
+	managers, cleanup, err := initTerraformEnvironments(ctx, input, filtered, []env.Environment{environment})
+	defer cleanup()
+	if err != nil {
+		return err
+	}
+
+	printer.Println("Checking for drift...")
+	spinner.Start()
+
+	report, err := managers[environment].Drift(ctx, environment)
+	if err != nil {
+		spinner.Stop()
+		return errors.Wrap(err, "detecting drift")
+	}
+
+	spinner.Stop()
+
+	switch format {
+	case "markdown":
+		fmt.Println(formatDriftAsMarkdown(report)) //nolint:forbidigo
+	case "json":
+		out, err := formatDriftAsJSON(report)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out) //nolint:forbidigo
+	default:
+		printDriftReport(input, report)
+	}
+
+	if report.HasDrift() {
+		return cmdtools.ExitWithCode(DriftExitCode)
+	}
+
+	return nil
+}
+
+// DriftExitCode is the exit code of the drift command when drift is
+// found, so it can be told apart from errors, which exit with 1.
+const DriftExitCode = 2
+
+// driftFormats are the supported values of the --format flag.
+var driftFormats = []string{"text", "markdown", "json"}
+
+// printDriftReport writes the drifted resources as a table.
+func printDriftReport(input cmdtools.CommandInput, report infra.DriftReport) {
+	printer := input.Printer()
+	printer.Print("")
+
+	if !report.HasDrift() {
+		printer.Success(fmt.Sprintf("No drift detected in %s", report.Environment))
+		return
+	}
+
+	rows := make([][]string, 0, len(report.Resources))
+	for _, r := range report.Resources {
+		rows = append(rows, []string{r.Kind, r.Name, r.Address, r.Action, strings.Join(r.Attributes, ", ")})
+	}
+
+	printer.Table([]string{"Kind", "Name", "Address", "Action", "Attributes"}, rows)
+	printer.Print("")
+	printer.Warn(fmt.Sprintf("%d resource(s) in %s have drifted from the Terraform state", len(report.Resources), report.Environment))
+	printer.Info("Run 'webkit infra apply' to revert the changes, or update app.json to match them")
+}
+
+// formatDriftAsMarkdown formats the report for GitHub issues
+// and comments.
+func formatDriftAsMarkdown(report infra.DriftReport) string {
+	var out strings.Builder
+	out.WriteString(fmt.Sprintf("### Infrastructure Drift (%s)\n\n", report.Environment))
+
+	if !report.HasDrift() {
+		out.WriteString("🟢 **No drift detected** - infrastructure matches the Terraform state")
+		return out.String()
+	}
+
+	out.WriteString(fmt.Sprintf("⚠ **%d resource(s) changed outside of Terraform**\n\n", len(report.Resources)))
+	out.WriteString("| Kind | Name | Address | Action | Attributes |\n")
+	out.WriteString("|------|------|---------|--------|------------|\n")
+	for _, r := range report.Resources {
+		out.WriteString(fmt.Sprintf("| %s | %s | `%s` | %s | %s |\n",
+			orDash(r.Kind), orDash(r.Name), r.Address, r.Action, orDash(strings.Join(r.Attributes, ", "))))
+	}
+
+	if report.Output != "" {
+		out.WriteString("\n<details>\n<summary>Refresh-only plan</summary>\n\n```\n")
+		out.WriteString(strings.TrimSpace(report.Output))
+		out.WriteString("\n```\n\n</details>\n")
+	}
+
+	out.WriteString("\n**Action Required:** Run `webkit infra apply` to revert the changes, or update `app.json` to match them\n")
+
+	return out.String()
+}
+
+// formatDriftAsJSON formats the report as indented JSON.
+func formatDriftAsJSON(report infra.DriftReport) (string, error) {
+	out := struct {
+		DriftDetected bool `json:"drift_detected"`
+		infra.DriftReport
+	}{
+		DriftDetected: report.HasDrift(),
+		DriftReport:   report,
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "serializing drift report")
+	}
+
+	return string(data), nil
+}
+
+// orDash returns a dash for empty table cells.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
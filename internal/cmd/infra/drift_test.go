@@ -0,0 +1,59 @@
+package infra
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/internal/infra"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+func TestFormatDriftAsMarkdown(t *testing.T) {
+	t.Parallel()
+
+	t.Run("No Drift", func(t *testing.T) {
+		t.Parallel()
+
+		got := formatDriftAsMarkdown(infra.DriftReport{Environment: env.Production})
+		assert.Contains(t, got, "### Infrastructure Drift (production)")
+		assert.Contains(t, got, "No drift detected")
+	})
+
+	t.Run("Drift", func(t *testing.T) {
+		t.Parallel()
+
+		got := formatDriftAsMarkdown(infra.DriftReport{
+			Environment: env.Production,
+			Resources: []infra.DriftedResource{
+				{Kind: "resource", Name: "db", Address: `module.resources["db"].x.this`, Action: "update", Attributes: []string{"size", "tags"}},
+				{Address: "digitalocean_project.this", Action: "delete"},
+			},
+			Output: "  ~ update in-place\n",
+		})
+		assert.Contains(t, got, "**2 resource(s) changed outside of Terraform**")
+		assert.Contains(t, got, "| resource | db | `module.resources[\"db\"].x.this` | update | size, tags |")
+		assert.Contains(t, got, "| - | - | `digitalocean_project.this` | delete | - |")
+		assert.Contains(t, got, "```\n~ update in-place\n```")
+	})
+}
+
+func TestFormatDriftAsJSON(t *testing.T) {
+	t.Parallel()
+
+	got, err := formatDriftAsJSON(infra.DriftReport{
+		Environment: env.Staging,
+		Resources:   []infra.DriftedResource{{Kind: "app", Name: "web", Address: "a", Action: "update"}},
+		Output:      "plan output",
+	})
+	require.NoError(t, err)
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal([]byte(got), &out))
+	assert.Equal(t, true, out["drift_detected"])
+	assert.Equal(t, "staging", out["environment"])
+	assert.Len(t, out["resources"], 1)
+	assert.NotContains(t, got, "plan output")
+}
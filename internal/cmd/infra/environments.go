@@ -0,0 +1,116 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+// allEnvironments is the value of the --env flag that runs a
+// command against every deployable environment at once.
+const allEnvironments = "all"
+
+// deployableEnvironments are the environments that are run
+// when --env is set to all.
+var deployableEnvironments = []env.Environment{
+	env.Staging,
+	env.Production,
+}
+
+// parseEnvironments parses the --env flag into the environments
+// a command should run against.
+func parseEnvironments(value string) ([]env.Environment, error) {
+	if value == allEnvironments {
+		return deployableEnvironments, nil
+	}
+	environment := env.Environment(value)
+	if !slices.Contains(env.All, environment) {
+		return nil, fmt.Errorf("invalid environment %q: must be one of development, staging, production or all", value)
+	}
+	return []env.Environment{environment}, nil
+}
+
+// envResult is the result of running a command against
+// a single environment.
+type envResult struct {
+	Environment env.Environment
+	Output      string
+	Status      string
+	Err         error
+}
+
+// runEnvironments runs fn concurrently for each environment and
+// returns the results in the same order as envs.
+func runEnvironments(ctx context.Context, envs []env.Environment, fn func(ctx context.Context, environment env.Environment) envResult) []envResult {
+	results := make([]envResult, len(envs))
+
+	var wg sync.WaitGroup
+	for i, environment := range envs {
+		wg.Go(func() {
+			result := fn(ctx, environment)
+			result.Environment = environment
+			results[i] = result
+		})
+	}
+	wg.Wait()
+
+	return results
+}
+
+// resultsError combines the errors of every failed environment into
+// a single error so the command exits with a non-zero status if any
+// environment failed.
+func resultsError(action string, results []envResult) error {
+	var failed []string
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", result.Environment, result.Err))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return errors.Errorf("%s failed for %d of %d environment(s): %s", action, len(failed), len(results), strings.Join(failed, "; "))
+}
+
+// prefixOutput prefixes every line of the output with the environment
+// so that interleaved results can be told apart.
+func prefixOutput(environment env.Environment, output string) string {
+	if output == "" {
+		return ""
+	}
+	prefix := "[" + environment.String() + "] "
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// printResults writes the output of each environment to stdout. When
+// more than one environment has been run, the output is prefixed and
+// followed by a summary of each environment's status.
+func printResults(input cmdtools.CommandInput, results []envResult) {
+	if len(results) == 1 {
+		fmt.Print(results[0].Output) //nolint:forbidigo
+		return
+	}
+
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		fmt.Print(prefixOutput(result.Environment, result.Output)) //nolint:forbidigo
+		rows = append(rows, []string{result.Environment.String(), result.Status})
+	}
+
+	printer := input.Printer()
+	printer.Print("")
+	printer.Table([]string{"Environment", "Status"}, rows)
+	printer.Print("")
+}
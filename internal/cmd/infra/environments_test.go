@@ -0,0 +1,112 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+func TestParseEnvironments(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]struct {
+		input   string
+		want    []env.Environment
+		wantErr bool
+	}{
+		"All":        {input: "all", want: []env.Environment{env.Staging, env.Production}},
+		"Production": {input: "production", want: []env.Environment{env.Production}},
+		"Staging":    {input: "staging", want: []env.Environment{env.Staging}},
+		"Invalid":    {input: "qa", wantErr: true},
+		"Empty":      {input: "", wantErr: true},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseEnvironments(test.input)
+			assert.Equal(t, test.wantErr, err != nil)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestRunEnvironments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Runs Concurrently", func(t *testing.T) {
+		t.Parallel()
+
+		// Each environment waits on the other, so this only
+		// completes if they're run at the same time.
+		started := make(chan struct{})
+		results := runEnvironments(t.Context(), []env.Environment{env.Staging, env.Production},
+			func(_ context.Context, environment env.Environment) envResult {
+				if environment == env.Staging {
+					started <- struct{}{}
+				} else {
+					<-started
+				}
+				return envResult{Output: environment.String()}
+			})
+
+		require.Len(t, results, 2)
+		assert.Equal(t, env.Staging, results[0].Environment)
+		assert.Equal(t, "staging", results[0].Output)
+		assert.Equal(t, env.Production, results[1].Environment)
+		assert.Equal(t, "production", results[1].Output)
+	})
+
+	t.Run("Aggregates Errors", func(t *testing.T) {
+		t.Parallel()
+
+		results := runEnvironments(t.Context(), []env.Environment{env.Staging, env.Production},
+			func(_ context.Context, environment env.Environment) envResult {
+				if environment == env.Production {
+					return envResult{Err: errors.New("boom")}
+				}
+				return envResult{}
+			})
+
+		err := resultsError("plan", results)
+		require.Error(t, err)
+		assert.Equal(t, "plan failed for 1 of 2 environment(s): production: boom", err.Error())
+	})
+
+	t.Run("No Errors", func(t *testing.T) {
+		t.Parallel()
+
+		results := runEnvironments(t.Context(), []env.Environment{env.Staging},
+			func(context.Context, env.Environment) envResult {
+				return envResult{}
+			})
+
+		assert.NoError(t, resultsError("plan", results))
+	})
+}
+
+func TestPrefixOutput(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]struct {
+		input string
+		want  string
+	}{
+		"Empty":       {input: "", want: ""},
+		"Single Line": {input: "No changes.", want: "[staging] No changes.\n"},
+		"Multi Line":  {input: "Plan: 1 to add\n\nDone\n", want: "[staging] Plan: 1 to add\n[staging] \n[staging] Done\n"},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.want, prefixOutput(env.Staging, test.input))
+		})
+	}
+}
@@ -2,7 +2,6 @@ package infra
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/urfave/cli/v3"
 
@@ -13,7 +12,7 @@ import (
 var PlanCmd = &cli.Command{
 	Name:  "plan",
 	Usage: "Generates an executive plan from the apps and resources defined in app.json",
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		&cli.BoolFlag{
 			Name:    "silent",
 			Aliases: []string{"s"},
@@ -23,7 +22,13 @@ var PlanCmd = &cli.Command{
 			Name:  "refresh-only",
 			Usage: "Show what changes would be made to state by refreshing (without planning infrastructure changes)",
 		},
-	},
+		&cli.StringFlag{
+			Name:    "env",
+			Usage:   "Environment to plan (development, staging, production or all)",
+			Aliases: []string{"e"},
+			Value:   env.Production.String(),
+		},
+	}, targetFlags()...),
 	Action: cmdtools.Wrap(Plan),
 }
 
@@ -31,6 +36,11 @@ func Plan(ctx context.Context, input cmdtools.CommandInput) error {
 	printer := input.Printer()
 	refreshOnly := input.Command.Bool("refresh-only")
 
+	envs, err := parseEnvironments(input.Command.String("env"))
+	if err != nil {
+		return err
+	}
+
 	printer.Info("Generating executive plan from app definition")
 	spinner := input.Spinner()
 
@@ -57,8 +67,16 @@ func Plan(ctx context.Context, input cmdtools.CommandInput) error {
 		printer.Print("")
 	}
 
+	targets, addresses, err := parseTargets(input, filtered)
+	if err != nil {
+		return err
+	}
+	if !targets.IsEmpty() {
+		warnTargeted(input, addresses)
+	}
+
 	// Use filtered definition for Terraform.
-	tf, cleanup, err := initTerraformWithDefinition(ctx, input, filtered)
+	managers, cleanup, err := initTerraformEnvironments(ctx, input, filtered, envs, managerOptions(targets)...)
 	defer cleanup()
 	if err != nil {
 		return err
@@ -67,15 +85,27 @@ func Plan(ctx context.Context, input cmdtools.CommandInput) error {
 	printer.Print("Making Plan...")
 	spinner.Start()
 
-	plan, err := tf.Plan(ctx, env.Production, refreshOnly)
-	if err != nil {
-		return err
-	}
+	results := runEnvironments(ctx, envs, func(ctx context.Context, environment env.Environment) envResult {
+		plan, err := managers[environment].Plan(ctx, environment, refreshOnly)
+		if err != nil {
+			return envResult{Output: plan.Output, Status: "Failed", Err: err}
+		}
+		status := "No changes"
+		if plan.HasChanges {
+			status = "Changes"
+		}
+		return envResult{Output: plan.Output, Status: status}
+	})
 
 	spinner.Stop()
 
 	// Write plan output directly to stdout (not through printer)
-	fmt.Print(plan.Output) //nolint:forbidigo
+	printResults(input, results)
+
+	if err = resultsError("plan", results); err != nil {
+		return err
+	}
+
 	printer.Success("Plan generated, see console output")
 
 	return nil
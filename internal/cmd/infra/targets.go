@@ -0,0 +1,90 @@
+package infra
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/infra"
+	"github.com/ainsleydev/webkit/internal/state/targeted"
+)
+
+// targetFlags returns the flags that limit a plan or apply to
+// a subset of the apps and resources defined in app.json.
+func targetFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  "app",
+			Usage: "Only target the named app from app.json (can be repeated)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "resource",
+			Usage: "Only target the named resource from app.json (can be repeated)",
+		},
+	}
+}
+
+// parseTargets reads the --app and --resource flags and translates them
+// into Terraform addresses, validating that each name exists in the
+// definition that will be passed to Terraform.
+func parseTargets(input cmdtools.CommandInput, def *appdef.Definition) (infra.Targets, []string, error) {
+	targets := infra.Targets{
+		Apps:      input.Command.StringSlice("app"),
+		Resources: input.Command.StringSlice("resource"),
+	}
+	if targets.IsEmpty() {
+		return targets, nil, nil
+	}
+
+	addresses, err := infra.TargetAddresses(def, targets)
+	if err != nil {
+		return targets, nil, errors.Wrap(err, "resolving targets")
+	}
+
+	return targets, addresses, nil
+}
+
+// warnTargeted warns that only the targeted addresses will be planned
+// or applied, so the rest of the project may not match app.json.
+func warnTargeted(input cmdtools.CommandInput, addresses []string) {
+	printer := input.Printer()
+	printer.Warn("Targeting is partial, only the following will be changed:")
+	for _, address := range addresses {
+		printer.Print("  - " + address)
+	}
+	printer.Warn("Anything outside of these targets may not match app.json until the next full apply")
+	printer.Print("")
+}
+
+// recordTargeted records a successful targeted apply in the targeted
+// runs file so partial applies can be traced later on.
+func recordTargeted(input cmdtools.CommandInput, targets infra.Targets, addresses []string, results []envResult) error {
+	for _, result := range results {
+		if result.Err != nil {
+			continue
+		}
+		err := targeted.Record(input.FS, targeted.Run{
+			Environment: result.Environment.String(),
+			Apps:        targets.Apps,
+			Resources:   targets.Resources,
+			Targets:     addresses,
+			AppliedAt:   time.Now().UTC(),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "recording targeted apply for %s", result.Environment)
+		}
+	}
+	return nil
+}
+
+// managerOptions returns the options passed to each
+// manager for the given targets.
+func managerOptions(targets infra.Targets) []infra.Option {
+	if targets.IsEmpty() {
+		return nil
+	}
+	return []infra.Option{infra.WithTargets(targets)}
+}
@@ -0,0 +1,98 @@
+package infra
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/infra"
+	"github.com/ainsleydev/webkit/internal/state/targeted"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+func TestParseTargets(t *testing.T) {
+	t.Parallel()
+
+	def := &appdef.Definition{
+		Apps:      []appdef.App{{Name: "web"}},
+		Resources: []appdef.Resource{{Name: "db"}},
+	}
+
+	setupTargets := func(t *testing.T, flags map[string]string) cmdtools.CommandInput {
+		t.Helper()
+		input := cmdtools.CommandInput{Command: &cli.Command{Flags: targetFlags()}}
+		for name, value := range flags {
+			require.NoError(t, input.Command.Set(name, value))
+		}
+		return input
+	}
+
+	t.Run("No Targets", func(t *testing.T) {
+		t.Parallel()
+
+		targets, addresses, err := parseTargets(setupTargets(t, nil), def)
+		require.NoError(t, err)
+		assert.True(t, targets.IsEmpty())
+		assert.Nil(t, addresses)
+	})
+
+	t.Run("App And Resource", func(t *testing.T) {
+		t.Parallel()
+
+		targets, addresses, err := parseTargets(setupTargets(t, map[string]string{"app": "web", "resource": "db"}), def)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"web"}, targets.Apps)
+		assert.Equal(t, []string{"db"}, targets.Resources)
+		assert.Equal(t, []string{`module.apps["web"]`, `module.resources["db"]`}, addresses)
+	})
+
+	t.Run("Unknown Name", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := parseTargets(setupTargets(t, map[string]string{"app": "api"}), def)
+		assert.ErrorContains(t, err, `app "api" not found`)
+	})
+}
+
+func TestRecordTargeted(t *testing.T) {
+	t.Parallel()
+
+	targets := infra.Targets{Apps: []string{"web"}}
+	addresses := []string{`module.apps["web"]`}
+
+	t.Run("Records Successful Environments", func(t *testing.T) {
+		t.Parallel()
+
+		input := cmdtools.CommandInput{FS: afero.NewMemMapFs()}
+		input.Printer().SetWriter(io.Discard)
+
+		err := recordTargeted(input, targets, addresses, []envResult{
+			{Environment: env.Staging},
+			{Environment: env.Production, Err: errors.New("apply failed")},
+		})
+		require.NoError(t, err)
+
+		got, err := targeted.Load(input.FS)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, "staging", got[0].Environment)
+		assert.Equal(t, []string{"web"}, got[0].Apps)
+		assert.Equal(t, addresses, got[0].Targets)
+	})
+
+	t.Run("Write Error", func(t *testing.T) {
+		t.Parallel()
+
+		input := cmdtools.CommandInput{FS: afero.NewReadOnlyFs(afero.NewMemMapFs())}
+
+		err := recordTargeted(input, targets, addresses, []envResult{{Environment: env.Production}})
+		assert.ErrorContains(t, err, "recording targeted apply for production")
+	})
+}
@@ -17,5 +17,10 @@ var Command = &cli.Command{
 		DecryptCmd,
 		GetCmd,
 		ValidateCmd,
+		KeysCmd,
+		RotateCmd,
+		DiffCmd,
+		EditCmd,
+		SetCmd,
 	},
 }
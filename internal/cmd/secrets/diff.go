@@ -0,0 +1,150 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/secrets"
+	"github.com/ainsleydev/webkit/internal/secrets/sops"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+var DiffCmd = &cli.Command{
+	Name:  "diff",
+	Usage: "Compare the secrets of two environments",
+	Description: "Decrypts the secret files of both environments in memory and lists the keys missing from " +
+		"or extra in the first, and the keys with identical values in both, exiting with a non-zero code " +
+		"if there are any. Values are only printed with --show-values.",
+	ArgsUsage: "<env> <env>",
+	Flags:     diffFlags(),
+	Action:    cmdtools.Wrap(Diff),
+}
+
+func diffFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "show-values",
+			Usage: "Print the values of the listed secrets",
+		},
+	}
+}
+
+// Diff compares the secrets of two environments, for example
+// `secrets diff staging production` lists the keys that production
+// has but staging doesn't, and the keys with identical values that
+// may have been copied from production.
+func Diff(_ context.Context, input cmdtools.CommandInput) error {
+	printer := input.Printer()
+
+	args := input.Command.Args()
+	if args.Len() != 2 {
+		return errors.New("two environments must be provided, e.g. webkit secrets diff staging production")
+	}
+
+	from, err := parseEnvironment(args.Get(0))
+	if err != nil {
+		return err
+	}
+	to, err := parseEnvironment(args.Get(1))
+	if err != nil {
+		return err
+	}
+	if from == to {
+		return errors.New("environments must be different")
+	}
+
+	fromSecrets, err := readSecrets(input, from)
+	if err != nil {
+		return fmt.Errorf("reading %s secrets: %w", from, err)
+	}
+	toSecrets, err := readSecrets(input, to)
+	if err != nil {
+		return fmt.Errorf("reading %s secrets: %w", to, err)
+	}
+
+	diff := secrets.Compare(fromSecrets, toSecrets)
+	if !diff.HasChanges() {
+		printer.Success(fmt.Sprintf("%s and %s have the same keys and no shared values", from, to))
+		return nil
+	}
+
+	showValues := input.Command.Bool("show-values")
+	printKeys := func(keys []string, values map[string]any) {
+		for _, key := range keys {
+			if showValues {
+				printer.Printf("  • %s: %v\n", key, values[key])
+				continue
+			}
+			printer.Printf("  • %s\n", key)
+		}
+	}
+
+	if len(diff.Missing) > 0 {
+		printer.Error(fmt.Sprintf("Missing from %s (only in %s):", from, to))
+		printKeys(diff.Missing, toSecrets)
+	}
+	if len(diff.Extra) > 0 {
+		printer.Warn(fmt.Sprintf("Extra in %s (not in %s):", from, to))
+		printKeys(diff.Extra, fromSecrets)
+	}
+	if len(diff.Identical) > 0 {
+		printer.Warn(fmt.Sprintf("Identical values in %s and %s:", from, to))
+		printKeys(diff.Identical, fromSecrets)
+	}
+
+	return cmdtools.ExitWithCode(1)
+}
+
+// readSecrets returns the secrets of an environment, decrypting the
+// file in memory if it's encrypted so it's never written to disk in
+// plain text.
+func readSecrets(input cmdtools.CommandInput, e env.Environment) (map[string]any, error) {
+	content, _, err := readPlaintext(input, e)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]any{}
+	if err = yaml.Unmarshal(content, &values); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	return values, nil
+}
+
+// readPlaintext returns the contents of the secret file of an
+// environment, decrypted in memory if it's encrypted, and whether
+// the file was encrypted.
+func readPlaintext(input cmdtools.CommandInput, e env.Environment) ([]byte, bool, error) {
+	path := secrets.FilePathFromEnv(e)
+
+	content, err := afero.ReadFile(input.FS, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, fmt.Errorf("%s does not exist", path)
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	if !sops.IsContentEncrypted(content) {
+		return content, false, nil
+	}
+
+	decrypter, ok := input.SOPSClient().(sops.DataDecrypter)
+	if !ok {
+		return nil, false, errors.New("sops client can't decrypt in memory")
+	}
+
+	content, err = sops.DecryptFileData(decrypter, path, content)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return content, true, nil
+}
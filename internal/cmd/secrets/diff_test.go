@@ -0,0 +1,136 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/secrets"
+	"github.com/ainsleydev/webkit/internal/secrets/sops"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	// run parses the arguments like the CLI would before running Diff.
+	run := func(t *testing.T, input cmdtools.CommandInput, args ...string) error {
+		t.Helper()
+
+		cmd := &cli.Command{
+			Name:  "diff",
+			Flags: diffFlags(),
+			Action: func(ctx context.Context, c *cli.Command) error {
+				input.Command = c
+				return Diff(ctx, input)
+			},
+		}
+
+		return cmd.Run(t.Context(), append([]string{"diff"}, args...))
+	}
+
+	setupDiff := func(t *testing.T, staging string) (cmdtools.CommandInput, *bytes.Buffer) {
+		t.Helper()
+
+		input, buf, _ := setupKeys(t, nil)
+
+		encrypted, err := input.SOPSCache.(*sops.NativeClient).EncryptData([]byte("KEY: value\nSTRIPE: sk_live\nNEW: x\n"))
+		require.NoError(t, err)
+		require.NoError(t, afero.WriteFile(input.FS, secrets.FilePathFromEnv(env.Production), encrypted, 0o600))
+
+		if staging != "" {
+			encrypted, err = input.SOPSCache.(*sops.NativeClient).EncryptData([]byte(staging))
+			require.NoError(t, err)
+			require.NoError(t, afero.WriteFile(input.FS, secrets.FilePathFromEnv(env.Staging), encrypted, 0o600))
+		}
+
+		return input, buf
+	}
+
+	t.Run("No Differences", func(t *testing.T) {
+		t.Parallel()
+
+		input, buf := setupDiff(t, "KEY: other\nSTRIPE: sk_test\nNEW: y\n")
+
+		err := run(t, input, "staging", "production")
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "staging and production have the same keys and no shared values")
+	})
+
+	t.Run("Differences", func(t *testing.T) {
+		t.Parallel()
+
+		input, buf := setupDiff(t, "KEY: other\nSTRIPE: sk_live\nOLD: z\n")
+
+		err := run(t, input, "staging", "production")
+		assert.Equal(t, cmdtools.ExitWithCode(1), err)
+
+		out := buf.String()
+		assert.Regexp(t, `Missing from staging \(only in production\):\s+• NEW\n`, out)
+		assert.Regexp(t, `Extra in staging \(not in production\):\s+• OLD\n`, out)
+		assert.Regexp(t, `Identical values in staging and production:\s+• STRIPE\n`, out)
+		assert.NotContains(t, out, "sk_live", "Values shouldn't be printed")
+	})
+
+	t.Run("Show Values", func(t *testing.T) {
+		t.Parallel()
+
+		input, buf := setupDiff(t, "KEY: other\nSTRIPE: sk_live\n")
+
+		err := run(t, input, "--show-values", "staging", "production")
+		assert.Equal(t, cmdtools.ExitWithCode(1), err)
+		assert.Contains(t, buf.String(), "  • NEW: x\n")
+		assert.Contains(t, buf.String(), "  • STRIPE: sk_live\n")
+	})
+
+	t.Run("Plain Text File", func(t *testing.T) {
+		t.Parallel()
+
+		input, buf := setupDiff(t, "")
+		require.NoError(t, afero.WriteFile(input.FS, secrets.FilePathFromEnv(env.Development), []byte("KEY: dev\n"), 0o600))
+
+		err := run(t, input, "development", "production")
+		assert.Equal(t, cmdtools.ExitWithCode(1), err)
+		assert.Contains(t, buf.String(), "Missing from development")
+	})
+
+	t.Run("Missing File", func(t *testing.T) {
+		t.Parallel()
+
+		input, _ := setupDiff(t, "")
+
+		err := run(t, input, "staging", "production")
+		assert.ErrorContains(t, err, "reading staging secrets: resources/secrets/staging.yaml does not exist")
+	})
+
+	t.Run("Invalid Arguments", func(t *testing.T) {
+		t.Parallel()
+
+		tt := map[string]struct {
+			args []string
+			want string
+		}{
+			"None":          {want: "two environments must be provided"},
+			"One":           {args: []string{"staging"}, want: "two environments must be provided"},
+			"Invalid":       {args: []string{"staging", "preview"}, want: "invalid environment: preview"},
+			"Same":          {args: []string{"staging", "staging"}, want: "environments must be different"},
+			"Three Or More": {args: []string{"staging", "production", "development"}, want: "two environments must be provided"},
+		}
+
+		for name, test := range tt {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				input, _ := setupDiff(t, "")
+				err := run(t, input, test.args...)
+				assert.ErrorContains(t, err, test.want)
+			})
+		}
+	})
+}
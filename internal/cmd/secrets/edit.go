@@ -0,0 +1,235 @@
+package secrets
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/secrets"
+	"github.com/ainsleydev/webkit/internal/secrets/sops"
+	"github.com/ainsleydev/webkit/internal/util/executil"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+var EditCmd = &cli.Command{
+	Name:  "edit",
+	Usage: "Edit the secrets of an environment in $EDITOR",
+	Description: "Decrypts the secret file of the environment into a private temporary file, opens it in " +
+		"$VISUAL or $EDITOR (defaulting to vi) and re-encrypts it once the editor exits. The temporary " +
+		"file is always removed. If the edited file isn't valid YAML the editor is opened again, unless " +
+		"you choose to discard the changes.",
+	Flags:  editFlags(),
+	Action: cmdtools.Wrap(Edit),
+}
+
+func editFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "env",
+			Aliases:  []string{"e"},
+			Usage:    "Environment to edit the secrets of (development, staging, production)",
+			Required: true,
+		},
+	}
+}
+
+// Edit opens the decrypted secrets of an environment in the user's
+// editor and re-encrypts them, so they're never left on disk in plain
+// text between `secrets decrypt` and `secrets encrypt`.
+func Edit(ctx context.Context, input cmdtools.CommandInput) error {
+	e, err := parseEnvironment(input.Command.String("env"))
+	if err != nil {
+		return err
+	}
+
+	plaintext, encrypted, err := readSecretFile(input, e)
+	if err != nil {
+		return err
+	}
+
+	stdin := input.Stdin
+	if stdin == nil {
+		stdin = os.Stdin
+	}
+	answers := bufio.NewReader(stdin)
+
+	edited := plaintext
+	for {
+		edited, err = editInTempFile(ctx, input, e, edited)
+		if err != nil {
+			return err
+		}
+
+		if encrypted && bytes.Equal(edited, plaintext) {
+			input.Printer().Info(fmt.Sprintf("No changes to %s secrets", e))
+			return nil
+		}
+
+		err = validateSecretsYAML(edited)
+		if err == nil {
+			break
+		}
+
+		input.Printer().Error(err.Error())
+		if !editAgain(input, answers) {
+			return fmt.Errorf("%w, no changes were saved", err)
+		}
+	}
+
+	if err = saveSecrets(input, e, edited); err != nil {
+		return err
+	}
+
+	input.Printer().Success(fmt.Sprintf("Saved %s secrets", e))
+
+	return nil
+}
+
+// editAgain asks whether to reopen the editor to fix the secrets,
+// defaulting to yes. Without an answer, for example when stdin
+// isn't a terminal, the changes are discarded.
+func editAgain(input cmdtools.CommandInput, answers *bufio.Reader) bool {
+	input.Printer().Print("Edit again? Answering no discards your changes [Y/n]: ")
+
+	answer, err := answers.ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+
+	answer = strings.TrimSpace(strings.ToLower(answer))
+	return answer == "" || answer == "y" || answer == "yes"
+}
+
+// editInTempFile writes the plaintext to a file only the current
+// user can read, opens it in the editor and returns its contents
+// once the editor exits. The file is overwritten and removed before
+// returning, even if the editor fails.
+func editInTempFile(ctx context.Context, input cmdtools.CommandInput, e env.Environment, plaintext []byte) ([]byte, error) {
+	dir, err := os.MkdirTemp(tempDir(), "webkit-secrets-")
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, e.String()+".yaml")
+	defer removeTempFile(dir, path)
+
+	if err = os.WriteFile(path, plaintext, 0o600); err != nil {
+		return nil, err
+	}
+
+	// The terminal sends interrupts to the editor and to webkit, so
+	// they're ignored until the editor exits to make sure the
+	// decrypted file is removed. The same goes for terminations, for
+	// example when the terminal is closed or a process manager stops
+	// webkit.
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupts)
+
+	cmd := editorCommand(path)
+	if _, err = input.Runner.Run(ctx, cmd); err != nil {
+		return nil, fmt.Errorf("running %s: %w", cmd.Name, err)
+	}
+
+	return os.ReadFile(path)
+}
+
+// editorCommand returns the command to open the file in the user's
+// editor, which may include arguments such as `code --wait`.
+func editorCommand(path string) executil.Command {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		fields = []string{"vi"}
+	}
+
+	cmd := executil.NewCommand(fields[0], append(fields[1:], path)...)
+	cmd.Interactive = true
+
+	return cmd
+}
+
+// tempDir returns the directory to write decrypted secrets to,
+// preferring /dev/shm where available as it's held in memory.
+func tempDir() string {
+	if info, err := os.Stat("/dev/shm"); err == nil && info.IsDir() {
+		return "/dev/shm"
+	}
+	return os.TempDir()
+}
+
+// removeTempFile overwrites the decrypted file before removing its
+// directory, so the secrets can't be recovered from the disk.
+func removeTempFile(dir, path string) {
+	if info, err := os.Stat(path); err == nil {
+		_ = os.WriteFile(path, make([]byte, info.Size()), 0o600)
+	}
+	_ = os.RemoveAll(dir)
+}
+
+// readSecretFile returns the decrypted contents of the secret file of
+// an environment and whether it was encrypted.
+func readSecretFile(input cmdtools.CommandInput, e env.Environment) ([]byte, bool, error) {
+	path := secrets.FilePathFromEnv(e)
+	if exists, err := afero.Exists(input.FS, path); err != nil {
+		return nil, false, err
+	} else if !exists {
+		return nil, false, fmt.Errorf("%s does not exist, run webkit secrets scaffold to create it", path)
+	}
+
+	plaintext, encrypted, err := readPlaintext(input, e)
+	if err != nil {
+		return nil, false, fmt.Errorf("reading %s secrets: %w", e, err)
+	}
+	return plaintext, encrypted, nil
+}
+
+// validateSecretsYAML checks the plaintext is a YAML mapping that can
+// be encrypted.
+func validateSecretsYAML(plaintext []byte) error {
+	values := map[string]any{}
+	if err := yaml.Unmarshal(plaintext, &values); err != nil {
+		return fmt.Errorf("invalid YAML: %w", err)
+	}
+	if _, ok := values["sops"]; ok {
+		return errors.New(`invalid YAML: the "sops" key is reserved for SOPS metadata`)
+	}
+	return nil
+}
+
+// saveSecrets encrypts the plaintext for the recipients of the
+// environment in .sops.yaml and replaces its secret file.
+func saveSecrets(input cmdtools.CommandInput, e env.Environment, plaintext []byte) error {
+	recipients, err := secrets.ReadRecipients(input.FS)
+	if err != nil {
+		return err
+	}
+
+	keys, err := sops.ParseRecipients(recipients[e])
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := sops.NewNativeClient(nil, keys).EncryptData(plaintext)
+	if err != nil {
+		return fmt.Errorf("encrypting %s secrets: %w", e, err)
+	}
+
+	return writeSecretFile(input.FS, e, encrypted)
+}
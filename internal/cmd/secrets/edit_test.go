@@ -0,0 +1,192 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/internal/secrets"
+	"github.com/ainsleydev/webkit/internal/util/executil"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+// editor is a Runner that stands in for $EDITOR, rewriting the file
+// it's opened with and recording its path and permissions so tests
+// can check it's private and removed afterwards.
+type editor struct {
+	edit func(content string) string
+	err  error
+	path string
+	perm os.FileMode
+}
+
+func (e *editor) Run(_ context.Context, cmd executil.Command) (executil.Result, error) {
+	e.path = cmd.Args[len(cmd.Args)-1]
+
+	info, err := os.Stat(e.path)
+	if err != nil {
+		return executil.Result{}, err
+	}
+	e.perm = info.Mode().Perm()
+
+	if e.edit != nil {
+		b, err := os.ReadFile(e.path)
+		if err != nil {
+			return executil.Result{}, err
+		}
+		if err = os.WriteFile(e.path, []byte(e.edit(string(b))), 0o600); err != nil {
+			return executil.Result{}, err
+		}
+	}
+
+	return executil.Result{}, e.err
+}
+
+func TestEdit(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]struct {
+		editor   *editor
+		stdin    string
+		wantErr  string
+		wantOut  string
+		wantFile string
+	}{
+		"Saves Changes": {
+			editor:   &editor{edit: func(content string) string { return content + "NEW: secret\n" }},
+			wantOut:  "Saved production secrets",
+			wantFile: "KEY: value\nNEW: secret\n",
+		},
+		"No Changes": {
+			editor:   &editor{},
+			wantOut:  "No changes to production secrets",
+			wantFile: "KEY: value\n",
+		},
+		"Invalid YAML": {
+			editor:   &editor{edit: func(string) string { return "KEY: [value\n" }},
+			wantErr:  "invalid YAML",
+			wantFile: "KEY: value\n",
+		},
+		"Invalid YAML Discarded": {
+			editor:   &editor{edit: func(string) string { return "KEY: [value\n" }},
+			stdin:    "n\n",
+			wantErr:  "invalid YAML",
+			wantFile: "KEY: value\n",
+		},
+		"Invalid YAML Edited Again": {
+			editor: &editor{edit: func(content string) string {
+				if content == "KEY: [value\n" {
+					return "KEY: fixed\n"
+				}
+				return "KEY: [value\n"
+			}},
+			stdin:    "\n",
+			wantOut:  "Saved production secrets",
+			wantFile: "KEY: fixed\n",
+		},
+		"Reserved Key": {
+			editor:   &editor{edit: func(string) string { return "sops: value\n" }},
+			wantErr:  `the "sops" key is reserved`,
+			wantFile: "KEY: value\n",
+		},
+		"Editor Fails": {
+			editor:   &editor{edit: func(string) string { return "KEY: changed\n" }, err: errors.New("exit status 1")},
+			wantErr:  "running vi: exit status 1",
+			wantFile: "KEY: value\n",
+		},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			input, buf, identity := setupKeys(t, editFlags())
+			require.NoError(t, input.Command.Set("env", "production"))
+			input.Runner = test.editor
+			input.Stdin = strings.NewReader(test.stdin)
+
+			err := Edit(t.Context(), input)
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Contains(t, buf.String(), test.wantOut)
+			}
+
+			got, err := decryptWith(t, input.FS, env.Production, identity)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantFile, string(got))
+
+			assert.Equal(t, os.FileMode(0o600), test.editor.perm)
+			assert.NoFileExists(t, test.editor.path, "Decrypted file should be removed")
+		})
+	}
+
+	t.Run("Encrypts Plain Text File", func(t *testing.T) {
+		t.Parallel()
+
+		input, buf, identity := setupKeys(t, editFlags())
+		require.NoError(t, input.Command.Set("env", "staging"))
+		require.NoError(t, afero.WriteFile(input.FS, secrets.FilePathFromEnv(env.Staging), []byte("KEY: staging\n"), 0o600))
+		input.Runner = &editor{}
+
+		err := Edit(t.Context(), input)
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "Saved staging secrets")
+
+		got, err := decryptWith(t, input.FS, env.Staging, identity)
+		require.NoError(t, err)
+		assert.Equal(t, "KEY: staging\n", string(got))
+	})
+
+	t.Run("Missing File", func(t *testing.T) {
+		t.Parallel()
+
+		input, _, _ := setupKeys(t, editFlags())
+		require.NoError(t, input.Command.Set("env", "staging"))
+		input.Runner = &editor{}
+
+		err := Edit(t.Context(), input)
+		assert.ErrorContains(t, err, "resources/secrets/staging.yaml does not exist, run webkit secrets scaffold")
+	})
+
+	t.Run("Invalid Environment", func(t *testing.T) {
+		t.Parallel()
+
+		input, _, _ := setupKeys(t, editFlags())
+		require.NoError(t, input.Command.Set("env", "preview"))
+
+		err := Edit(t.Context(), input)
+		assert.ErrorContains(t, err, "invalid environment: preview")
+	})
+}
+
+func TestEditorCommand(t *testing.T) {
+	tt := map[string]struct {
+		visual string
+		editor string
+		want   string
+	}{
+		"Default":        {want: "vi /tmp/production.yaml"},
+		"Editor":         {editor: "nano", want: "nano /tmp/production.yaml"},
+		"Visual":         {visual: "code --wait", editor: "nano", want: "code --wait /tmp/production.yaml"},
+		"Blank Defaults": {editor: "  ", want: "vi /tmp/production.yaml"},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("VISUAL", test.visual)
+			t.Setenv("EDITOR", test.editor)
+
+			got := editorCommand("/tmp/production.yaml")
+			assert.Equal(t, test.want, got.String())
+			assert.True(t, got.Interactive)
+		})
+	}
+}
@@ -53,6 +53,9 @@ func Get(_ context.Context, input cmdtools.CommandInput) error {
 	key := cmd.String("key")
 	showAll := cmd.Bool("all")
 	client := input.SOPSClient()
+	if !showAll && key != "" {
+		client = sops.WithKeys(client, key)
+	}
 
 	path := filepath.Join(input.BaseDir, secrets.FilePathFromEnv(env.Environment(enviro)))
 	vals, err := sops.DecryptFileToMap(client, path)
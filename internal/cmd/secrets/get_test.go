@@ -1,11 +1,17 @@
 package secrets
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"filippo.io/age"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ainsleydev/webkit/internal/secrets/audit"
+	"github.com/ainsleydev/webkit/internal/secrets/sops"
 	"github.com/ainsleydev/webkit/pkg/env"
 )
 
@@ -50,6 +56,30 @@ func TestGet(t *testing.T) {
 		assert.Contains(t, out, "KEY=1234")
 	})
 
+	t.Run("Single Key Audited", func(t *testing.T) {
+		identity, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		native := sops.NewNativeClient([]age.Identity{identity}, []age.Recipient{identity.Recipient()})
+		encrypted, err := native.EncryptData([]byte("KEY1: \"1234\"\nKEY2: \"abcd\"\n"))
+		require.NoError(t, err)
+
+		input, _ := setupEncryptedProdFile(t, string(encrypted))
+		log := filepath.Join(t.TempDir(), "audit.jsonl")
+		input.SOPSCache = audit.NewClient(native, audit.NewFileSink(log), "webkit secrets get")
+		require.NoError(t, input.Command.Set("env", env.Production.String()))
+		require.NoError(t, input.Command.Set("key", "KEY1"))
+
+		err = Get(t.Context(), input)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(log)
+		require.NoError(t, err)
+
+		var entry audit.Entry
+		require.NoError(t, json.Unmarshal(content, &entry))
+		assert.Equal(t, []string{"KEY1"}, entry.Keys)
+	})
+
 	t.Run("All Keys Success", func(t *testing.T) {
 		input, buf := setupEncryptedProdFile(t, `KEY1: "1234"
 KEY2: "abcd"`)
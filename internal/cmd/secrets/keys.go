@@ -0,0 +1,269 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/secrets"
+	"github.com/ainsleydev/webkit/internal/secrets/sops"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+// KeysCmd manages the age public keys that each environment's secret
+// file is encrypted for.
+var KeysCmd = &cli.Command{
+	Name:        "keys",
+	Usage:       "Manage the age keys that can decrypt each environment",
+	Description: "Lists, adds and removes age recipients per environment in resources/.sops.yaml and re-encrypts the affected secret files.",
+	Commands: []*cli.Command{
+		{
+			Name:   "list",
+			Usage:  "List the recipients of each environment",
+			Flags:  keysListFlags(),
+			Action: cmdtools.Wrap(KeysList),
+		},
+		{
+			Name:   "add",
+			Usage:  "Add a recipient to an environment and re-encrypt its secrets",
+			Flags:  keysFlags(),
+			Action: cmdtools.Wrap(KeysAdd),
+		},
+		{
+			Name:   "remove",
+			Usage:  "Remove a recipient from an environment and re-encrypt its secrets",
+			Flags:  keysFlags(),
+			Action: cmdtools.Wrap(KeysRemove),
+		},
+	},
+}
+
+func keysListFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "env",
+			Aliases: []string{"e"},
+			Usage:   "Only list the recipients of this environment (development, staging, production)",
+		},
+	}
+}
+
+func keysFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "env",
+			Aliases:  []string{"e"},
+			Usage:    "Environment to change the recipients of (development, staging, production)",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "key",
+			Aliases:  []string{"k"},
+			Usage:    "The age public key of the recipient, e.g. age1ql3z7hjy54pw3...",
+			Required: true,
+		},
+	}
+}
+
+// KeysList prints the age public keys that can decrypt each
+// environment, warning if production shares a key with another.
+func KeysList(_ context.Context, input cmdtools.CommandInput) error {
+	printer := input.Printer()
+
+	recipients, err := secrets.ReadRecipients(input.FS)
+	if err != nil {
+		return err
+	}
+
+	envs := env.All
+	if s := input.Command.String("env"); s != "" {
+		e, err := parseEnvironment(s)
+		if err != nil {
+			return err
+		}
+		envs = []env.Environment{e}
+	}
+
+	for _, e := range envs {
+		printer.Println(e.String())
+		printer.List(recipients[e])
+	}
+
+	warnSharedProduction(input, recipients)
+
+	return nil
+}
+
+// KeysAdd adds an age public key to the recipients of an environment
+// and re-encrypts its secret file so the key can decrypt it.
+func KeysAdd(_ context.Context, input cmdtools.CommandInput) error {
+	e, key, err := parseKeyFlags(input)
+	if err != nil {
+		return err
+	}
+
+	recipients, err := secrets.ReadRecipients(input.FS)
+	if err != nil {
+		return err
+	}
+
+	if !recipients.Add(e, key) {
+		input.Printer().Info(fmt.Sprintf("%s is already a recipient of %s", key, e))
+		return nil
+	}
+
+	if err = updateRecipients(input, recipients, e); err != nil {
+		return err
+	}
+
+	input.Printer().Success(fmt.Sprintf("Added %s as a recipient of %s", key, e))
+
+	return nil
+}
+
+// KeysRemove removes an age public key from the recipients of an
+// environment and re-encrypts its secret file with a new data key, so
+// the removed key can no longer decrypt it.
+//
+// The removed key could have kept a copy of the secrets, so they
+// should be rotated as well.
+func KeysRemove(_ context.Context, input cmdtools.CommandInput) error {
+	e, key, err := parseKeyFlags(input)
+	if err != nil {
+		return err
+	}
+
+	recipients, err := secrets.ReadRecipients(input.FS)
+	if err != nil {
+		return err
+	}
+
+	if err = recipients.Remove(e, key); err != nil {
+		return err
+	}
+
+	if err = updateRecipients(input, recipients, e); err != nil {
+		return err
+	}
+
+	input.Printer().Success(fmt.Sprintf("Removed %s as a recipient of %s", key, e))
+
+	return nil
+}
+
+// updateRecipients re-encrypts the secret file of the environment for
+// its new recipients before writing them to .sops.yaml, so the config
+// is left untouched if the file can't be decrypted.
+func updateRecipients(input cmdtools.CommandInput, recipients secrets.Recipients, e env.Environment) error {
+	if err := reencrypt(input, e, recipients[e]); err != nil {
+		return fmt.Errorf("re-encrypting %s secrets: %w", e, err)
+	}
+
+	if err := input.Generator().YAML(secrets.SOPSConfigPath, recipients.SOPSConfig()); err != nil {
+		return fmt.Errorf("writing %s: %w", secrets.SOPSConfigPath, err)
+	}
+
+	warnSharedProduction(input, recipients)
+
+	return nil
+}
+
+// reencrypt decrypts the secret file of the environment in memory and
+// encrypts it for the given recipients.
+func reencrypt(input cmdtools.CommandInput, e env.Environment, keys []string) error {
+	encrypted, err := reencryptData(input, e, keys)
+	if err != nil || encrypted == nil {
+		return err
+	}
+	return writeSecretFile(input.FS, e, encrypted)
+}
+
+// reencryptData returns the secret file of the environment encrypted
+// for the given recipients with a new data key, without writing it.
+//
+// Returns nil if the file is missing, empty or not yet encrypted, as
+// it's encrypted for the new recipients by `secrets encrypt`.
+func reencryptData(input cmdtools.CommandInput, e env.Environment, keys []string) ([]byte, error) {
+	path := secrets.FilePathFromEnv(e)
+
+	content, err := afero.ReadFile(input.FS, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !sops.IsContentEncrypted(content) {
+		return nil, nil
+	}
+
+	decrypter, ok := input.SOPSClient().(sops.DataDecrypter)
+	if !ok {
+		return nil, errors.New("sops client can't decrypt in memory")
+	}
+
+	plaintext, err := sops.DecryptFileData(decrypter, path, content)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients, err := sops.ParseRecipients(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return sops.NewNativeClient(nil, recipients).EncryptData(plaintext)
+}
+
+// writeSecretFile replaces the secret file of the environment, keeping
+// its permissions.
+func writeSecretFile(fs afero.Fs, e env.Environment, content []byte) error {
+	path := secrets.FilePathFromEnv(e)
+
+	info, err := fs.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	return afero.WriteFile(fs, path, content, info.Mode().Perm())
+}
+
+// parseKeyFlags returns the environment and age public key passed
+// to the add and remove commands.
+func parseKeyFlags(input cmdtools.CommandInput) (env.Environment, string, error) {
+	e, err := parseEnvironment(input.Command.String("env"))
+	if err != nil {
+		return "", "", err
+	}
+
+	key := strings.TrimSpace(input.Command.String("key"))
+	if _, err = sops.ParseRecipients([]string{key}); err != nil {
+		return "", "", err
+	}
+
+	return e, key, nil
+}
+
+func parseEnvironment(s string) (env.Environment, error) {
+	e := env.Environment(s)
+	if !slices.Contains(env.All, e) {
+		return "", fmt.Errorf("invalid environment: %s", s)
+	}
+	return e, nil
+}
+
+func warnSharedProduction(input cmdtools.CommandInput, recipients secrets.Recipients) {
+	if shared := recipients.SharedWithProduction(); len(shared) > 0 {
+		input.Printer().Warn(fmt.Sprintf(
+			"Production shares %s with another environment, add a separate key to production and remove the shared one",
+			strings.Join(shared, ", "),
+		))
+	}
+}
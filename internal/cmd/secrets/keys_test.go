@@ -0,0 +1,253 @@
+package secrets
+
+import (
+	"bytes"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/secrets"
+	"github.com/ainsleydev/webkit/internal/secrets/sops"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+// setupKeys returns an input whose .sops.yaml encrypts every
+// environment for a new identity, and whose production secrets are
+// encrypted for it.
+func setupKeys(t *testing.T, flags []cli.Flag) (cmdtools.CommandInput, *bytes.Buffer, *age.X25519Identity) {
+	t.Helper()
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	input, buf := setup(t, &appdef.Definition{})
+	input.Command = &cli.Command{Flags: flags}
+	input.SOPSCache = sops.NewNativeClient([]age.Identity{identity}, []age.Recipient{identity.Recipient()})
+
+	recipients := secrets.Recipients{}
+	for _, e := range env.All {
+		recipients[e] = []string{identity.Recipient().String()}
+	}
+	require.NoError(t, input.Generator().YAML(secrets.SOPSConfigPath, recipients.SOPSConfig()))
+
+	encrypted, err := input.SOPSCache.(*sops.NativeClient).EncryptData([]byte("KEY: value\n"))
+	require.NoError(t, err)
+	require.NoError(t, afero.WriteFile(input.FS, secrets.FilePathFromEnv(env.Production), encrypted, 0o600))
+
+	return input, buf, identity
+}
+
+func decryptWith(t *testing.T, fs afero.Fs, e env.Environment, identity age.Identity) ([]byte, error) {
+	t.Helper()
+
+	content, err := afero.ReadFile(fs, secrets.FilePathFromEnv(e))
+	require.NoError(t, err)
+
+	return sops.NewNativeClient([]age.Identity{identity}, nil).DecryptData(content)
+}
+
+func TestKeysList(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Default Recipients", func(t *testing.T) {
+		t.Parallel()
+
+		input, buf := setup(t, &appdef.Definition{})
+		input.Command = &cli.Command{Flags: keysListFlags()}
+
+		err := KeysList(t.Context(), input)
+		require.NoError(t, err)
+
+		out := buf.String()
+		for _, e := range env.All {
+			assert.Contains(t, out, e.String())
+		}
+		assert.Contains(t, out, secrets.AgePublicKey)
+		assert.Contains(t, out, "Production shares")
+	})
+
+	t.Run("Single Environment", func(t *testing.T) {
+		t.Parallel()
+
+		input, buf, identity := setupKeys(t, keysListFlags())
+		require.NoError(t, input.Command.Set("env", env.Staging.String()))
+
+		err := KeysList(t.Context(), input)
+		require.NoError(t, err)
+
+		out := buf.String()
+		assert.Contains(t, out, "staging")
+		assert.NotContains(t, out, "development")
+		assert.Contains(t, out, identity.Recipient().String())
+	})
+
+	t.Run("Invalid Environment", func(t *testing.T) {
+		t.Parallel()
+
+		input, _ := setup(t, &appdef.Definition{})
+		input.Command = &cli.Command{Flags: keysListFlags()}
+		require.NoError(t, input.Command.Set("env", "preview"))
+
+		err := KeysList(t.Context(), input)
+		assert.ErrorContains(t, err, "invalid environment: preview")
+	})
+}
+
+func TestKeysAdd(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Re-encrypts For New Recipient", func(t *testing.T) {
+		t.Parallel()
+
+		input, buf, identity := setupKeys(t, keysFlags())
+		production, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+
+		require.NoError(t, input.Command.Set("env", env.Production.String()))
+		require.NoError(t, input.Command.Set("key", production.Recipient().String()))
+
+		err = KeysAdd(t.Context(), input)
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "Added "+production.Recipient().String())
+
+		recipients, err := secrets.ReadRecipients(input.FS)
+		require.NoError(t, err)
+		assert.Equal(t, []string{identity.Recipient().String(), production.Recipient().String()}, recipients[env.Production])
+		assert.Equal(t, []string{identity.Recipient().String()}, recipients[env.Staging])
+
+		for _, id := range []age.Identity{identity, production} {
+			got, err := decryptWith(t, input.FS, env.Production, id)
+			require.NoError(t, err)
+			assert.Equal(t, "KEY: value\n", string(got))
+		}
+	})
+
+	t.Run("Already A Recipient", func(t *testing.T) {
+		t.Parallel()
+
+		input, buf, identity := setupKeys(t, keysFlags())
+		require.NoError(t, input.Command.Set("env", env.Production.String()))
+		require.NoError(t, input.Command.Set("key", identity.Recipient().String()))
+
+		err := KeysAdd(t.Context(), input)
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "already a recipient of production")
+	})
+
+	t.Run("Invalid Key", func(t *testing.T) {
+		t.Parallel()
+
+		input, _, _ := setupKeys(t, keysFlags())
+		require.NoError(t, input.Command.Set("env", env.Production.String()))
+		require.NoError(t, input.Command.Set("key", "age1nope"))
+
+		err := KeysAdd(t.Context(), input)
+		assert.ErrorContains(t, err, "invalid age public key")
+	})
+
+	t.Run("Invalid Environment", func(t *testing.T) {
+		t.Parallel()
+
+		input, _, identity := setupKeys(t, keysFlags())
+		require.NoError(t, input.Command.Set("env", "preview"))
+		require.NoError(t, input.Command.Set("key", identity.Recipient().String()))
+
+		err := KeysAdd(t.Context(), input)
+		assert.ErrorContains(t, err, "invalid environment: preview")
+	})
+
+	t.Run("Skips Unencrypted Files", func(t *testing.T) {
+		t.Parallel()
+
+		input, _, _ := setupKeys(t, keysFlags())
+		path := secrets.FilePathFromEnv(env.Staging)
+		require.NoError(t, afero.WriteFile(input.FS, path, []byte("KEY: value\n"), 0o600))
+
+		staging, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		require.NoError(t, input.Command.Set("env", env.Staging.String()))
+		require.NoError(t, input.Command.Set("key", staging.Recipient().String()))
+
+		err = KeysAdd(t.Context(), input)
+		require.NoError(t, err)
+
+		content, err := afero.ReadFile(input.FS, path)
+		require.NoError(t, err)
+		assert.Equal(t, "KEY: value\n", string(content))
+	})
+
+	t.Run("Decrypt Error Leaves Config", func(t *testing.T) {
+		t.Parallel()
+
+		input, _, identity := setupKeys(t, keysFlags())
+		other, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		input.SOPSCache = sops.NewNativeClient([]age.Identity{other}, nil)
+
+		require.NoError(t, input.Command.Set("env", env.Production.String()))
+		require.NoError(t, input.Command.Set("key", other.Recipient().String()))
+
+		err = KeysAdd(t.Context(), input)
+		assert.ErrorIs(t, err, sops.ErrNoMatchingIdentity)
+
+		recipients, err := secrets.ReadRecipients(input.FS)
+		require.NoError(t, err)
+		assert.Equal(t, []string{identity.Recipient().String()}, recipients[env.Production])
+	})
+}
+
+func TestKeysRemove(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Separates Production Key", func(t *testing.T) {
+		t.Parallel()
+
+		input, buf, identity := setupKeys(t, keysFlags())
+		production, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+
+		require.NoError(t, input.Command.Set("env", env.Production.String()))
+		require.NoError(t, input.Command.Set("key", production.Recipient().String()))
+		require.NoError(t, KeysAdd(t.Context(), input))
+		assert.Contains(t, buf.String(), "Production shares")
+
+		input.Command = &cli.Command{Flags: keysFlags()}
+		require.NoError(t, input.Command.Set("env", env.Production.String()))
+		require.NoError(t, input.Command.Set("key", identity.Recipient().String()))
+		buf.Reset()
+
+		err = KeysRemove(t.Context(), input)
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "Removed "+identity.Recipient().String())
+		assert.NotContains(t, buf.String(), "Production shares")
+
+		recipients, err := secrets.ReadRecipients(input.FS)
+		require.NoError(t, err)
+		assert.Equal(t, []string{production.Recipient().String()}, recipients[env.Production])
+		assert.Nil(t, recipients.SharedWithProduction())
+
+		_, err = decryptWith(t, input.FS, env.Production, identity)
+		assert.ErrorIs(t, err, sops.ErrNoMatchingIdentity)
+
+		got, err := decryptWith(t, input.FS, env.Production, production)
+		require.NoError(t, err)
+		assert.Equal(t, "KEY: value\n", string(got))
+	})
+
+	t.Run("Last Recipient", func(t *testing.T) {
+		t.Parallel()
+
+		input, _, identity := setupKeys(t, keysFlags())
+		require.NoError(t, input.Command.Set("env", env.Production.String()))
+		require.NoError(t, input.Command.Set("key", identity.Recipient().String()))
+
+		err := KeysRemove(t.Context(), input)
+		assert.ErrorContains(t, err, "can't remove the last recipient of production")
+	})
+}
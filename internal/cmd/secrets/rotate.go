@@ -0,0 +1,147 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/ghapi"
+	"github.com/ainsleydev/webkit/internal/secrets"
+	"github.com/ainsleydev/webkit/internal/secrets/age"
+	"github.com/ainsleydev/webkit/internal/state/rotations"
+)
+
+var RotateCmd = &cli.Command{
+	Name:  "rotate",
+	Usage: "Rotate the age key of an environment",
+	Description: "Generates a new age key for the environment and re-encrypts its secrets for it, " +
+		"replacing every other recipient unless kept with --keep. The new key is added to " +
+		"~/.config/webkit/age.key and stored in the REPO_AGE_SECRET_<ENV> GitHub Actions secret.",
+	Flags:  rotateFlags(),
+	Action: cmdtools.Wrap(Rotate),
+}
+
+func rotateFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "env",
+			Aliases:  []string{"e"},
+			Usage:    "Environment to rotate the key of (development, staging, production)",
+			Required: true,
+		},
+		&cli.StringSliceFlag{
+			Name:  "keep",
+			Usage: "Age public key of a current recipient to keep alongside the new key (can be repeated)",
+		},
+		&cli.BoolFlag{
+			Name:  "skip-github",
+			Usage: "Don't store the new key in a GitHub Actions secret",
+		},
+	}
+}
+
+// Rotate generates a new age key for an environment, for example when
+// a developer leaves, and re-encrypts its secrets so only the new key
+// (and any recipients passed with --keep) can decrypt them.
+//
+// GITHUB_TOKEN (or GITHUB_TOKEN_CLASSIC) must be set with permission to
+// manage Actions secrets, unless --skip-github is passed.
+func Rotate(ctx context.Context, input cmdtools.CommandInput) error {
+	var client ghapi.Client
+	if !input.Command.Bool("skip-github") {
+		token := os.Getenv("GITHUB_TOKEN")
+		if token == "" {
+			token = os.Getenv("GITHUB_TOKEN_CLASSIC")
+		}
+		if token == "" {
+			return errors.New("GITHUB_TOKEN must be set to update the age key secret, or pass --skip-github")
+		}
+		client = ghapi.New(token)
+	}
+
+	return rotate(ctx, input, client)
+}
+
+func rotate(ctx context.Context, input cmdtools.CommandInput, client ghapi.Client) error {
+	printer := input.Printer()
+
+	e, err := parseEnvironment(input.Command.String("env"))
+	if err != nil {
+		return err
+	}
+
+	recipients, err := secrets.ReadRecipients(input.FS)
+	if err != nil {
+		return err
+	}
+
+	keep := input.Command.StringSlice("keep")
+	for _, key := range keep {
+		if !slices.Contains(recipients[e], key) {
+			return fmt.Errorf("%s is not a recipient of %s", key, e)
+		}
+	}
+
+	identity, err := age.NewIdentity()
+	if err != nil {
+		return errors.Wrap(err, "generating age key")
+	}
+	keys := append(slices.Clone(keep), identity.Recipient().String())
+
+	// Re-encrypt in memory first so nothing changes if the current
+	// secrets can't be decrypted.
+	encrypted, err := reencryptData(input, e, keys)
+	if err != nil {
+		return errors.Wrapf(err, "re-encrypting %s secrets", e)
+	}
+
+	// Save the private key before anything else, it can't be
+	// recovered if a later step fails.
+	keyPath, err := age.AppendPrivateKey(identity.String())
+	if err != nil {
+		return err
+	}
+	printer.Info("Saved the new " + e.String() + " key to " + keyPath)
+
+	secretName := ""
+	if client != nil {
+		repo := input.AppDef().Project.Repo
+		secretName = secrets.AgeKeySecretName(e)
+		if err = client.SetRepoSecret(ctx, repo.Owner, repo.Name, secretName, identity.String()); err != nil {
+			return errors.Wrapf(err, "updating the %s GitHub secret", secretName)
+		}
+		printer.Info("Updated the " + secretName + " GitHub secret")
+	}
+
+	if encrypted != nil {
+		if err = writeSecretFile(input.FS, e, encrypted); err != nil {
+			return errors.Wrapf(err, "writing %s secrets", e)
+		}
+	}
+
+	recipients[e] = keys
+	if err = input.Generator().YAML(secrets.SOPSConfigPath, recipients.SOPSConfig()); err != nil {
+		return errors.Wrapf(err, "writing %s", secrets.SOPSConfigPath)
+	}
+
+	err = rotations.Record(input.FS, e.String(), rotations.Rotation{
+		Recipient:    identity.Recipient().String(),
+		GitHubSecret: secretName,
+		RotatedAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	printer.Success(fmt.Sprintf("Rotated the %s key to %s", e, identity.Recipient().String()))
+	printer.Println("Share the new private key, the last line of " + keyPath + ", with anyone who still needs access, then commit the changes.")
+	warnSharedProduction(input, recipients)
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package secrets
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/mocks"
+	"github.com/ainsleydev/webkit/internal/secrets"
+	webkitage "github.com/ainsleydev/webkit/internal/secrets/age"
+	"github.com/ainsleydev/webkit/internal/secrets/sops"
+	"github.com/ainsleydev/webkit/internal/state/rotations"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+// TestRotate cannot run in parallel as the new key is written to the
+// key file in $HOME.
+func TestRotate(t *testing.T) {
+	t.Setenv(webkitage.KeyEnvVar, "")
+
+	t.Run("Rotates Key", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+
+		input, buf, identity := setupKeys(t, rotateFlags())
+		input.AppDefCache = &appdef.Definition{
+			Project: appdef.Project{Repo: appdef.GitHubRepo{Owner: "ainsleydev", Name: "website"}},
+		}
+		require.NoError(t, input.Command.Set("env", env.Production.String()))
+
+		var secret string
+		client := mocks.NewGHClient(gomock.NewController(t))
+		client.EXPECT().
+			SetRepoSecret(gomock.Any(), "ainsleydev", "website", "REPO_AGE_SECRET_PRODUCTION", gomock.Any()).
+			DoAndReturn(func(_ any, _, _, _, value string) error {
+				secret = value
+				return nil
+			})
+
+		err := rotate(t.Context(), input, client)
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "Rotated the production key")
+
+		t.Log("Key Saved Locally")
+		saved, err := webkitage.ReadIdentities()
+		require.NoError(t, err)
+		require.Len(t, saved, 1)
+		assert.Equal(t, saved[0].String(), secret)
+		newKey := saved[0].Recipient().String()
+
+		t.Log("Recipients Replaced")
+		recipients, err := secrets.ReadRecipients(input.FS)
+		require.NoError(t, err)
+		assert.Equal(t, []string{newKey}, recipients[env.Production])
+		assert.Equal(t, []string{identity.Recipient().String()}, recipients[env.Staging])
+
+		t.Log("Secrets Re-encrypted")
+		_, err = decryptWith(t, input.FS, env.Production, identity)
+		assert.ErrorIs(t, err, sops.ErrNoMatchingIdentity)
+		got, err := decryptWith(t, input.FS, env.Production, saved[0])
+		require.NoError(t, err)
+		assert.Equal(t, "KEY: value\n", string(got))
+
+		t.Log("Rotation Recorded")
+		file, err := rotations.Load(input.FS)
+		require.NoError(t, err)
+		rotation := file[env.Production.String()]
+		assert.Equal(t, newKey, rotation.Recipient)
+		assert.Equal(t, "REPO_AGE_SECRET_PRODUCTION", rotation.GitHubSecret)
+		assert.WithinDuration(t, time.Now(), rotation.RotatedAt, time.Minute)
+	})
+
+	t.Run("Keeps Recipients", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+
+		input, _, identity := setupKeys(t, rotateFlags())
+		require.NoError(t, input.Command.Set("env", env.Production.String()))
+		require.NoError(t, input.Command.Set("keep", identity.Recipient().String()))
+
+		err := rotate(t.Context(), input, nil)
+		require.NoError(t, err)
+
+		recipients, err := secrets.ReadRecipients(input.FS)
+		require.NoError(t, err)
+		require.Len(t, recipients[env.Production], 2)
+		assert.Equal(t, identity.Recipient().String(), recipients[env.Production][0])
+
+		got, err := decryptWith(t, input.FS, env.Production, identity)
+		require.NoError(t, err)
+		assert.Equal(t, "KEY: value\n", string(got))
+
+		file, err := rotations.Load(input.FS)
+		require.NoError(t, err)
+		assert.Empty(t, file[env.Production.String()].GitHubSecret)
+	})
+
+	t.Run("Keep Not A Recipient", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+
+		input, _, _ := setupKeys(t, rotateFlags())
+		other, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		require.NoError(t, input.Command.Set("env", env.Production.String()))
+		require.NoError(t, input.Command.Set("keep", other.Recipient().String()))
+
+		err = rotate(t.Context(), input, nil)
+		assert.ErrorContains(t, err, "is not a recipient of production")
+	})
+
+	t.Run("Invalid Environment", func(t *testing.T) {
+		input, _, _ := setupKeys(t, rotateFlags())
+		require.NoError(t, input.Command.Set("env", "preview"))
+
+		err := rotate(t.Context(), input, nil)
+		assert.ErrorContains(t, err, "invalid environment: preview")
+	})
+
+	t.Run("Decrypt Error Changes Nothing", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+
+		input, _, identity := setupKeys(t, rotateFlags())
+		other, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		input.SOPSCache = sops.NewNativeClient([]age.Identity{other}, nil)
+		require.NoError(t, input.Command.Set("env", env.Production.String()))
+
+		err = rotate(t.Context(), input, nil)
+		assert.ErrorIs(t, err, sops.ErrNoMatchingIdentity)
+
+		_, err = webkitage.ReadIdentities()
+		assert.Error(t, err, "no key should have been saved")
+
+		recipients, err := secrets.ReadRecipients(input.FS)
+		require.NoError(t, err)
+		assert.Equal(t, []string{identity.Recipient().String()}, recipients[env.Production])
+	})
+
+	t.Run("GitHub Error", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+
+		input, _, identity := setupKeys(t, rotateFlags())
+		require.NoError(t, input.Command.Set("env", env.Staging.String()))
+
+		client := mocks.NewGHClient(gomock.NewController(t))
+		client.EXPECT().
+			SetRepoSecret(gomock.Any(), gomock.Any(), gomock.Any(), "REPO_AGE_SECRET_STAGING", gomock.Any()).
+			Return(errors.New("forbidden"))
+
+		err := rotate(t.Context(), input, client)
+		assert.ErrorContains(t, err, "updating the REPO_AGE_SECRET_STAGING GitHub secret")
+
+		recipients, err := secrets.ReadRecipients(input.FS)
+		require.NoError(t, err)
+		assert.Equal(t, []string{identity.Recipient().String()}, recipients[env.Staging])
+
+		t.Log("Key Still Saved")
+		saved, err := webkitage.ReadIdentities()
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(saved[0].String(), "AGE-SECRET-KEY-"))
+	})
+}
+
+func TestRotate_NoToken(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN_CLASSIC", "")
+
+	input, _, _ := setupKeys(t, rotateFlags())
+	require.NoError(t, input.Command.Set("env", env.Production.String()))
+
+	err := Rotate(t.Context(), input)
+	assert.ErrorContains(t, err, "GITHUB_TOKEN must be set")
+}
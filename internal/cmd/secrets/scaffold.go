@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"github.com/pkg/errors"
+	"github.com/spf13/afero"
 	"github.com/urfave/cli/v3"
 
 	"github.com/ainsleydev/webkit/internal/cmdtools"
@@ -24,7 +25,7 @@ var ScaffoldCmd = &cli.Command{
 // This creates empty secret files and SOPS configuration without
 // parsing app.json.
 func Scaffold(_ context.Context, input cmdtools.CommandInput) error {
-	if err := generateSOPSConfig(input.Generator()); err != nil {
+	if err := generateSOPSConfig(input.FS, input.Generator()); err != nil {
 		return errors.Wrap(err, "generating sops config")
 	}
 
@@ -48,14 +49,13 @@ func Scaffold(_ context.Context, input cmdtools.CommandInput) error {
 // generateSOPSConfig creates the .sops.yaml configuration file which tells
 // sops how to encrypt and decrypt files without specifying rules or keys
 // everytime we call the cmd.
-func generateSOPSConfig(gen scaffold.Generator) error {
-	config := map[string]any{
-		"creation_rules": []map[string]any{
-			{
-				"path_regex": `secrets/.*\.yaml$`,
-				"age":        secrets.AgePublicKey,
-			},
-		},
+//
+// Each environment has its own creation rule, so recipients added with
+// `secrets keys` are kept when the file is regenerated.
+func generateSOPSConfig(fs afero.Fs, gen scaffold.Generator) error {
+	recipients, err := secrets.ReadRecipients(fs)
+	if err != nil {
+		return err
 	}
-	return gen.YAML(filepath.Join("resources", ".sops.yaml"), config)
+	return gen.YAML(secrets.SOPSConfigPath, recipients.SOPSConfig())
 }
@@ -109,4 +109,20 @@ func TestScaffold(t *testing.T) {
 			}
 		}
 	})
+	t.Run("Keeps Recipients", func(t *testing.T) {
+		t.Parallel()
+
+		input, _ := setup(t, &appdef.Definition{})
+
+		recipients := secrets.DefaultRecipients()
+		recipients[env.Production] = []string{"age1prod"}
+		require.NoError(t, input.Generator().YAML(secrets.SOPSConfigPath, recipients.SOPSConfig()))
+
+		err := Scaffold(t.Context(), input)
+		require.NoError(t, err)
+
+		got, err := secrets.ReadRecipients(input.FS)
+		require.NoError(t, err)
+		assert.Equal(t, recipients, got)
+	})
 }
@@ -0,0 +1,123 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+)
+
+var SetCmd = &cli.Command{
+	Name:  "set",
+	Usage: "Set secrets of an environment without decrypting them to disk",
+	Description: "Decrypts the secret file of the environment in memory, sets each KEY=VALUE pair and " +
+		"re-encrypts it. Existing keys are updated in place and new keys are added to the end of the file.",
+	ArgsUsage: "KEY=VALUE [KEY=VALUE...]",
+	Flags:     setFlags(),
+	Action:    cmdtools.Wrap(Set),
+}
+
+func setFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "env",
+			Aliases:  []string{"e"},
+			Usage:    "Environment to set the secrets of (development, staging, production)",
+			Required: true,
+		},
+	}
+}
+
+// Set updates the secrets of an environment from KEY=VALUE arguments,
+// for example `secrets set STRIPE_KEY=sk_live --env production`.
+// Values are never printed.
+func Set(_ context.Context, input cmdtools.CommandInput) error {
+	e, err := parseEnvironment(input.Command.String("env"))
+	if err != nil {
+		return err
+	}
+
+	args := input.Command.Args().Slice()
+	if len(args) == 0 {
+		return errors.New("at least one KEY=VALUE must be provided, e.g. webkit secrets set API_KEY=value --env production")
+	}
+
+	pairs := make([][2]string, 0, len(args))
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return fmt.Errorf("invalid argument %q, expected KEY=VALUE", arg)
+		}
+		if key == "sops" {
+			return errors.New(`the "sops" key is reserved for SOPS metadata`)
+		}
+		pairs = append(pairs, [2]string{key, value})
+	}
+
+	plaintext, _, err := readSecretFile(input, e)
+	if err != nil {
+		return err
+	}
+
+	updated, err := setValues(plaintext, pairs)
+	if err != nil {
+		return err
+	}
+
+	if err = saveSecrets(input, e, updated); err != nil {
+		return err
+	}
+
+	for _, pair := range pairs {
+		input.Printer().Success(fmt.Sprintf("Set %s in %s", pair[0], e))
+	}
+
+	return nil
+}
+
+// setValues sets the key value pairs in the top level mapping of the
+// YAML, keeping the order of the existing keys.
+func setValues(plaintext []byte, pairs [][2]string) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(plaintext, &doc); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+		}
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, errors.New("invalid YAML: secrets must be a mapping of keys to values")
+	}
+
+	for _, pair := range pairs {
+		value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: pair[1]}
+
+		found := false
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			if root.Content[i].Value == pair[0] {
+				root.Content[i+1] = value
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: pair[0]}
+			root.Content = append(root.Content, key, value)
+		}
+	}
+
+	return yaml.Marshal(&doc)
+}
@@ -0,0 +1,101 @@
+package secrets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/secrets"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	// run parses the arguments like the CLI would before running Set.
+	run := func(t *testing.T, input cmdtools.CommandInput, args ...string) error {
+		t.Helper()
+
+		cmd := &cli.Command{
+			Name:  "set",
+			Flags: setFlags(),
+			Action: func(ctx context.Context, c *cli.Command) error {
+				input.Command = c
+				return Set(ctx, input)
+			},
+		}
+
+		return cmd.Run(t.Context(), append([]string{"set"}, args...))
+	}
+
+	tt := map[string]struct {
+		file    string
+		args    []string
+		want    string
+		wantErr string
+	}{
+		"Update And Add": {
+			args: []string{"KEY=updated", "NEW=a=b"},
+			want: "KEY: updated\nNEW: a=b\n",
+		},
+		"Keeps String Values": {
+			args: []string{"PORT=8080", "EMPTY="},
+			want: "KEY: value\nPORT: \"8080\"\nEMPTY: \"\"\n",
+		},
+		"Plain Text File": {
+			file: "KEY: value\nOTHER: x\n",
+			args: []string{"OTHER=y"},
+			want: "KEY: value\nOTHER: y\n",
+		},
+		"Empty File": {
+			file: "\n",
+			args: []string{"KEY=value"},
+			want: "KEY: value\n",
+		},
+		"No Arguments":      {wantErr: "at least one KEY=VALUE must be provided"},
+		"Missing Separator": {args: []string{"KEY"}, wantErr: `invalid argument "KEY", expected KEY=VALUE`},
+		"Missing Key":       {args: []string{"=value"}, wantErr: `invalid argument "=value", expected KEY=VALUE`},
+		"Reserved Key":      {args: []string{"sops=value"}, wantErr: `the "sops" key is reserved`},
+		"Not A Mapping":     {file: "- KEY\n", args: []string{"KEY=value"}, wantErr: "secrets must be a mapping"},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			input, buf, identity := setupKeys(t, nil)
+			if test.file != "" {
+				require.NoError(t, afero.WriteFile(input.FS, secrets.FilePathFromEnv(env.Production), []byte(test.file), 0o600))
+			}
+
+			err := run(t, input, append([]string{"--env", "production"}, test.args...)...)
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := decryptWith(t, input.FS, env.Production, identity)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, string(got))
+
+			assert.Contains(t, buf.String(), "in production")
+			for _, arg := range test.args {
+				assert.NotContains(t, buf.String(), arg, "Values shouldn't be printed")
+			}
+		})
+	}
+
+	t.Run("Missing File", func(t *testing.T) {
+		t.Parallel()
+
+		input, _, _ := setupKeys(t, nil)
+		err := run(t, input, "--env", "staging", "KEY=value")
+		assert.ErrorContains(t, err, "resources/secrets/staging.yaml does not exist")
+	})
+}
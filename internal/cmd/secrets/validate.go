@@ -1,15 +1,30 @@
 package secrets
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/urfave/cli/v3"
-	//"github.com/ainsleydev/webkit/internal/secrets"
+
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/secrets"
+	"github.com/ainsleydev/webkit/internal/state/rotations"
 )
 
 var ValidateCmd = &cli.Command{
-	Name:        "validate",
-	Usage:       "Validate that all secrets from app.json exist in secret files",
-	Description: "Ensures every secret referenced in app.json has a corresponding entry in SOPS files",
-	Flags: []cli.Flag{
+	Name:  "validate",
+	Usage: "Validate that all secrets from app.json exist in secret files",
+	Description: "Ensures every secret referenced in app.json has a non-empty entry in the SOPS file of " +
+		"each environment and that files holding secrets are encrypted, exiting with a non-zero code otherwise",
+	Flags:  validateFlags(),
+	Action: cmdtools.Wrap(Validate),
+}
+
+func validateFlags() []cli.Flag {
+	return []cli.Flag{
 		&cli.BoolFlag{
 			Name:    "check-orphans",
 			Usage:   "Report keys in SOPS files not referenced in app.json",
@@ -17,132 +32,140 @@ var ValidateCmd = &cli.Command{
 		},
 		&cli.BoolFlag{
 			Name:    "allow-encrypted",
-			Usage:   "Attempt to validate encrypted files (requires SOPS/age access)",
+			Usage:   "Decrypt encrypted files in memory to check they can be decrypted (requires SOPS/age access)",
 			Aliases: []string{"e"},
 		},
-	},
-	// Action: cmdtools.Wrap(Validate),
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "Output format: text or json",
+			Value: "text",
+		},
+	}
+}
+
+// validateFormats are the supported values of the --format flag.
+var validateFormats = []string{"text", "json"}
+
+// Validate validates that all secrets referenced in app.json exist in
+// the SOPS files, that none of them are empty and that files holding
+// secrets are encrypted.
+func Validate(_ context.Context, input cmdtools.CommandInput) error {
+	format := input.Command.String("format")
+	if !slices.Contains(validateFormats, format) {
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+
+	checkOrphans := input.Command.Bool("check-orphans")
+	allowEncrypted := input.Command.Bool("allow-encrypted")
+
+	cfg := secrets.ValidateConfig{
+		FS:             input.FS,
+		AppDef:         input.AppDef(),
+		CheckOrphans:   checkOrphans,
+		AllowEncrypted: allowEncrypted,
+	}
+	if allowEncrypted {
+		cfg.SOPSClient = input.SOPSClient()
+	}
+
+	result, err := secrets.Validate(cfg)
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if format == "json" {
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("formatting validation result: %w", err)
+		}
+		input.Printer().Println(string(out))
+	} else {
+		printValidationResults(input, result, checkOrphans)
+	}
+
+	if !result.Valid {
+		return cmdtools.ExitWithCode(1)
+	}
+
+	return nil
 }
 
-// Validate validates that all secrets referenced in app.json exist in SOPS files
-//func Validate(ctx context.Context, input cmdtools.CommandInput) error {
-//	app := input.AppDef()
-//
-//	checkOrphans := input.Command.Bool("check-orphans")
-//	allowEncrypted := input.Command.Bool("allow-encrypted")
-//
-//	fmt.Println("Validating secrets from app.json...")
-//
-//	result, err := secrets.Validate(secrets.ValidateConfig{
-//		FS:             input.FS,
-//		AppDef:         app,
-//		CheckOrphans:   checkOrphans,
-//		AllowEncrypted: allowEncrypted,
-//	})
-//	if err != nil {
-//		return fmt.Errorf("validation failed: %w", err)
-//	}
-//
-//	printValidationResults(result, checkOrphans)
-//
-//	if !result.Valid {
-//		return fmt.Errorf("validation failed - missing secrets detected")
-//	}
-//
-//	return nil
-//}
-//
-//// printValidationResults outputs validation results in a user-friendly format
-//func printValidationResults(result *secrets.ValidationResult, checkOrphans bool) {
-//	separator := "═══════════════════════════════════════════════════════════"
-//
-//	fmt.Println()
-//
-//	// Print file-by-file results
-//	for _, file := range result.Files {
-//		printFileValidation(file)
-//	}
-//
-//	fmt.Println(separator)
-//
-//	// Print summary
-//	if result.Valid {
-//		fmt.Println("✅ All secrets validated successfully!")
-//
-//		if len(result.Files) > 0 {
-//			totalKeys := 0
-//			for _, file := range result.Files {
-//				totalKeys += len(file.MissingKeys)
-//			}
-//			fmt.Printf("   Validated %d secret file(s)\n", len(result.Files))
-//		}
-//	} else {
-//		fmt.Println("❌ Validation failed!")
-//		fmt.Printf("   %d missing secret(s) detected\n", len(result.MissingSecrets))
-//
-//		// Group by environment
-//		byEnv := make(map[string][]secrets.MissingSecret)
-//		for _, missing := range result.MissingSecrets {
-//			byEnv[missing.Environment] = append(byEnv[missing.Environment], missing)
-//		}
-//
-//		fmt.Println("\nMissing secrets:")
-//		for env, secrets := range byEnv {
-//			fmt.Printf("\n  %s:\n", env)
-//			for _, secret := range secrets {
-//				fmt.Printf("    • %s (used by: %s)\n", secret.Key, secret.AppName)
-//				fmt.Printf("      Expected in: %s\n", secret.ExpectedIn)
-//			}
-//		}
-//	}
-//
-//	// Print orphaned keys if checked
-//	if checkOrphans && len(result.OrphanedKeys) > 0 {
-//		fmt.Println("\n⚠️  Orphaned keys (in SOPS files but not in app.json):")
-//		byFile := make(map[string][]string)
-//		for _, orphan := range result.OrphanedKeys {
-//			byFile[orphan.FilePath] = append(byFile[orphan.FilePath], orphan.Key)
-//		}
-//		for file, keys := range byFile {
-//			fmt.Printf("\n  %s:\n", file)
-//			for _, key := range keys {
-//				fmt.Printf("    • %s\n", key)
-//			}
-//		}
-//	}
-//
-//	fmt.Println(separator)
-//
-//	// Helpful hints
-//	if !result.Valid {
-//		fmt.Println("\n💡 Run 'webkit secrets sync' to add missing secrets")
-//		fmt.Println("💡 Run 'webkit secrets validate --check-orphans' to find unused keys")
-//	}
-//}
-//
-//// printFileValidation outputs validation results for a single file
-//func printFileValidation(file secrets.FileValidation) {
-//	if !file.Exists {
-//		fmt.Printf("⚠️  %s - file does not exist\n", file.FilePath)
-//		return
-//	}
-//
-//	if file.Error != nil {
-//		fmt.Printf("❌ %s - error: %v\n", file.FilePath, file.Error)
-//		return
-//	}
-//
-//	if file.IsEncrypted {
-//		fmt.Printf("🔒 %s - encrypted (skipped)\n", file.FilePath)
-//		return
-//	}
-//
-//	if len(file.MissingKeys) == 0 {
-//		fmt.Printf("✅ %s\n", file.FilePath)
-//	} else {
-//		fmt.Printf("❌ %s - %d missing key(s)\n", file.FilePath, len(file.MissingKeys))
-//		for _, key := range file.MissingKeys {
-//			fmt.Printf("     • %s\n", key)
-//		}
-//	}
-//}
+// printValidationResults outputs validation results in a user-friendly format.
+func printValidationResults(input cmdtools.CommandInput, result *secrets.ValidationResult, checkOrphans bool) {
+	printer := input.Printer()
+
+	for _, file := range result.Files {
+		printFileValidation(input, file)
+	}
+
+	printer.Println("")
+
+	if len(result.MissingSecrets) > 0 {
+		printer.Println("Missing secrets:")
+		for _, missing := range result.MissingSecrets {
+			printer.Printf("  • %s (used by %s), expected in %s\n", missing.Key, missing.AppName, missing.ExpectedIn)
+		}
+		printer.Println("")
+	}
+
+	if checkOrphans && len(result.OrphanedKeys) > 0 {
+		printer.Warn("Orphaned keys (in SOPS files but not in app.json):")
+		for _, orphan := range result.OrphanedKeys {
+			printer.Printf("  • %s in %s\n", orphan.Key, orphan.FilePath)
+		}
+		printer.Println("")
+	}
+
+	if len(result.SharedProductionKeys) > 0 {
+		printer.Printf("✗ production shares %s with another environment\n", strings.Join(result.SharedProductionKeys, ", "))
+		printer.Println("")
+	}
+
+	if len(result.StaleKeys) > 0 {
+		printer.Warn(fmt.Sprintf(
+			"The age keys of %s haven't been rotated in %d days, run 'webkit secrets rotate -e <env>'",
+			strings.Join(result.StaleKeys, ", "), int(rotations.MaxAge.Hours()/24),
+		))
+	}
+
+	if result.Valid {
+		printer.Success("All secrets validated successfully")
+		return
+	}
+
+	printer.Error("Secrets validation failed")
+	if len(result.MissingSecrets) > 0 {
+		printer.Info("Run 'webkit secrets sync' to add placeholders for missing secrets")
+	}
+	if len(result.SharedProductionKeys) > 0 {
+		printer.Info("Run 'webkit secrets rotate -e production' to give production its own key")
+	}
+}
+
+// printFileValidation outputs validation results for a single file.
+func printFileValidation(input cmdtools.CommandInput, file secrets.FileValidation) {
+	printer := input.Printer()
+
+	switch {
+	case file.Error != "":
+		printer.Printf("✗ %s - %s\n", file.FilePath, file.Error)
+		return
+	case !file.Exists:
+		printer.Printf("⚠ %s - file does not exist\n", file.FilePath)
+	case !file.HasProblems():
+		printer.Printf("✓ %s\n", file.FilePath)
+		return
+	default:
+		printer.Printf("✗ %s\n", file.FilePath)
+	}
+
+	if file.Unencrypted {
+		printer.Println("    • not encrypted, run 'webkit secrets encrypt'")
+	}
+	for _, key := range file.MissingKeys {
+		printer.Printf("    • %s is missing\n", key)
+	}
+	for _, key := range file.EmptyKeys {
+		printer.Printf("    • %s is empty\n", key)
+	}
+}
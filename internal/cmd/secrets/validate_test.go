@@ -0,0 +1,114 @@
+package secrets
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/cmdtools"
+	"github.com/ainsleydev/webkit/internal/secrets"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	def := &appdef.Definition{
+		Apps: []appdef.App{
+			{
+				Name: "web",
+				Env: appdef.Environment{
+					Production: appdef.EnvVar{
+						"API_KEY": {Source: appdef.EnvSourceSOPS},
+					},
+				},
+			},
+		},
+	}
+
+	setupValidate := func(t *testing.T, production string, flags map[string]string) (cmdtools.CommandInput, func() string) {
+		t.Helper()
+
+		input, buf := setup(t, def)
+		input.Command = &cli.Command{Flags: validateFlags()}
+		for name, value := range flags {
+			require.NoError(t, input.Command.Set(name, value))
+		}
+
+		err := afero.WriteFile(input.FS, secrets.FilePathFromEnv(env.Production), []byte(production), 0o644)
+		require.NoError(t, err)
+
+		recipients := secrets.DefaultRecipients()
+		recipients[env.Production] = []string{"age1production"}
+		require.NoError(t, input.Generator().YAML(secrets.SOPSConfigPath, recipients.SOPSConfig()))
+
+		return input, buf.String
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		t.Parallel()
+
+		input, out := setupValidate(t, "API_KEY: ENC[AES256_GCM,data:abc,iv:def,tag:ghi,type:str]\nsops:\n  version: 3.9.0\n", nil)
+
+		err := Validate(t.Context(), input)
+		require.NoError(t, err)
+		assert.Contains(t, out(), "✓ resources/secrets/production.yaml")
+		assert.Contains(t, out(), "All secrets validated successfully")
+	})
+
+	t.Run("Problems", func(t *testing.T) {
+		t.Parallel()
+
+		input, out := setupValidate(t, "OTHER: \"\"\n", map[string]string{"check-orphans": "true"})
+
+		err := Validate(t.Context(), input)
+		assert.Equal(t, cmdtools.ExitWithCode(1), err)
+		assert.Contains(t, out(), "not encrypted")
+		assert.Contains(t, out(), "API_KEY is missing")
+		assert.Contains(t, out(), "OTHER is empty")
+		assert.Contains(t, out(), "API_KEY (used by web), expected in resources/secrets/production.yaml")
+		assert.Contains(t, out(), "OTHER in resources/secrets/production.yaml")
+		assert.Contains(t, out(), "webkit secrets sync")
+	})
+
+	t.Run("Shared Production Key", func(t *testing.T) {
+		t.Parallel()
+
+		input, out := setupValidate(t, "API_KEY: ENC[AES256_GCM,data:abc,iv:def,tag:ghi,type:str]\nsops:\n  version: 3.9.0\n", nil)
+		require.NoError(t, input.Generator().YAML(secrets.SOPSConfigPath, secrets.DefaultRecipients().SOPSConfig()))
+
+		err := Validate(t.Context(), input)
+		assert.Equal(t, cmdtools.ExitWithCode(1), err)
+		assert.Contains(t, out(), "production shares "+secrets.AgePublicKey+" with another environment")
+		assert.Contains(t, out(), "webkit secrets rotate -e production")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		input, out := setupValidate(t, "API_KEY: value\n", map[string]string{"format": "json"})
+
+		err := Validate(t.Context(), input)
+		assert.Equal(t, cmdtools.ExitWithCode(1), err)
+
+		var result secrets.ValidationResult
+		require.NoError(t, json.Unmarshal([]byte(out()), &result))
+		assert.False(t, result.Valid)
+		require.Len(t, result.Files, 3)
+		assert.True(t, result.Files[2].Unencrypted)
+	})
+
+	t.Run("Invalid Format", func(t *testing.T) {
+		t.Parallel()
+
+		input, _ := setupValidate(t, "", map[string]string{"format": "xml"})
+
+		err := Validate(t.Context(), input)
+		assert.ErrorContains(t, err, "unsupported format: xml")
+	})
+}
@@ -52,8 +52,10 @@ var updateOps = []runner{
 	{files.TurboJSON, "Files: Create turbo.json"},
 	{cicd.PR, "CICD: Create PR workflows"},
 	{cicd.ReleaseWorkflow, "CICD: Create release workflow"},
+	{cicd.RollbackWorkflow, "CICD: Create rollback workflow"},
 	{cicd.BackupWorkflow, "CICD: Create backup workflows"},
 	{cicd.VMMaintenanceWorkflow, "CICD: Create maintenance workflow"},
+	{cicd.InfraDriftWorkflow, "CICD: Create infrastructure drift workflow"},
 	{cicd.ActionTemplates, "CICD: Create action templates"},
 	{docs.Readme, "Docs: README.md"},
 	{docs.Agents, "Docs: AGENTS.md"},
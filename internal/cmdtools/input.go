@@ -13,7 +13,9 @@ import (
 	"github.com/ainsleydev/webkit/internal/appdef"
 	"github.com/ainsleydev/webkit/internal/printer"
 	"github.com/ainsleydev/webkit/internal/scaffold"
+	"github.com/ainsleydev/webkit/internal/secrets"
 	"github.com/ainsleydev/webkit/internal/secrets/age"
+	"github.com/ainsleydev/webkit/internal/secrets/audit"
 	"github.com/ainsleydev/webkit/internal/secrets/sops"
 	"github.com/ainsleydev/webkit/internal/state/manifest"
 	"github.com/ainsleydev/webkit/internal/util/executil"
@@ -33,8 +35,14 @@ type CommandInput struct {
 	SOPSCache   sops.EncrypterDecrypter
 	Manifest    *manifest.Tracker
 	Runner      executil.Runner
+	Stdin       io.Reader
 	Silent      bool
 	printer     *printer.Console
+
+	// NewManager and StateVersion are used to fetch Terraform
+	// outputs, defaulting to the infra package when nil.
+	NewManager   ManagerFunc
+	StateVersion StateVersionFunc
 }
 
 // Wrap wraps a RunCommand to work with urfave/cli.
@@ -56,6 +64,7 @@ func Wrap(command RunCommand) cli.ActionFunc {
 			BaseDir:  dir,
 			Manifest: manifest.NewTracker(),
 			Runner:   executil.DefaultRunner(),
+			Stdin:    os.Stdin,
 			Silent:   c.Bool("silent"),
 		}
 
@@ -105,20 +114,45 @@ func (c *CommandInput) Printer() *printer.Console {
 	return c.printer
 }
 
-// SOPSClient returns a cached sops.Client or initialises it
-// by using an age provider.
+// SOPSClient returns a cached SOPS client or initialises a native
+// client with the age key, which decrypts files in memory. Files are
+// encrypted for the recipients of their environment in .sops.yaml.
+//
+// Every decrypt is recorded in the audit log, see audit.SinkFromEnv.
 func (c *CommandInput) SOPSClient() sops.EncrypterDecrypter {
 	if c.SOPSCache != nil {
 		return c.SOPSCache
 	}
-	prov, err := age.NewProvider()
+	client, err := age.NewClient()
+	if err != nil {
+		Exit(err)
+	}
+	if c.FS != nil {
+		cfg, err := secrets.ReadSOPSConfig(c.FS)
+		if err != nil {
+			Exit(err)
+		}
+		if cfg != nil {
+			client = client.WithConfig(cfg)
+		}
+	}
+	sink, err := audit.SinkFromEnv()
 	if err != nil {
 		Exit(err)
 	}
-	c.SOPSCache = sops.NewClient(prov)
+	c.SOPSCache = audit.NewClient(client, sink, c.commandName())
 	return c.SOPSCache
 }
 
+// commandName returns the full name of the running command, for
+// example "webkit secrets get".
+func (c *CommandInput) commandName() string {
+	if c.Command == nil {
+		return ""
+	}
+	return c.Command.FullName()
+}
+
 func (c *CommandInput) Spinner() *spinner.Spinner {
 	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
 	if c.Silent {
@@ -2,14 +2,21 @@ package cmdtools
 
 import (
 	"context"
+	"encoding/json"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"filippo.io/age"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
 
+	"github.com/ainsleydev/webkit/internal/secrets"
+	"github.com/ainsleydev/webkit/internal/secrets/audit"
+	"github.com/ainsleydev/webkit/internal/secrets/sops"
 	"github.com/ainsleydev/webkit/internal/state/manifest"
 	"github.com/ainsleydev/webkit/pkg/env"
 )
@@ -195,6 +202,69 @@ func TestCommandInput_SOPSClient(t *testing.T) {
 	})
 }
 
+func TestCommandInput_SOPSClientConfig(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	production, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	t.Setenv("SOPS_AGE_KEY", identity.String())
+
+	fs := afero.NewMemMapFs()
+	recipients := secrets.DefaultRecipients()
+	recipients[env.Production] = []string{production.Recipient().String()}
+	cfg, err := yaml.Marshal(recipients.SOPSConfig())
+	require.NoError(t, err)
+	require.NoError(t, afero.WriteFile(fs, secrets.SOPSConfigPath, cfg, 0o644))
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, secrets.FilePathFromEnv(env.Production))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte("KEY: value\n"), 0o600))
+
+	input := CommandInput{FS: fs}
+	require.NoError(t, input.SOPSClient().Encrypt(path))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), production.Recipient().String())
+	assert.NotContains(t, string(content), identity.Recipient().String())
+}
+
+func TestCommandInput_SOPSClientAudit(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	t.Setenv("SOPS_AGE_KEY", identity.String())
+
+	log := filepath.Join(t.TempDir(), "audit.jsonl")
+	t.Setenv(audit.FileEnvVar, log)
+	t.Setenv(audit.URLEnvVar, "")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, secrets.FilePathFromEnv(env.Staging))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte("KEY: value\n"), 0o600))
+
+	input := CommandInput{
+		FS:      afero.NewMemMapFs(),
+		Command: &cli.Command{Name: "get"},
+	}
+	require.NoError(t, input.SOPSClient().Encrypt(path))
+
+	values, err := sops.DecryptFileToMap(input.SOPSClient(), path)
+	require.NoError(t, err)
+	assert.Equal(t, "value", values["KEY"])
+
+	content, err := os.ReadFile(log)
+	require.NoError(t, err)
+
+	var entry audit.Entry
+	require.NoError(t, json.Unmarshal(content, &entry))
+	assert.Equal(t, "staging", entry.Environment)
+	assert.Equal(t, []string{"KEY"}, entry.Keys)
+	assert.Equal(t, "get", entry.Command)
+	assert.NotContains(t, string(content), "value", "Values shouldn't be logged")
+}
+
 func TestCommandInput_Spinner(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,120 @@
+package cmdtools
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/pkg/errors"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/infra"
+	"github.com/ainsleydev/webkit/internal/secrets"
+	"github.com/ainsleydev/webkit/internal/secrets/age"
+	"github.com/ainsleydev/webkit/internal/state/manifest"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+// OutputOptions configures how Terraform outputs are fetched.
+type OutputOptions struct {
+	// Manager is an initialised manager for the environment to
+	// reuse. When nil, a manager is created and initialised only
+	// if the outputs aren't already cached.
+	Manager infra.Manager
+
+	// Refresh bypasses the cache and always reads the
+	// outputs from Terraform.
+	Refresh bool
+}
+
+type (
+	// ManagerFunc creates a Terraform manager, see infra.NewManager.
+	ManagerFunc func(ctx context.Context, appDef *appdef.Definition, tracker *manifest.Tracker, opts ...infra.Option) (infra.Manager, error)
+	// StateVersionFunc reads the version of an environment's remote
+	// state, see infra.RemoteStateVersion.
+	StateVersionFunc func(ctx context.Context, projectName string, environment env.Environment) (infra.StateVersion, error)
+)
+
+// TerraformOutputs returns the Terraform outputs for the environment
+// as a provider for resolving resource references.
+//
+// Outputs are cached encrypted under .webkit/ and reused until the
+// remote state changes, so Terraform is only initialised when the
+// cache is stale, missing or opts.Refresh is set.
+func (c *CommandInput) TerraformOutputs(ctx context.Context, environment env.Environment, opts OutputOptions) (*secrets.TerraformOutputProvider, error) {
+	appDef := c.AppDef()
+	cache := c.outputCache()
+
+	var version infra.StateVersion
+	if cache != nil {
+		var err error
+		version, err = c.stateVersion()(ctx, appDef.Project.Name, environment)
+		if err != nil {
+			c.Printer().Warn("Terraform outputs won't be cached, the remote state version couldn't be read: " + err.Error())
+			cache = nil
+		}
+	}
+
+	if cache != nil && !opts.Refresh {
+		if result, ok := cache.Get(environment, version); ok {
+			provider := secrets.TransformOutputs(result, environment)
+			return &provider, nil
+		}
+	}
+
+	tf := opts.Manager
+	if tf == nil {
+		var err error
+		tf, err = c.manager()(ctx, appDef, c.Manifest, infra.WithEnvironment(environment))
+		if err != nil {
+			return nil, errors.Wrap(err, "creating terraform manager")
+		}
+		defer tf.Cleanup()
+
+		if err = tf.Init(ctx); err != nil {
+			return nil, errors.Wrap(err, "initialising terraform")
+		}
+	}
+
+	result, err := tf.Output(ctx, environment)
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieving terraform outputs")
+	}
+
+	if cache != nil {
+		if err = cache.Set(environment, version, result); err != nil {
+			slog.Debug("Caching terraform outputs", slog.String("error", err.Error()))
+		}
+	}
+
+	provider := secrets.TransformOutputs(result, environment)
+	return &provider, nil
+}
+
+// outputCache returns the cache for Terraform outputs, or nil if
+// there's no age identity to encrypt it with.
+func (c *CommandInput) outputCache() *infra.OutputCache {
+	identities, err := age.ReadIdentities()
+	if err != nil {
+		c.Printer().Warn("Terraform outputs won't be cached, no age key could be read: " + err.Error())
+		return nil
+	}
+	return infra.NewOutputCache(c.FS, identities)
+}
+
+// manager returns the function that creates Terraform managers,
+// defaulting to infra.NewManager.
+func (c *CommandInput) manager() ManagerFunc {
+	if c.NewManager != nil {
+		return c.NewManager
+	}
+	return infra.NewManager
+}
+
+// stateVersion returns the function that reads remote state versions,
+// defaulting to infra.RemoteStateVersion.
+func (c *CommandInput) stateVersion() StateVersionFunc {
+	if c.StateVersion != nil {
+		return c.StateVersion
+	}
+	return infra.RemoteStateVersion
+}
@@ -0,0 +1,179 @@
+package cmdtools
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/infra"
+	mockinfra "github.com/ainsleydev/webkit/internal/infra/mocks"
+	"github.com/ainsleydev/webkit/internal/secrets"
+	"github.com/ainsleydev/webkit/internal/secrets/age"
+	"github.com/ainsleydev/webkit/internal/state/manifest"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+func TestCommandInput_TerraformOutputs(t *testing.T) {
+	outputs := infra.OutputResult{
+		Resources: map[string]map[string]any{
+			"db": {"connection_url": "postgres://host/db"},
+		},
+	}
+	key := secrets.OutputKey{Environment: env.Production, ResourceName: "db", OutputName: "connection_url"}
+
+	setupOutputs := func(t *testing.T, manager infra.Manager, versionErr error) CommandInput {
+		t.Helper()
+
+		identity, err := age.NewIdentity()
+		require.NoError(t, err)
+		t.Setenv(age.KeyEnvVar, identity.String())
+
+		input := CommandInput{
+			FS:          afero.NewMemMapFs(),
+			AppDefCache: &appdef.Definition{Project: appdef.Project{Name: "project"}},
+			Manifest:    manifest.NewTracker(),
+			NewManager: func(context.Context, *appdef.Definition, *manifest.Tracker, ...infra.Option) (infra.Manager, error) {
+				return manager, nil
+			},
+			StateVersion: func(context.Context, string, env.Environment) (infra.StateVersion, error) {
+				return infra.StateVersion{Lineage: "abc", Serial: 1}, versionErr
+			},
+		}
+		input.Printer().SetWriter(io.Discard)
+
+		return input
+	}
+
+	t.Run("Fetches And Caches", func(t *testing.T) {
+		manager := mockinfra.NewMockManager(gomock.NewController(t))
+		manager.EXPECT().Init(gomock.Any()).Return(nil).Times(1)
+		manager.EXPECT().Output(gomock.Any(), env.Production).Return(outputs, nil).Times(1)
+		manager.EXPECT().Cleanup().Times(1)
+
+		input := setupOutputs(t, manager, nil)
+
+		got, err := input.TerraformOutputs(t.Context(), env.Production, OutputOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "postgres://host/db", (*got)[key])
+
+		t.Log("Second call is served from the cache")
+		{
+			got, err = input.TerraformOutputs(t.Context(), env.Production, OutputOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, "postgres://host/db", (*got)[key])
+		}
+	})
+
+	t.Run("Refresh Bypasses Cache", func(t *testing.T) {
+		manager := mockinfra.NewMockManager(gomock.NewController(t))
+		manager.EXPECT().Init(gomock.Any()).Return(nil).Times(2)
+		manager.EXPECT().Output(gomock.Any(), env.Production).Return(outputs, nil).Times(2)
+		manager.EXPECT().Cleanup().Times(2)
+
+		input := setupOutputs(t, manager, nil)
+
+		for range 2 {
+			_, err := input.TerraformOutputs(t.Context(), env.Production, OutputOptions{Refresh: true})
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("Uncached When State Unavailable", func(t *testing.T) {
+		manager := mockinfra.NewMockManager(gomock.NewController(t))
+		manager.EXPECT().Init(gomock.Any()).Return(nil).Times(1)
+		manager.EXPECT().Output(gomock.Any(), env.Production).Return(outputs, nil).Times(1)
+		manager.EXPECT().Cleanup().Times(1)
+
+		input := setupOutputs(t, manager, errors.New("no state"))
+		buf := &bytes.Buffer{}
+		input.Printer().SetWriter(buf)
+
+		_, err := input.TerraformOutputs(t.Context(), env.Production, OutputOptions{})
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "Terraform outputs won't be cached")
+
+		exists, err := afero.DirExists(input.FS, infra.OutputCacheDir)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("Uncached Without Age Key", func(t *testing.T) {
+		manager := mockinfra.NewMockManager(gomock.NewController(t))
+		manager.EXPECT().Init(gomock.Any()).Return(nil).Times(1)
+		manager.EXPECT().Output(gomock.Any(), env.Production).Return(outputs, nil).Times(1)
+		manager.EXPECT().Cleanup().Times(1)
+
+		input := setupOutputs(t, manager, nil)
+		t.Setenv(age.KeyEnvVar, "")
+		t.Setenv("HOME", t.TempDir())
+		buf := &bytes.Buffer{}
+		input.Printer().SetWriter(buf)
+
+		_, err := input.TerraformOutputs(t.Context(), env.Production, OutputOptions{})
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "Terraform outputs won't be cached, no age key could be read")
+
+		exists, err := afero.DirExists(input.FS, infra.OutputCacheDir)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("Several Age Keys", func(t *testing.T) {
+		manager := mockinfra.NewMockManager(gomock.NewController(t))
+		manager.EXPECT().Init(gomock.Any()).Return(nil).Times(1)
+		manager.EXPECT().Output(gomock.Any(), env.Production).Return(outputs, nil).Times(1)
+		manager.EXPECT().Cleanup().Times(1)
+
+		input := setupOutputs(t, manager, nil)
+		first, err := age.NewIdentity()
+		require.NoError(t, err)
+		second, err := age.NewIdentity()
+		require.NoError(t, err)
+		t.Setenv(age.KeyEnvVar, first.String()+"\n"+second.String())
+
+		for range 2 {
+			got, err := input.TerraformOutputs(t.Context(), env.Production, OutputOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, "postgres://host/db", (*got)[key])
+		}
+	})
+
+	t.Run("Reuses Initialised Manager", func(t *testing.T) {
+		manager := mockinfra.NewMockManager(gomock.NewController(t))
+		manager.EXPECT().Output(gomock.Any(), env.Production).Return(outputs, nil).Times(1)
+
+		input := setupOutputs(t, nil, nil)
+
+		_, err := input.TerraformOutputs(t.Context(), env.Production, OutputOptions{Manager: manager})
+		require.NoError(t, err)
+	})
+
+	t.Run("Init Error", func(t *testing.T) {
+		manager := mockinfra.NewMockManager(gomock.NewController(t))
+		manager.EXPECT().Init(gomock.Any()).Return(errors.New("init error")).Times(1)
+		manager.EXPECT().Cleanup().Times(1)
+
+		input := setupOutputs(t, manager, nil)
+
+		_, err := input.TerraformOutputs(t.Context(), env.Production, OutputOptions{})
+		assert.ErrorContains(t, err, "initialising terraform")
+	})
+
+	t.Run("Output Error", func(t *testing.T) {
+		manager := mockinfra.NewMockManager(gomock.NewController(t))
+		manager.EXPECT().Output(gomock.Any(), env.Production).Return(infra.OutputResult{}, errors.New("output error")).Times(1)
+
+		input := setupOutputs(t, nil, nil)
+
+		_, err := input.TerraformOutputs(t.Context(), env.Production, OutputOptions{Manager: manager})
+		assert.ErrorContains(t, err, "retrieving terraform outputs")
+	})
+}
@@ -2,13 +2,17 @@ package ghapi
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/google/go-github/v76/github"
+	"golang.org/x/crypto/nacl/box"
 
 	"github.com/ainsleydev/webkit/pkg/enforce"
 )
@@ -29,6 +33,14 @@ type Client interface {
 	// GetFileContent fetches the content of a file from a repository at a specific ref (tag/branch/commit).
 	// Returns the decoded file content as bytes.
 	GetFileContent(ctx context.Context, owner, repo, path, ref string) ([]byte, error)
+
+	// DispatchWorkflow triggers a workflow_dispatch event for the workflow
+	// file (e.g. "rollback.yaml") on the given ref with the given inputs.
+	DispatchWorkflow(ctx context.Context, owner, repo, workflowFile, ref string, inputs map[string]any) error
+
+	// SetRepoSecret creates or updates a GitHub Actions secret in the
+	// repository, encrypting the value with the repository's public key.
+	SetRepoSecret(ctx context.Context, owner, repo, name, value string) error
 }
 
 // DefaultClient implements the Client interface using the official go-github library.
@@ -174,3 +186,49 @@ func (c *DefaultClient) GetFileContent(ctx context.Context, owner, repo, path, r
 
 	return []byte(content), nil
 }
+
+// DispatchWorkflow triggers a workflow_dispatch event for a workflow file.
+// The workflow must declare a workflow_dispatch trigger with matching inputs.
+func (c *DefaultClient) DispatchWorkflow(ctx context.Context, owner, repo, workflowFile, ref string, inputs map[string]any) error {
+	_, err := c.client.Actions.CreateWorkflowDispatchEventByFileName(
+		ctx,
+		owner,
+		repo,
+		workflowFile,
+		github.CreateWorkflowDispatchEventRequest{
+			Ref:    ref,
+			Inputs: inputs,
+		},
+	)
+	return err
+}
+
+// SetRepoSecret creates or updates an Actions secret. GitHub requires
+// the value to be sealed with the repository's public key before it's
+// sent, so it's never transmitted in plaintext.
+func (c *DefaultClient) SetRepoSecret(ctx context.Context, owner, repo, name, value string) error {
+	key, _, err := c.client.Actions.GetRepoPublicKey(ctx, owner, repo)
+	if err != nil {
+		return fmt.Errorf("fetching repository public key: %w", err)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(key.GetKey())
+	if err != nil || len(decoded) != 32 {
+		return errors.New("invalid repository public key")
+	}
+
+	var publicKey [32]byte
+	copy(publicKey[:], decoded)
+
+	sealed, err := box.SealAnonymous(nil, []byte(value), &publicKey, rand.Reader)
+	if err != nil {
+		return fmt.Errorf("encrypting secret: %w", err)
+	}
+
+	_, err = c.client.Actions.CreateOrUpdateRepoSecret(ctx, owner, repo, &github.EncryptedSecret{
+		Name:           name,
+		KeyID:          key.GetKeyID(),
+		EncryptedValue: base64.StdEncoding.EncodeToString(sealed),
+	})
+	return err
+}
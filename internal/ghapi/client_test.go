@@ -2,10 +2,19 @@ package ghapi
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
+	"github.com/google/go-github/v76/github"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/box"
 )
 
 func TestNew(t *testing.T) {
@@ -271,3 +280,147 @@ func TestGetFileContent(t *testing.T) {
 		assert.Contains(t, string(content), "3.1.0")
 	})
 }
+
+// newTestClient returns a DefaultClient that sends requests to the handler.
+func newTestClient(t *testing.T, handler http.Handler) *DefaultClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	u, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+	client.BaseURL = u
+
+	return &DefaultClient{client: client}
+}
+
+func TestDefaultClient_GetLatestSHATag(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Returns newest tag", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/users/owner/packages/container/repo-web/versions", r.URL.Path)
+			_, _ = w.Write([]byte(`[
+				{"created_at": "2025-11-01T10:00:00Z", "metadata": {"container": {"tags": ["sha-aaa"]}}},
+				{"created_at": "2025-11-01T12:00:00Z", "metadata": {"container": {"tags": ["latest"]}}},
+				{"created_at": "2025-11-01T11:00:00Z", "metadata": {"container": {"tags": ["sha-bbb", "main"]}}}
+			]`))
+		}))
+
+		got, err := client.GetLatestSHATag(t.Context(), "owner", "repo", "web")
+		require.NoError(t, err)
+		assert.Equal(t, "sha-bbb", got)
+	})
+
+	t.Run("Error when no SHA tags", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`[{"created_at": "2025-11-01T10:00:00Z", "metadata": {"container": {"tags": ["latest"]}}}]`))
+		}))
+
+		_, err := client.GetLatestSHATag(t.Context(), "owner", "repo", "web")
+		assert.ErrorContains(t, err, "no sha-tags found")
+	})
+}
+
+func TestDefaultClient_DispatchWorkflow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/repos/owner/repo/actions/workflows/rollback.yaml/dispatches", r.URL.Path)
+
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "main", body["ref"])
+			assert.Equal(t, map[string]any{"app": "web"}, body["inputs"])
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		err := client.DispatchWorkflow(t.Context(), "owner", "repo", "rollback.yaml", "main", map[string]any{"app": "web"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+
+		err := client.DispatchWorkflow(t.Context(), "owner", "repo", "rollback.yaml", "main", nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestDefaultClient_SetRepoSecret(t *testing.T) {
+	t.Parallel()
+
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/repos/owner/repo/actions/secrets/public-key":
+				assert.Equal(t, http.MethodGet, r.Method)
+				_ = json.NewEncoder(w).Encode(map[string]string{
+					"key_id": "123",
+					"key":    base64.StdEncoding.EncodeToString(publicKey[:]),
+				})
+			case "/repos/owner/repo/actions/secrets/REPO_AGE_SECRET_PRODUCTION":
+				assert.Equal(t, http.MethodPut, r.Method)
+
+				var body map[string]string
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, "123", body["key_id"])
+
+				sealed, err := base64.StdEncoding.DecodeString(body["encrypted_value"])
+				require.NoError(t, err)
+				opened, ok := box.OpenAnonymous(nil, sealed, publicKey, privateKey)
+				require.True(t, ok)
+				assert.Equal(t, "AGE-SECRET-KEY-1", string(opened))
+
+				w.WriteHeader(http.StatusCreated)
+			default:
+				t.Errorf("unexpected request to %s", r.URL.Path)
+			}
+		}))
+
+		err := client.SetRepoSecret(t.Context(), "owner", "repo", "REPO_AGE_SECRET_PRODUCTION", "AGE-SECRET-KEY-1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Public Key Error", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+
+		err := client.SetRepoSecret(t.Context(), "owner", "repo", "NAME", "value")
+		assert.ErrorContains(t, err, "fetching repository public key")
+	})
+
+	t.Run("Invalid Public Key", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"key_id": "123", "key": "short"})
+		}))
+
+		err := client.SetRepoSecret(t.Context(), "owner", "repo", "NAME", "value")
+		assert.ErrorContains(t, err, "invalid repository public key")
+	})
+}
@@ -0,0 +1,528 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/cast"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/util/executil"
+	"github.com/ainsleydev/webkit/pkg/enforce"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+// ComposeDir is the directory, relative to the project root, that the
+// Docker Compose file for the local stack is written to.
+var ComposeDir = filepath.Join(".webkit", "dev")
+
+const (
+	composeFileName = "docker-compose.yaml"
+
+	composePostgresImage = "postgres:17-alpine"
+	composeMinIOImage    = "minio/minio:latest"
+	composeMinIOCLIImage = "minio/mc:latest"
+	composeLibSQLImage   = "ghcr.io/tursodatabase/libsql-server:latest"
+
+	// composeUser and composePassword are the credentials used for every
+	// local resource. They're only reachable from the host machine so
+	// there is no need for them to be secret.
+	composeUser     = "webkit"
+	composePassword = "webkit-password"
+	composeRegion   = "us-east-1"
+)
+
+// Compose is a Manager that runs the apps and resources defined in
+// app.json locally with Docker Compose. Postgres resources run on
+// Postgres, S3 resources on MinIO and SQLite resources on libsql-server.
+// Each app is built from its Dockerfile.
+//
+// It backs the webkit dev commands, the outputs mirror the Terraform
+// resource outputs so the same resource references can be resolved
+// against the local stack.
+type Compose struct {
+	appDef  *appdef.Definition
+	fs      afero.Fs
+	runner  executil.Runner
+	baseDir string
+	stdout  io.Writer
+	// ports caches the host port of each service, keyed by
+	// service name, allocated when the file is rendered.
+	ports map[string]int
+}
+
+var _ Manager = (*Compose)(nil)
+
+// NewCompose creates a new Docker Compose manager for the project
+// within baseDir.
+func NewCompose(appDef *appdef.Definition, baseDir string, runner executil.Runner) *Compose {
+	enforce.NotNil(appDef, "app definition is required")
+	enforce.NotNil(runner, "runner is required")
+
+	return &Compose{
+		appDef:  appDef,
+		fs:      afero.NewOsFs(),
+		runner:  runner,
+		baseDir: baseDir,
+		stdout:  os.Stdout,
+	}
+}
+
+type (
+	// composeFile is the structure of a docker-compose.yaml file,
+	// only the keys used by webkit are defined.
+	composeFile struct {
+		Name     string                    `yaml:"name"`
+		Services map[string]composeService `yaml:"services"`
+		Volumes  map[string]struct{}       `yaml:"volumes,omitempty"`
+	}
+	composeService struct {
+		Image       string                       `yaml:"image,omitempty"`
+		Build       *composeBuild                `yaml:"build,omitempty"`
+		Entrypoint  []string                     `yaml:"entrypoint,omitempty"`
+		Command     []string                     `yaml:"command,omitempty"`
+		Environment map[string]string            `yaml:"environment,omitempty"`
+		Ports       []string                     `yaml:"ports,omitempty"`
+		Volumes     []string                     `yaml:"volumes,omitempty"`
+		DependsOn   map[string]composeDependency `yaml:"depends_on,omitempty"`
+		Healthcheck *composeHealthcheck          `yaml:"healthcheck,omitempty"`
+		Restart     string                       `yaml:"restart,omitempty"`
+	}
+	composeBuild struct {
+		Context    string `yaml:"context"`
+		Dockerfile string `yaml:"dockerfile"`
+	}
+	composeDependency struct {
+		Condition string `yaml:"condition"`
+	}
+	composeHealthcheck struct {
+		Test     []string `yaml:"test"`
+		Interval string   `yaml:"interval"`
+		Timeout  string   `yaml:"timeout"`
+		Retries  int      `yaml:"retries"`
+	}
+)
+
+// Init renders the Docker Compose file from the app definition and
+// writes it to the ComposeDir.
+//
+// Must be called before Plan() or Apply()
+func (c *Compose) Init(ctx context.Context) error {
+	if _, err := c.runner.Run(ctx, executil.NewCommand("docker", "compose", "version")); err != nil {
+		return errors.Wrap(err, "docker compose not found (install Docker or add it to your PATH)")
+	}
+
+	data, err := yaml.Marshal(c.render())
+	if err != nil {
+		return errors.Wrap(err, "marshalling compose file")
+	}
+
+	// The file holds the resolved secrets of every app, so only the
+	// current user may read it. Permissions are set again in case the
+	// dir or file were left behind by an older version.
+	if err = c.fs.MkdirAll(filepath.Dir(c.WorkDir()), 0o755); err != nil {
+		return errors.Wrap(err, "creating compose dir")
+	}
+	if err = c.fs.MkdirAll(c.WorkDir(), 0o700); err != nil {
+		return errors.Wrap(err, "creating compose dir")
+	}
+	if err = c.fs.Chmod(c.WorkDir(), 0o700); err != nil {
+		return errors.Wrap(err, "setting compose dir permissions")
+	}
+
+	if err = afero.WriteFile(c.fs, c.path(), data, 0o600); err != nil {
+		return errors.Wrap(err, "writing compose file")
+	}
+	if err = c.fs.Chmod(c.path(), 0o600); err != nil {
+		return errors.Wrap(err, "setting compose file permissions")
+	}
+
+	return nil
+}
+
+// Plan validates the rendered Compose file and returns the
+// resolved configuration.
+//
+// Must be called after Init().
+func (c *Compose) Plan(ctx context.Context, _ env.Environment, _ bool) (PlanOutput, error) {
+	res, err := c.compose(ctx, false, "config")
+	if err != nil {
+		return PlanOutput{}, errors.Wrap(err, "docker compose config failed")
+	}
+	return PlanOutput{HasChanges: true, Output: res.Output}, nil
+}
+
+// Apply builds the apps and starts every container in the background.
+//
+// Must be called after Init().
+func (c *Compose) Apply(ctx context.Context, _ env.Environment, _ bool) (ApplyOutput, error) {
+	res, err := c.compose(ctx, true, "up", "--detach", "--build", "--remove-orphans")
+	if err != nil {
+		return ApplyOutput{}, errors.Wrap(err, "docker compose up failed")
+	}
+	return ApplyOutput{Output: res.Output}, nil
+}
+
+// Destroy stops and removes every container, network and volume.
+//
+// Must be called after Init().
+func (c *Compose) Destroy(ctx context.Context, _ env.Environment) (DestroyOutput, error) {
+	return c.Down(ctx, true)
+}
+
+// Down stops and removes every container and network, volumes
+// (and therefore data) are only removed if removeVolumes is true.
+// The rendered Compose file is removed once the stack is down.
+//
+// Must be called after Init().
+func (c *Compose) Down(ctx context.Context, removeVolumes bool) (DestroyOutput, error) {
+	args := []string{"down", "--remove-orphans"}
+	if removeVolumes {
+		args = append(args, "--volumes")
+	}
+
+	res, err := c.compose(ctx, true, args...)
+	if err != nil {
+		return DestroyOutput{}, errors.Wrap(err, "docker compose down failed")
+	}
+
+	if err = c.fs.RemoveAll(c.WorkDir()); err != nil {
+		return DestroyOutput{}, errors.Wrap(err, "removing compose dir")
+	}
+
+	return DestroyOutput{Output: res.Output}, nil
+}
+
+// Output returns the connection details of the local resources as
+// seen from the host machine, using the same output names as the
+// Terraform resource module. Apps are keyed by name with their URL.
+func (c *Compose) Output(_ context.Context, _ env.Environment) (OutputResult, error) {
+	return c.outputs(false), nil
+}
+
+// ServiceOutputs returns the connection details of the local resources
+// as seen from within the Compose network, for use by the app containers.
+func (c *Compose) ServiceOutputs() OutputResult {
+	return c.outputs(true)
+}
+
+// Import is not supported as the local stack has no state.
+func (c *Compose) Import(_ context.Context, _ ImportInput) (ImportOutput, error) {
+	return ImportOutput{}, errors.New("importing resources is not supported by docker compose")
+}
+
+// Cost returns an empty report as the local stack is free to run.
+func (c *Compose) Cost(_ context.Context, environment env.Environment) (CostReport, error) {
+	return CostReport{
+		Environment:   environment,
+		Currency:      "USD",
+		PriceVersions: map[string]string{},
+		Items:         []CostItem{},
+		Changes:       []CostChange{},
+	}, nil
+}
+
+// Drift returns an empty report as the local stack has no remote
+// state to drift from.
+func (c *Compose) Drift(_ context.Context, environment env.Environment) (DriftReport, error) {
+	return DriftReport{
+		Environment: environment,
+		Resources:   []DriftedResource{},
+	}, nil
+}
+
+// Cleanup is a no-op, the Compose file is kept so the
+// stack can be torn down at a later date.
+func (c *Compose) Cleanup() {}
+
+// WorkDir returns the directory the Compose file is written to.
+func (c *Compose) WorkDir() string {
+	return filepath.Join(c.baseDir, ComposeDir)
+}
+
+// ProjectName returns the Compose project name, used to
+// namespace the containers, networks and volumes.
+func (c *Compose) ProjectName() string {
+	return c.appDef.Project.Name + "-dev"
+}
+
+func (c *Compose) path() string {
+	return filepath.Join(c.WorkDir(), composeFileName)
+}
+
+// compose runs a docker compose command against the rendered file. If
+// stream is true, output is written to stdout as the command runs.
+func (c *Compose) compose(ctx context.Context, stream bool, args ...string) (executil.Result, error) {
+	base := []string{
+		"compose",
+		"--project-name", c.ProjectName(),
+		"--project-directory", c.baseDir,
+		"--file", c.path(),
+	}
+
+	cmd := executil.NewCommand("docker", append(base, args...)...)
+	cmd.Dir = c.baseDir
+	if stream && c.stdout != nil {
+		cmd.Stdout = c.stdout
+		cmd.Stderr = c.stdout
+	}
+
+	return c.runner.Run(ctx, cmd)
+}
+
+// render builds the Compose file from the app definition. Host ports
+// are allocated in definition order (apps first), starting at each
+// service's default port and moving up until a free one is found.
+func (c *Compose) render() composeFile {
+	c.allocatePorts()
+
+	file := composeFile{
+		Name:     c.ProjectName(),
+		Services: make(map[string]composeService),
+		Volumes:  make(map[string]struct{}),
+	}
+
+	dependsOn := make(map[string]composeDependency)
+	for _, res := range c.appDef.Resources {
+		volume := res.Name + "-data"
+		port := c.ports[res.Name]
+
+		switch res.Type {
+		case appdef.ResourceTypePostgres:
+			file.Services[res.Name] = composeService{
+				Image: composePostgresImage,
+				Environment: map[string]string{
+					"POSTGRES_USER":     composeUser,
+					"POSTGRES_PASSWORD": composePassword,
+					"POSTGRES_DB":       res.Name,
+				},
+				Ports:   []string{fmt.Sprintf("%d:5432", port)},
+				Volumes: []string{volume + ":/var/lib/postgresql/data"},
+				Healthcheck: &composeHealthcheck{
+					Test:     []string{"CMD-SHELL", fmt.Sprintf("pg_isready -U %s -d %s", composeUser, res.Name)},
+					Interval: "5s",
+					Timeout:  "5s",
+					Retries:  10,
+				},
+				Restart: "unless-stopped",
+			}
+			dependsOn[res.Name] = composeDependency{Condition: "service_healthy"}
+		case appdef.ResourceTypeS3:
+			file.Services[res.Name] = composeService{
+				Image:   composeMinIOImage,
+				Command: []string{"server", "/data", "--console-address", ":9001"},
+				Environment: map[string]string{
+					"MINIO_ROOT_USER":     composeUser,
+					"MINIO_ROOT_PASSWORD": composePassword,
+				},
+				Ports:   []string{fmt.Sprintf("%d:9000", port)},
+				Volumes: []string{volume + ":/data"},
+				Healthcheck: &composeHealthcheck{
+					Test:     []string{"CMD", "mc", "ready", "local"},
+					Interval: "5s",
+					Timeout:  "5s",
+					Retries:  10,
+				},
+				Restart: "unless-stopped",
+			}
+			// MinIO has no way of creating buckets on start, so a one-off
+			// container creates the bucket once the server is healthy.
+			file.Services[res.Name+"-init"] = composeService{
+				Image:      composeMinIOCLIImage,
+				Entrypoint: []string{"sh", "-c", c.minioInitScript(res)},
+				DependsOn:  map[string]composeDependency{res.Name: {Condition: "service_healthy"}},
+			}
+			dependsOn[res.Name+"-init"] = composeDependency{Condition: "service_completed_successfully"}
+		case appdef.ResourceTypeSQLite:
+			file.Services[res.Name] = composeService{
+				Image:   composeLibSQLImage,
+				Ports:   []string{fmt.Sprintf("%d:8080", port)},
+				Volumes: []string{volume + ":/var/lib/sqld"},
+				Restart: "unless-stopped",
+			}
+			dependsOn[res.Name] = composeDependency{Condition: "service_started"}
+		default:
+			continue
+		}
+
+		file.Volumes[volume] = struct{}{}
+	}
+
+	for _, app := range c.appDef.Apps {
+		buildContext := app.BuildContext()
+		dockerfile, err := filepath.Rel(buildContext, filepath.Join(app.Path, app.Build.Dockerfile))
+		if err != nil {
+			dockerfile = app.Build.Dockerfile
+		}
+
+		contextPath := filepath.ToSlash(buildContext)
+		if contextPath != "." {
+			contextPath = "./" + contextPath
+		}
+
+		svc := composeService{
+			Build: &composeBuild{
+				Context:    contextPath,
+				Dockerfile: filepath.ToSlash(dockerfile),
+			},
+			Environment: c.appEnvironment(app),
+			Ports:       []string{fmt.Sprintf("%d:%d", c.ports[app.Name], app.Build.Port)},
+			Restart:     "unless-stopped",
+		}
+		if len(dependsOn) > 0 {
+			svc.DependsOn = dependsOn
+		}
+
+		file.Services[app.Name] = svc
+	}
+
+	return file
+}
+
+// appEnvironment returns the development environment variables for an
+// app, merged with the shared environment. Secrets and resource
+// references should have been resolved before the file is rendered.
+func (c *Compose) appEnvironment(app appdef.App) map[string]string {
+	environment := map[string]string{
+		"PORT": strconv.Itoa(app.Build.Port),
+	}
+
+	merged := app.MergeEnvironments(c.appDef.Shared.Env)
+	vars, err := merged.GetVarsForEnvironment(env.Development)
+	if err != nil {
+		return environment
+	}
+
+	// Compose interpolates variables within the file, so any
+	// dollar signs within values need escaping.
+	for key, value := range vars {
+		environment[key] = strings.ReplaceAll(cast.ToString(value.Value), "$", "$$")
+	}
+
+	return environment
+}
+
+// minioInitScript returns the shell script that creates the bucket
+// for an S3 resource, allowing anonymous downloads if it's public.
+func (c *Compose) minioInitScript(res appdef.Resource) string {
+	script := fmt.Sprintf(
+		"mc alias set local http://%s:9000 %s %s && mc mb --ignore-existing local/%s",
+		res.Name, composeUser, composePassword, res.Name,
+	)
+	if acl, ok := res.Config["acl"].(string); ok && acl == "public-read" {
+		script += fmt.Sprintf(" && mc anonymous set download local/%s", res.Name)
+	}
+	return script
+}
+
+// composeDefaultPorts is the preferred host port for each resource type.
+var composeDefaultPorts = map[appdef.ResourceType]int{
+	appdef.ResourceTypePostgres: 5432,
+	appdef.ResourceTypeS3:       9000,
+	appdef.ResourceTypeSQLite:   8080,
+}
+
+// allocatePorts assigns a unique host port to every app and resource.
+func (c *Compose) allocatePorts() {
+	c.ports = make(map[string]int)
+	used := make(map[int]bool)
+
+	allocate := func(name string, port int) {
+		for used[port] {
+			port++
+		}
+		used[port] = true
+		c.ports[name] = port
+	}
+
+	for _, app := range c.appDef.Apps {
+		allocate(app.Name, app.Build.Port)
+	}
+	for _, res := range c.appDef.Resources {
+		if port, ok := composeDefaultPorts[res.Type]; ok {
+			allocate(res.Name, port)
+		}
+	}
+}
+
+// outputs returns the connection details for every resource and app.
+// If internal is true, hosts are the service names within the Compose
+// network, otherwise they're localhost with the allocated host port.
+func (c *Compose) outputs(internal bool) OutputResult {
+	if c.ports == nil {
+		c.allocatePorts()
+	}
+
+	result := OutputResult{
+		Resources: make(map[string]map[string]any),
+		Apps:      make(map[string]map[string]any),
+		Extra:     make(map[string]any),
+	}
+
+	address := func(name string, containerPort int) (string, int) {
+		if internal {
+			return name, containerPort
+		}
+		return "localhost", c.ports[name]
+	}
+
+	for _, res := range c.appDef.Resources {
+		outputs := map[string]any{
+			"id":                res.Name,
+			"name":              res.Name,
+			"platform_type":     res.Type.String(),
+			"platform_provider": "docker",
+		}
+
+		switch res.Type {
+		case appdef.ResourceTypePostgres:
+			host, port := address(res.Name, 5432)
+			outputs["host"] = host
+			outputs["port"] = port
+			outputs["database"] = res.Name
+			outputs["user"] = composeUser
+			outputs["password"] = composePassword
+			outputs["connection_url"] = fmt.Sprintf(
+				"postgresql://%s:%s@%s:%d/%s?sslmode=disable",
+				composeUser, composePassword, host, port, res.Name,
+			)
+		case appdef.ResourceTypeS3:
+			host, port := address(res.Name, 9000)
+			// The bucket URL is opened by browsers, so always
+			// points to the host rather than the service.
+			outputs["bucket_name"] = res.Name
+			outputs["bucket_url"] = fmt.Sprintf("http://localhost:%d/%s", c.ports[res.Name], res.Name)
+			outputs["endpoint"] = fmt.Sprintf("http://%s:%d", host, port)
+			outputs["region"] = composeRegion
+			outputs["access_key"] = composeUser
+			outputs["secret_key"] = composePassword
+		case appdef.ResourceTypeSQLite:
+			host, port := address(res.Name, 8080)
+			outputs["host"] = host
+			outputs["database"] = res.Name
+			outputs["auth_token"] = ""
+			outputs["connection_url"] = fmt.Sprintf("http://%s:%d", host, port)
+		default:
+			continue
+		}
+
+		result.Resources[res.Name] = outputs
+	}
+
+	for _, app := range c.appDef.Apps {
+		host, port := address(app.Name, app.Build.Port)
+		result.Apps[app.Name] = map[string]any{
+			"url": fmt.Sprintf("http://%s:%d", host, port),
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,370 @@
+package infra
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"sort"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/pkg/errors"
+
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+//go:embed pricing/*.json
+var pricingFS embed.FS
+
+type (
+	// CostReport is the result of calling Cost, it contains the estimated
+	// monthly cost of every app and resource and the change in cost
+	// that the current plan would introduce.
+	CostReport struct {
+		Environment   env.Environment   `json:"environment"`
+		Currency      string            `json:"currency"`
+		PriceVersions map[string]string `json:"price_versions"`
+		Items         []CostItem        `json:"items"`
+		Total         float64           `json:"total"`
+		Changes       []CostChange      `json:"changes"`
+		Delta         float64           `json:"delta"`
+	}
+	// CostItem is the estimated monthly cost of a single app or resource.
+	CostItem struct {
+		Kind     string  `json:"kind"`
+		Name     string  `json:"name"`
+		Provider string  `json:"provider"`
+		Type     string  `json:"type"`
+		Size     string  `json:"size"`
+		Monthly  float64 `json:"monthly"`
+		Priced   bool    `json:"priced"`
+		Note     string  `json:"note,omitempty"`
+	}
+	// CostChange is the change in monthly cost of a single Terraform
+	// resource within the plan.
+	CostChange struct {
+		Kind    string  `json:"kind"`
+		Name    string  `json:"name"`
+		Address string  `json:"address"`
+		Action  string  `json:"action"`
+		Before  float64 `json:"before"`
+		After   float64 `json:"after"`
+		Delta   float64 `json:"delta"`
+	}
+)
+
+type (
+	// priceTable is the embedded price list for a single provider,
+	// see the pricing directory.
+	priceTable struct {
+		Provider string               `json:"provider"`
+		Version  string               `json:"version"`
+		Currency string               `json:"currency"`
+		Types    map[string]priceTier `json:"types"`
+	}
+	// priceTier describes how to price a platform type (vm, postgres, etc.)
+	// from its config. The keys map to the config keys in app.json, which
+	// are the same keys the Terraform modules read.
+	priceTier struct {
+		SizeKey        string             `json:"size_key"`
+		CountKey       string             `json:"count_key"`
+		MultiAZKey     string             `json:"multi_az_key"`
+		StorageKey     string             `json:"storage_key"`
+		StorageDefault float64            `json:"storage_default"`
+		StoragePerGB   float64            `json:"storage_per_gb"`
+		Default        string             `json:"default"`
+		Sizes          map[string]float64 `json:"sizes"`
+		Note           string             `json:"note"`
+	}
+	// priceTables is a lookup of provider name to price table.
+	priceTables map[string]priceTable
+)
+
+// loadPriceTables reads every embedded provider price table.
+func loadPriceTables() (priceTables, error) {
+	entries, err := pricingFS.ReadDir("pricing")
+	if err != nil {
+		return nil, errors.Wrap(err, "reading price tables")
+	}
+
+	tables := make(priceTables, len(entries))
+	for _, entry := range entries {
+		data, err := pricingFS.ReadFile(path.Join("pricing", entry.Name()))
+		if err != nil {
+			return nil, errors.Wrap(err, "reading price table")
+		}
+		var table priceTable
+		if err = json.Unmarshal(data, &table); err != nil {
+			return nil, fmt.Errorf("parsing price table %s: %w", entry.Name(), err)
+		}
+		tables[table.Provider] = table
+	}
+
+	return tables, nil
+}
+
+// tier returns the price tier for a provider and platform type.
+func (p priceTables) tier(provider, platformType string) (priceTier, bool) {
+	table, ok := p[provider]
+	if !ok {
+		return priceTier{}, false
+	}
+	tier, ok := table.Types[platformType]
+	return tier, ok
+}
+
+// monthly returns the size and monthly cost for the given config.
+// Returns false if the size isn't in the price table.
+func (t priceTier) monthly(config map[string]any) (string, float64, bool) {
+	size := t.Default
+	if s, ok := config[t.SizeKey].(string); ok && s != "" {
+		size = s
+	}
+
+	base, ok := t.Sizes[size]
+	if !ok {
+		return size, 0, false
+	}
+
+	count := 1.0
+	if n, ok := toFloat(config[t.CountKey]); ok && n > 0 {
+		count = n
+	}
+	if b, ok := config[t.MultiAZKey].(bool); ok && b {
+		count *= 2
+	}
+
+	total := base * count
+	if t.StorageKey != "" {
+		gb := t.StorageDefault
+		if n, ok := toFloat(config[t.StorageKey]); ok {
+			gb = n
+		}
+		total += gb * t.StoragePerGB
+	}
+
+	return size, roundCost(total), true
+}
+
+// Cost estimates the monthly cost of every app and resource for the
+// given environment, along with the change in cost of the current plan.
+//
+// Must be called after Init().
+func (t *Terraform) Cost(ctx context.Context, environment env.Environment) (CostReport, error) {
+	plan, err := t.Plan(ctx, environment, false)
+	if err != nil {
+		return CostReport{}, err
+	}
+
+	prices, err := loadPriceTables()
+	if err != nil {
+		return CostReport{}, err
+	}
+
+	report := estimateCost(t.varsCache[environment], prices, plan.Plan)
+	report.Environment = environment
+
+	return report, nil
+}
+
+// estimateCost prices the apps and resources in the Terraform variables
+// and every priced resource change within the plan.
+func estimateCost(vars tfVars, prices priceTables, plan *tfjson.Plan) CostReport {
+	report := CostReport{
+		PriceVersions: make(map[string]string, len(prices)),
+		Items:         []CostItem{},
+		Changes:       []CostChange{},
+	}
+
+	// Every table is priced in the same currency so that
+	// costs can be summed across providers.
+	for name, table := range prices {
+		report.PriceVersions[name] = table.Version
+		report.Currency = table.Currency
+	}
+
+	price := func(kind, name, provider, platformType string, config map[string]any) {
+		item := CostItem{Kind: kind, Name: name, Provider: provider, Type: platformType}
+		if tier, ok := prices.tier(provider, platformType); ok {
+			item.Size, item.Monthly, item.Priced = tier.monthly(config)
+			item.Note = tier.Note
+		}
+		report.Total += item.Monthly
+		report.Items = append(report.Items, item)
+	}
+
+	for _, app := range vars.Apps {
+		price("app", app.Name, app.PlatformProvider, app.PlatformType, app.Config)
+	}
+	for _, res := range vars.Resources {
+		price("resource", res.Name, res.PlatformProvider, res.PlatformType, res.Config)
+	}
+
+	report.Changes = planCostChanges(prices, plan)
+	for _, c := range report.Changes {
+		report.Delta += c.Delta
+	}
+
+	report.Total = roundCost(report.Total)
+	report.Delta = roundCost(report.Delta)
+
+	return report
+}
+
+// costResource maps a Terraform resource type to a price tier and
+// converts its attributes into the config keys used by that tier.
+type costResource struct {
+	provider     string
+	platformType string
+	config       func(attrs map[string]any) map[string]any
+}
+
+// costResources is a lookup of the Terraform resource types that
+// incur a cost and are created by the webkit provider modules.
+var costResources = map[string]costResource{
+	"digitalocean_droplet": {
+		provider:     "digitalocean",
+		platformType: "vm",
+		config: func(attrs map[string]any) map[string]any {
+			return map[string]any{"size": attrs["size"]}
+		},
+	},
+	"digitalocean_app": {
+		provider:     "digitalocean",
+		platformType: "container",
+		config: func(attrs map[string]any) map[string]any {
+			service := firstBlock(firstBlock(attrs, "spec"), "service")
+			return map[string]any{
+				"size":           service["instance_size_slug"],
+				"instance_count": service["instance_count"],
+			}
+		},
+	},
+	"digitalocean_database_cluster": {
+		provider:     "digitalocean",
+		platformType: "postgres",
+		config: func(attrs map[string]any) map[string]any {
+			return map[string]any{"size": attrs["size"], "node_count": attrs["node_count"]}
+		},
+	},
+	"digitalocean_spaces_bucket": {
+		provider:     "digitalocean",
+		platformType: "s3",
+		config: func(map[string]any) map[string]any {
+			return map[string]any{}
+		},
+	},
+	"hcloud_server": {
+		provider:     "hetzner",
+		platformType: "vm",
+		config: func(attrs map[string]any) map[string]any {
+			return map[string]any{"size": attrs["server_type"]}
+		},
+	},
+	"hcloud_volume": {
+		provider:     "hetzner",
+		platformType: "volume",
+		config: func(attrs map[string]any) map[string]any {
+			return map[string]any{"size": attrs["size"]}
+		},
+	},
+	"aws_db_instance": {
+		provider:     "aws",
+		platformType: "postgres",
+		config: func(attrs map[string]any) map[string]any {
+			return map[string]any{
+				"size":         attrs["instance_class"],
+				"storage_size": attrs["allocated_storage"],
+				"multi_az":     attrs["multi_az"],
+			}
+		},
+	},
+}
+
+// planCostChanges returns the change in monthly cost for every
+// priced resource that the plan would create, update or delete.
+func planCostChanges(prices priceTables, plan *tfjson.Plan) []CostChange {
+	changes := []CostChange{}
+	if plan == nil {
+		return changes
+	}
+
+	for _, rc := range plan.ResourceChanges {
+		if rc == nil || rc.Change == nil {
+			continue
+		}
+		res, ok := costResources[rc.Type]
+		if !ok {
+			continue
+		}
+		tier, ok := prices.tier(res.provider, res.platformType)
+		if !ok {
+			continue
+		}
+
+		actions := rc.Change.Actions
+		if actions.NoOp() || actions.Read() {
+			continue
+		}
+
+		change := CostChange{Address: rc.Address, Action: planAction(actions)}
+		change.Kind, change.Name = moduleOwner(rc.Address)
+		if before, ok := rc.Change.Before.(map[string]any); ok {
+			_, change.Before, _ = tier.monthly(res.config(before))
+		}
+		if after, ok := rc.Change.After.(map[string]any); ok {
+			_, change.After, _ = tier.monthly(res.config(after))
+		}
+
+		change.Delta = roundCost(change.After - change.Before)
+		if change.Delta == 0 {
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Address < changes[j].Address
+	})
+
+	return changes
+}
+
+// firstBlock returns the first element of a nested block
+// within Terraform JSON attributes.
+func firstBlock(attrs map[string]any, key string) map[string]any {
+	blocks, ok := attrs[key].([]any)
+	if !ok || len(blocks) == 0 {
+		return map[string]any{}
+	}
+	block, ok := blocks[0].(map[string]any)
+	if !ok {
+		return map[string]any{}
+	}
+	return block
+}
+
+// toFloat converts a JSON number from either app.json or
+// Terraform attributes into a float.
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// roundCost rounds a cost to the nearest cent.
+func roundCost(v float64) float64 {
+	return math.Round(v*100) / 100
+}
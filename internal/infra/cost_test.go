@@ -0,0 +1,222 @@
+package infra
+
+import (
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPriceTables(t *testing.T) {
+	t.Parallel()
+
+	tables, err := loadPriceTables()
+	require.NoError(t, err)
+
+	for _, provider := range []string{"digitalocean", "hetzner", "backblaze", "turso", "cloudflare", "aws"} {
+		t.Run(provider, func(t *testing.T) {
+			t.Parallel()
+
+			table, ok := tables[provider]
+			require.True(t, ok, "missing price table")
+			assert.NotEmpty(t, table.Version)
+			assert.Equal(t, "USD", table.Currency)
+
+			for name, tier := range table.Types {
+				_, ok := tier.Sizes[tier.Default]
+				assert.True(t, ok, "default size for %s is not priced", name)
+			}
+		})
+	}
+}
+
+func TestPriceTier_Monthly(t *testing.T) {
+	t.Parallel()
+
+	tier := priceTier{
+		SizeKey:        "size",
+		CountKey:       "node_count",
+		MultiAZKey:     "multi_az",
+		StorageKey:     "storage_size",
+		StorageDefault: 20,
+		StoragePerGB:   0.1,
+		Default:        "small",
+		Sizes:          map[string]float64{"small": 10, "large": 40},
+	}
+
+	tt := map[string]struct {
+		config    map[string]any
+		wantSize  string
+		wantCost  float64
+		wantPrice bool
+	}{
+		"Default size": {
+			config:    map[string]any{},
+			wantSize:  "small",
+			wantCost:  12,
+			wantPrice: true,
+		},
+		"Configured size": {
+			config:    map[string]any{"size": "large"},
+			wantSize:  "large",
+			wantCost:  42,
+			wantPrice: true,
+		},
+		"Node count": {
+			config:    map[string]any{"size": "large", "node_count": float64(3)},
+			wantSize:  "large",
+			wantCost:  122,
+			wantPrice: true,
+		},
+		"Multi AZ": {
+			config:    map[string]any{"multi_az": true},
+			wantSize:  "small",
+			wantCost:  22,
+			wantPrice: true,
+		},
+		"Storage": {
+			config:    map[string]any{"storage_size": 100},
+			wantSize:  "small",
+			wantCost:  20,
+			wantPrice: true,
+		},
+		"Unknown size": {
+			config:    map[string]any{"size": "huge"},
+			wantSize:  "huge",
+			wantCost:  0,
+			wantPrice: false,
+		},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			size, cost, ok := tier.monthly(test.config)
+			assert.Equal(t, test.wantSize, size)
+			assert.Equal(t, test.wantCost, cost)
+			assert.Equal(t, test.wantPrice, ok)
+		})
+	}
+}
+
+func TestEstimateCost(t *testing.T) {
+	t.Parallel()
+
+	prices, err := loadPriceTables()
+	require.NoError(t, err)
+
+	vars := tfVars{
+		Apps: []tfApp{
+			{Name: "web", PlatformProvider: "digitalocean", PlatformType: "vm", Config: map[string]any{"size": "s-1vcpu-2gb"}},
+			{Name: "cms", PlatformProvider: "digitalocean", PlatformType: "container", Config: map[string]any{"size": "apps-s-1vcpu-1gb", "instance_count": float64(2)}},
+		},
+		Resources: []tfResource{
+			{Name: "db", PlatformProvider: "digitalocean", PlatformType: "postgres", Config: map[string]any{}},
+			{Name: "store", PlatformProvider: "digitalocean", PlatformType: "s3", Config: map[string]any{}},
+			{Name: "other", PlatformProvider: "unknown", PlatformType: "vm", Config: map[string]any{}},
+		},
+	}
+
+	got := estimateCost(vars, prices, nil)
+
+	assert.Equal(t, "USD", got.Currency)
+	assert.Equal(t, "2026-10-01", got.PriceVersions["digitalocean"])
+	require.Len(t, got.Items, 5)
+	assert.Equal(t, CostItem{Kind: "app", Name: "web", Provider: "digitalocean", Type: "vm", Size: "s-1vcpu-2gb", Monthly: 12, Priced: true}, got.Items[0])
+	assert.Equal(t, 24.0, got.Items[1].Monthly)
+	assert.Equal(t, 15.0, got.Items[2].Monthly)
+	assert.Equal(t, 5.0, got.Items[3].Monthly)
+	assert.NotEmpty(t, got.Items[3].Note)
+	assert.False(t, got.Items[4].Priced)
+	assert.Equal(t, 56.0, got.Total)
+	assert.Empty(t, got.Changes)
+	assert.Zero(t, got.Delta)
+}
+
+func TestPlanCostChanges(t *testing.T) {
+	t.Parallel()
+
+	prices, err := loadPriceTables()
+	require.NoError(t, err)
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: `module.resources["db"].module.do_postgres[0].digitalocean_database_cluster.this`,
+				Type:    "digitalocean_database_cluster",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionUpdate},
+					Before:  map[string]any{"size": "db-s-1vcpu-1gb", "node_count": float64(1)},
+					After:   map[string]any{"size": "db-s-1vcpu-2gb", "node_count": float64(2)},
+				},
+			},
+			{
+				Address: `module.apps["web"].module.do_droplet[0].digitalocean_droplet.this`,
+				Type:    "digitalocean_droplet",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionCreate},
+					After:   map[string]any{"size": "s-1vcpu-1gb"},
+				},
+			},
+			{
+				Address: `module.apps["old"].module.hetzner_server[0].hcloud_server.this`,
+				Type:    "hcloud_server",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionDelete},
+					Before:  map[string]any{"server_type": "cx22"},
+				},
+			},
+			{
+				Address: `module.apps["same"].module.do_droplet[0].digitalocean_droplet.this`,
+				Type:    "digitalocean_droplet",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionNoop},
+					Before:  map[string]any{"size": "s-1vcpu-1gb"},
+					After:   map[string]any{"size": "s-1vcpu-1gb"},
+				},
+			},
+			{
+				Address: `module.resources["db"].module.do_postgres[0].digitalocean_database_db.this`,
+				Type:    "digitalocean_database_db",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionCreate},
+					After:   map[string]any{},
+				},
+			},
+		},
+	}
+
+	got := planCostChanges(prices, plan)
+	require.Len(t, got, 3)
+
+	assert.Equal(t, "app", got[0].Kind)
+	assert.Equal(t, "old", got[0].Name)
+	assert.Equal(t, "delete", got[0].Action)
+	assert.Less(t, got[0].Delta, 0.0)
+
+	assert.Equal(t, CostChange{
+		Kind:    "app",
+		Name:    "web",
+		Address: `module.apps["web"].module.do_droplet[0].digitalocean_droplet.this`,
+		Action:  "create",
+		After:   6,
+		Delta:   6,
+	}, got[1])
+
+	assert.Equal(t, CostChange{
+		Kind:    "resource",
+		Name:    "db",
+		Address: `module.resources["db"].module.do_postgres[0].digitalocean_database_cluster.this`,
+		Action:  "update",
+		Before:  15,
+		After:   60,
+		Delta:   45,
+	}, got[2])
+
+	t.Run("Nil plan", func(t *testing.T) {
+		t.Parallel()
+		assert.Empty(t, planCostChanges(prices, nil))
+	})
+}
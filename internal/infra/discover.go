@@ -0,0 +1,776 @@
+package infra
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/pkg/enforce"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+type (
+	// DiscoveryEndpoints defines the base URLs of the provider APIs that
+	// are queried when discovering existing infrastructure.
+	//
+	// They're configurable so that discovery can be pointed at a local
+	// stand-in server when testing.
+	DiscoveryEndpoints struct {
+		DigitalOcean string
+		Hetzner      string
+		HetznerDNS   string
+		BackBlaze    string
+		Turso        string
+		Cloudflare   string
+	}
+	// Discoverer looks up existing infrastructure through provider APIs
+	// using the naming conventions that the webkit Terraform modules
+	// use when provisioning (e.g. ${project_name}-${resource_name}).
+	Discoverer struct {
+		appDef    *appdef.Definition
+		env       TFEnvironment
+		endpoints DiscoveryEndpoints
+		client    *http.Client
+	}
+	// DiscoveredItem is a single proposed import mapping between an
+	// item in app.json and an existing provider resource.
+	DiscoveredItem struct {
+		// Kind specifies what type of item was discovered.
+		Kind ImportKind
+
+		// Name is the name of the resource or app in app.json (empty for projects).
+		Name string
+
+		// Provider is the cloud provider the item was discovered in.
+		Provider appdef.ResourceProvider
+
+		// RemoteName is the name of the item within the provider.
+		RemoteName string
+
+		// ID is the provider-specific ID used for importing.
+		ID string
+
+		// Options holds any additional identifiers discovered alongside
+		// the item that are required to import related resources.
+		Options map[string]string
+	}
+	// DiscoveryMiss describes an item in app.json that could not be
+	// found within the provider.
+	DiscoveryMiss struct {
+		Kind       ImportKind
+		Name       string
+		Provider   appdef.ResourceProvider
+		RemoteName string
+		Reason     string
+	}
+	// DiscoveryResult is the result of calling Discover.
+	DiscoveryResult struct {
+		Found   []DiscoveredItem
+		Missing []DiscoveryMiss
+	}
+)
+
+// DefaultDiscoveryEndpoints returns the public API endpoints for each provider.
+func DefaultDiscoveryEndpoints() DiscoveryEndpoints {
+	return DiscoveryEndpoints{
+		DigitalOcean: "https://api.digitalocean.com",
+		Hetzner:      "https://api.hetzner.cloud",
+		HetznerDNS:   "https://dns.hetzner.com",
+		BackBlaze:    "https://api.backblazeb2.com",
+		Turso:        "https://api.turso.tech",
+		Cloudflare:   "https://api.cloudflare.com",
+	}
+}
+
+// NewDiscoverer creates a new Discoverer for the given definition
+// using the provider credentials within the Terraform environment.
+func NewDiscoverer(appDef *appdef.Definition, tfEnv TFEnvironment) *Discoverer {
+	enforce.NotNil(appDef, "app definition is required")
+
+	return &Discoverer{
+		appDef:    appDef,
+		env:       tfEnv,
+		endpoints: DefaultDiscoveryEndpoints(),
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// WithEndpoints overrides the provider API endpoints used for discovery.
+func (d *Discoverer) WithEndpoints(endpoints DiscoveryEndpoints) *Discoverer {
+	d.endpoints = endpoints
+	return d
+}
+
+// Input converts the discovered item into an ImportInput for
+// the given environment.
+func (i DiscoveredItem) Input(environment env.Environment) ImportInput {
+	return ImportInput{
+		Kind:        i.Kind,
+		Name:        i.Name,
+		ID:          i.ID,
+		Environment: environment,
+		Options:     i.Options,
+	}
+}
+
+// Discover looks up every resource and app within the definition and
+// proposes an import mapping for each one that exists in the provider.
+//
+// Items that can't be found, or whose provider/type doesn't support
+// discovery, are returned in DiscoveryResult.Missing. An error is only
+// returned when a provider API request fails.
+func (d *Discoverer) Discover(ctx context.Context) (DiscoveryResult, error) {
+	var result DiscoveryResult
+
+	for i := range d.appDef.Resources {
+		res := &d.appDef.Resources[i]
+		item, miss, err := d.discoverResource(ctx, res)
+		if err != nil {
+			return DiscoveryResult{}, errors.Wrapf(err, "discovering resource %q", res.Name)
+		}
+		result.add(item, miss)
+	}
+
+	for i := range d.appDef.Apps {
+		app := &d.appDef.Apps[i]
+		item, miss, err := d.discoverApp(ctx, app)
+		if err != nil {
+			return DiscoveryResult{}, errors.Wrapf(err, "discovering app %q", app.Name)
+		}
+		result.add(item, miss)
+	}
+
+	if d.usesDigitalOcean() {
+		item, miss, err := d.discoverProject(ctx)
+		if err != nil {
+			return DiscoveryResult{}, errors.Wrap(err, "discovering project")
+		}
+		result.add(item, miss)
+	}
+
+	return result, nil
+}
+
+func (r *DiscoveryResult) add(item *DiscoveredItem, miss *DiscoveryMiss) {
+	if item != nil {
+		r.Found = append(r.Found, *item)
+	}
+	if miss != nil {
+		r.Missing = append(r.Missing, *miss)
+	}
+}
+
+// usesDigitalOcean reports whether any resource or app is provisioned
+// on DigitalOcean, in which case the Terraform modules create a project.
+func (d *Discoverer) usesDigitalOcean() bool {
+	for _, res := range d.appDef.Resources {
+		if res.Provider == appdef.ResourceProviderDigitalOcean {
+			return true
+		}
+	}
+	for _, app := range d.appDef.Apps {
+		if app.Infra.Provider == appdef.ResourceProviderDigitalOcean {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteName returns the name the Terraform modules give to an
+// item within the provider: ${project_name}-${name}.
+func (d *Discoverer) remoteName(name string) string {
+	return fmt.Sprintf("%s-%s", d.appDef.Project.Name, name)
+}
+
+func (d *Discoverer) discoverResource(ctx context.Context, res *appdef.Resource) (*DiscoveredItem, *DiscoveryMiss, error) {
+	item := DiscoveredItem{
+		Kind:       ImportKindResource,
+		Name:       res.Name,
+		Provider:   res.Provider,
+		RemoteName: d.remoteName(res.Name),
+	}
+
+	var (
+		found bool
+		err   error
+	)
+
+	switch {
+	case res.Provider == appdef.ResourceProviderDigitalOcean && res.Type == appdef.ResourceTypePostgres:
+		item.ID, found, err = d.findDigitalOceanDatabase(ctx, item.RemoteName)
+	case res.Provider == appdef.ResourceProviderDigitalOcean && res.Type == appdef.ResourceTypeS3:
+		region, ok := res.Config.String("region")
+		if !ok {
+			region = "ams3"
+		}
+		var cdnID string
+		cdnID, found, err = d.findDigitalOceanCDN(ctx, fmt.Sprintf("%s.%s.digitaloceanspaces.com", item.RemoteName, region))
+		item.ID = item.RemoteName
+		item.Options = map[string]string{"cdn_id": cdnID}
+	case res.Provider == appdef.ResourceProviderBackBlaze && res.Type == appdef.ResourceTypeS3:
+		// The B2 module names buckets after the resource, without the project prefix.
+		item.RemoteName = res.Name
+		item.ID, found, err = d.findBackBlazeBucket(ctx, item.RemoteName)
+	case res.Provider == appdef.ResourceProviderTurso && res.Type == appdef.ResourceTypeSQLite:
+		org, ok := res.Config.String("organisation")
+		if !ok || org == "" {
+			return nil, d.miss(item, "config.organisation is required to discover Turso databases"), nil
+		}
+		found, err = d.findTursoDatabase(ctx, org, item.RemoteName)
+		item.ID = org + "/" + item.RemoteName
+	case res.Provider == appdef.ResourceProviderCloudflare && res.Type == appdef.ResourceTypeS3:
+		account, ok := res.Config.String("account_id")
+		if !ok || account == "" {
+			account = d.env.CloudflareAccountID
+		}
+		if account == "" {
+			return nil, d.miss(item, "CLOUDFLARE_ACCOUNT_ID is required to discover R2 buckets"), nil
+		}
+		found, err = d.findCloudflareR2Bucket(ctx, account, item.RemoteName)
+		item.ID = account + "/" + item.RemoteName + "/default"
+	default:
+		return nil, d.miss(item, fmt.Sprintf("discovery not supported for %s %s", res.Provider, res.Type)), nil
+	}
+
+	return d.result(item, found, err)
+}
+
+func (d *Discoverer) discoverApp(ctx context.Context, app *appdef.App) (*DiscoveredItem, *DiscoveryMiss, error) {
+	item := DiscoveredItem{
+		Kind:       ImportKindApp,
+		Name:       app.Name,
+		Provider:   app.Infra.Provider,
+		RemoteName: d.remoteName(app.Name),
+	}
+
+	var (
+		found bool
+		err   error
+	)
+
+	switch {
+	case app.Infra.Provider == appdef.ResourceProviderDigitalOcean && app.Infra.Type == "container":
+		item.ID, found, err = d.findDigitalOceanApp(ctx, item.RemoteName)
+	case app.Infra.Provider == appdef.ResourceProviderDigitalOcean && app.Infra.Type == "vm":
+		item.ID, found, err = d.findDigitalOceanDroplet(ctx, item.RemoteName)
+	case app.Infra.Provider == appdef.ResourceProviderHetzner && app.Infra.Type == "vm":
+		var server hetznerServer
+		server, found, err = d.findHetznerServer(ctx, item.RemoteName)
+		if found && err == nil {
+			item.ID = strconv.FormatInt(server.ID, 10)
+			item.Options, err = d.hetznerServerOptions(ctx, app, item.RemoteName, server)
+		}
+	default:
+		return nil, d.miss(item, fmt.Sprintf("discovery not supported for %s %s", app.Infra.Provider, app.Infra.Type)), nil
+	}
+
+	return d.result(item, found, err)
+}
+
+func (d *Discoverer) discoverProject(ctx context.Context) (*DiscoveredItem, *DiscoveryMiss, error) {
+	// DigitalOcean projects are named after the project title, see base/project.tf.
+	item := DiscoveredItem{
+		Kind:       ImportKindProject,
+		Provider:   appdef.ResourceProviderDigitalOcean,
+		RemoteName: d.appDef.Project.Title,
+	}
+
+	id, found, err := d.findDigitalOceanProject(ctx, item.RemoteName)
+	item.ID = id
+
+	return d.result(item, found, err)
+}
+
+func (d *Discoverer) result(item DiscoveredItem, found bool, err error) (*DiscoveredItem, *DiscoveryMiss, error) {
+	if err != nil {
+		return nil, nil, err
+	}
+	if !found {
+		return nil, d.miss(item, "not found"), nil
+	}
+	return &item, nil, nil
+}
+
+func (d *Discoverer) miss(item DiscoveredItem, reason string) *DiscoveryMiss {
+	return &DiscoveryMiss{
+		Kind:       item.Kind,
+		Name:       item.Name,
+		Provider:   item.Provider,
+		RemoteName: item.RemoteName,
+		Reason:     reason,
+	}
+}
+
+/************************************
+	DigitalOcean
+************************************/
+
+func (d *Discoverer) digitalOceanGet(ctx context.Context, path string, out any) (bool, error) {
+	return d.doJSON(ctx, http.MethodGet, d.endpoints.DigitalOcean+path, bearer(d.env.DigitalOceanAPIKey), nil, out)
+}
+
+func (d *Discoverer) findDigitalOceanDatabase(ctx context.Context, name string) (string, bool, error) {
+	var resp struct {
+		Databases []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"databases"`
+	}
+	if _, err := d.digitalOceanGet(ctx, "/v2/databases", &resp); err != nil {
+		return "", false, err
+	}
+	for _, db := range resp.Databases {
+		if db.Name == name {
+			return db.ID, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+func (d *Discoverer) findDigitalOceanCDN(ctx context.Context, origin string) (string, bool, error) {
+	var resp struct {
+		Endpoints []struct {
+			ID     string `json:"id"`
+			Origin string `json:"origin"`
+		} `json:"endpoints"`
+	}
+	if _, err := d.digitalOceanGet(ctx, "/v2/cdn/endpoints?per_page=200", &resp); err != nil {
+		return "", false, err
+	}
+	for _, e := range resp.Endpoints {
+		if e.Origin == origin {
+			return e.ID, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+func (d *Discoverer) findDigitalOceanApp(ctx context.Context, name string) (string, bool, error) {
+	var resp struct {
+		Apps []struct {
+			ID   string `json:"id"`
+			Spec struct {
+				Name string `json:"name"`
+			} `json:"spec"`
+		} `json:"apps"`
+	}
+	if _, err := d.digitalOceanGet(ctx, "/v2/apps?per_page=200", &resp); err != nil {
+		return "", false, err
+	}
+	for _, app := range resp.Apps {
+		if app.Spec.Name == name {
+			return app.ID, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+func (d *Discoverer) findDigitalOceanDroplet(ctx context.Context, name string) (string, bool, error) {
+	var resp struct {
+		Droplets []struct {
+			ID   int64  `json:"id"`
+			Name string `json:"name"`
+		} `json:"droplets"`
+	}
+	if _, err := d.digitalOceanGet(ctx, "/v2/droplets?name="+url.QueryEscape(name), &resp); err != nil {
+		return "", false, err
+	}
+	for _, droplet := range resp.Droplets {
+		if droplet.Name == name {
+			return strconv.FormatInt(droplet.ID, 10), true, nil
+		}
+	}
+	return "", false, nil
+}
+
+func (d *Discoverer) findDigitalOceanProject(ctx context.Context, name string) (string, bool, error) {
+	var resp struct {
+		Projects []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"projects"`
+	}
+	if _, err := d.digitalOceanGet(ctx, "/v2/projects?per_page=200", &resp); err != nil {
+		return "", false, err
+	}
+	for _, p := range resp.Projects {
+		if p.Name == name {
+			return p.ID, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+/************************************
+	Hetzner
+************************************/
+
+// hetznerServer is a server returned by the Hetzner Cloud API.
+type hetznerServer struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	PublicNet struct {
+		IPv4 struct {
+			IP string `json:"ip"`
+		} `json:"ipv4"`
+		IPv6 struct {
+			IP string `json:"ip"`
+		} `json:"ipv6"`
+	} `json:"public_net"`
+}
+
+func (d *Discoverer) hetznerGet(ctx context.Context, path string, out any) (bool, error) {
+	return d.doJSON(ctx, http.MethodGet, d.endpoints.Hetzner+path, bearer(d.env.HetznerToken), nil, out)
+}
+
+func (d *Discoverer) findHetznerServer(ctx context.Context, name string) (hetznerServer, bool, error) {
+	var resp struct {
+		Servers []hetznerServer `json:"servers"`
+	}
+	if _, err := d.hetznerGet(ctx, "/v1/servers?name="+url.QueryEscape(name), &resp); err != nil {
+		return hetznerServer{}, false, err
+	}
+	for _, s := range resp.Servers {
+		if s.Name == name {
+			return s, true, nil
+		}
+	}
+	return hetznerServer{}, false, nil
+}
+
+// hetznerServerOptions looks up the resources that the server module
+// creates alongside the server, returning their import IDs keyed as
+// buildHetznerAppImports expects. Resources that don't exist, or that
+// the app's config doesn't create, are left out.
+func (d *Discoverer) hetznerServerOptions(ctx context.Context, app *appdef.App, name string, server hetznerServer) (map[string]string, error) {
+	opts := map[string]string{}
+
+	id, found, err := d.findHetznerNamed(ctx, "ssh_keys", name+"-key")
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		opts["ssh_key_id"] = id
+	}
+
+	if len(app.Network.Inbound) == 0 {
+		id, found, err = d.findHetznerNamed(ctx, "firewalls", name+"-firewall")
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			opts["firewall_id"] = id
+		}
+	}
+
+	if cast.ToInt(app.Infra.Config["volume_size"]) > 0 {
+		id, found, err = d.findHetznerNamed(ctx, "volumes", name+"-data")
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			opts["volume_id"] = id
+		}
+	}
+
+	if hasPrimaryDomain(app) {
+		if ip := server.PublicNet.IPv4.IP; ip != "" {
+			opts["ipv4_address"] = ip
+		}
+		// The server's IPv6 address is the first address of its
+		// network, e.g. 2001:db8::/64 -> 2001:db8::1.
+		if prefix, err := netip.ParsePrefix(server.PublicNet.IPv6.IP); err == nil {
+			opts["ipv6_address"] = prefix.Masked().Addr().Next().String()
+		}
+	}
+
+	for _, record := range appDNSRecords(app, []string{"A", "AAAA"}) {
+		id, found, err = d.findDNSRecord(ctx, record)
+		if err != nil {
+			return nil, errors.Wrapf(err, "looking up DNS record %s", dnsRecordKey(record.FQDN, record.Type))
+		}
+		if found {
+			opts[dnsRecordOption(record.FQDN, record.Type)] = id
+		}
+	}
+
+	return opts, nil
+}
+
+// findHetznerNamed looks up a Hetzner Cloud resource of the given kind,
+// such as "ssh_keys" or "firewalls", by its name.
+func (d *Discoverer) findHetznerNamed(ctx context.Context, kind, name string) (string, bool, error) {
+	var resp map[string][]struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	if _, err := d.hetznerGet(ctx, "/v1/"+kind+"?name="+url.QueryEscape(name), &resp); err != nil {
+		return "", false, err
+	}
+	for _, r := range resp[kind] {
+		if r.Name == name {
+			return strconv.FormatInt(r.ID, 10), true, nil
+		}
+	}
+	return "", false, nil
+}
+
+/************************************
+	DNS
+************************************/
+
+// findDNSRecord looks up a record that the apps module creates in the
+// provider hosting its zone and returns its import ID.
+func (d *Discoverer) findDNSRecord(ctx context.Context, record appDNSRecord) (string, bool, error) {
+	switch record.Provider {
+	case appdef.DNSProviderHetzner:
+		return d.findHetznerDNSRecord(ctx, record)
+	case appdef.DNSProviderDigitalOcean:
+		return d.findDigitalOceanDNSRecord(ctx, record)
+	case appdef.DNSProviderCloudflare:
+		return d.findCloudflareDNSRecord(ctx, record)
+	default:
+		return "", false, nil
+	}
+}
+
+// relativeRecordName returns the name of the record within its
+// zone, with @ for the apex.
+func relativeRecordName(record appDNSRecord) string {
+	if record.FQDN == record.Zone {
+		return "@"
+	}
+	return strings.TrimSuffix(record.FQDN, "."+record.Zone)
+}
+
+func (d *Discoverer) findHetznerDNSRecord(ctx context.Context, record appDNSRecord) (string, bool, error) {
+	// Hetzner DNS uses a separate token, without it the zone can't
+	// be read and the record is left to be created.
+	if d.env.HetznerDNSToken == "" {
+		return "", false, nil
+	}
+
+	get := func(path string, out any) (bool, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.endpoints.HetznerDNS+path, nil)
+		if err != nil {
+			return false, errors.Wrap(err, "creating request")
+		}
+		req.Header.Set("Auth-API-Token", d.env.HetznerDNSToken)
+		return d.send(req, out)
+	}
+
+	var zones struct {
+		Zones []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"zones"`
+	}
+	if _, err := get("/api/v1/zones?name="+url.QueryEscape(record.Zone), &zones); err != nil {
+		return "", false, err
+	}
+
+	for _, zone := range zones.Zones {
+		if zone.Name != record.Zone {
+			continue
+		}
+		var records struct {
+			Records []struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+				Type string `json:"type"`
+			} `json:"records"`
+		}
+		if _, err := get("/api/v1/records?zone_id="+url.QueryEscape(zone.ID), &records); err != nil {
+			return "", false, err
+		}
+		for _, r := range records.Records {
+			if r.Name == relativeRecordName(record) && r.Type == record.Type {
+				return r.ID, true, nil
+			}
+		}
+	}
+	return "", false, nil
+}
+
+func (d *Discoverer) findDigitalOceanDNSRecord(ctx context.Context, record appDNSRecord) (string, bool, error) {
+	var resp struct {
+		Records []struct {
+			ID   int64  `json:"id"`
+			Name string `json:"name"`
+			Type string `json:"type"`
+		} `json:"domain_records"`
+	}
+	path := fmt.Sprintf("/v2/domains/%s/records?type=%s&name=%s",
+		url.PathEscape(record.Zone), url.QueryEscape(record.Type), url.QueryEscape(record.FQDN))
+	if _, err := d.digitalOceanGet(ctx, path, &resp); err != nil {
+		return "", false, err
+	}
+	for _, r := range resp.Records {
+		if r.Name == relativeRecordName(record) && r.Type == record.Type {
+			return fmt.Sprintf("%s,%d", record.Zone, r.ID), true, nil
+		}
+	}
+	return "", false, nil
+}
+
+func (d *Discoverer) findCloudflareDNSRecord(ctx context.Context, record appDNSRecord) (string, bool, error) {
+	type result struct {
+		Result []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			Type string `json:"type"`
+		} `json:"result"`
+	}
+
+	var zones result
+	endpoint := d.endpoints.Cloudflare + "/client/v4/zones?name=" + url.QueryEscape(record.Zone)
+	if _, err := d.doJSON(ctx, http.MethodGet, endpoint, bearer(d.env.CloudflareAPIToken), nil, &zones); err != nil {
+		return "", false, err
+	}
+
+	for _, zone := range zones.Result {
+		if zone.Name != record.Zone {
+			continue
+		}
+		var records result
+		endpoint = fmt.Sprintf("%s/client/v4/zones/%s/dns_records?type=%s&name=%s", d.endpoints.Cloudflare,
+			url.PathEscape(zone.ID), url.QueryEscape(record.Type), url.QueryEscape(record.FQDN))
+		if _, err := d.doJSON(ctx, http.MethodGet, endpoint, bearer(d.env.CloudflareAPIToken), nil, &records); err != nil {
+			return "", false, err
+		}
+		for _, r := range records.Result {
+			if r.Name == record.FQDN && r.Type == record.Type {
+				return zone.ID + "/" + r.ID, true, nil
+			}
+		}
+	}
+	return "", false, nil
+}
+
+/************************************
+	BackBlaze
+************************************/
+
+func (d *Discoverer) findBackBlazeBucket(ctx context.Context, name string) (string, bool, error) {
+	var auth struct {
+		AccountID          string `json:"accountId"`
+		APIURL             string `json:"apiUrl"`
+		AuthorizationToken string `json:"authorizationToken"`
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.endpoints.BackBlaze+"/b2api/v2/b2_authorize_account", nil)
+	if err != nil {
+		return "", false, errors.Wrap(err, "creating request")
+	}
+	req.SetBasicAuth(d.env.BackBlazeKeyID, d.env.BackBlazeApplicationKey)
+	if _, err = d.send(req, &auth); err != nil {
+		return "", false, errors.Wrap(err, "authorising b2 account")
+	}
+
+	body := map[string]string{
+		"accountId":  auth.AccountID,
+		"bucketName": name,
+	}
+	var resp struct {
+		Buckets []struct {
+			BucketID   string `json:"bucketId"`
+			BucketName string `json:"bucketName"`
+		} `json:"buckets"`
+	}
+	if _, err = d.doJSON(ctx, http.MethodPost, auth.APIURL+"/b2api/v2/b2_list_buckets", auth.AuthorizationToken, body, &resp); err != nil {
+		return "", false, err
+	}
+	for _, b := range resp.Buckets {
+		if b.BucketName == name {
+			return b.BucketID, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+/************************************
+	Turso
+************************************/
+
+func (d *Discoverer) findTursoDatabase(ctx context.Context, org, name string) (bool, error) {
+	endpoint := fmt.Sprintf("%s/v1/organizations/%s/databases/%s", d.endpoints.Turso, url.PathEscape(org), url.PathEscape(name))
+	return d.doJSON(ctx, http.MethodGet, endpoint, bearer(d.env.TursoToken), nil, nil)
+}
+
+/************************************
+	Cloudflare
+************************************/
+
+func (d *Discoverer) findCloudflareR2Bucket(ctx context.Context, account, name string) (bool, error) {
+	endpoint := fmt.Sprintf("%s/client/v4/accounts/%s/r2/buckets/%s", d.endpoints.Cloudflare, url.PathEscape(account), url.PathEscape(name))
+	return d.doJSON(ctx, http.MethodGet, endpoint, bearer(d.env.CloudflareAPIToken), nil, nil)
+}
+
+/************************************
+	HTTP
+************************************/
+
+func bearer(token string) string {
+	return "Bearer " + token
+}
+
+// doJSON performs an HTTP request against a provider API and decodes
+// the JSON response into out (if non-nil). A 404 response is not
+// treated as an error, instead false is returned.
+func (d *Discoverer) doJSON(ctx context.Context, method, endpoint, authorization string, body, out any) (bool, error) {
+	var reader io.Reader
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return false, errors.Wrap(err, "marshalling request body")
+		}
+		reader = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return false, errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Authorization", authorization)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return d.send(req, out)
+}
+
+func (d *Discoverer) send(req *http.Request, out any) (bool, error) {
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return false, errors.Wrapf(err, "requesting %s", req.URL.Path)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return false, errors.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, req.URL.Path, bytes.TrimSpace(msg))
+	}
+
+	if out == nil {
+		return true, nil
+	}
+	if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return false, errors.Wrap(err, "decoding response")
+	}
+
+	return true, nil
+}
@@ -0,0 +1,349 @@
+package infra
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+func discoveryStandIn(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	writeJSON := func(w http.ResponseWriter, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v)
+	}
+
+	// DigitalOcean
+	mux.HandleFunc("GET /v2/databases", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer do-token", r.Header.Get("Authorization"))
+		writeJSON(w, map[string]any{"databases": []map[string]any{
+			{"id": "other-cluster", "name": "other-db"},
+			{"id": "cluster-123", "name": "my-project-db"},
+		}})
+	})
+	mux.HandleFunc("GET /v2/cdn/endpoints", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{"endpoints": []map[string]any{
+			{"id": "cdn-123", "origin": "my-project-media.ams3.digitaloceanspaces.com"},
+		}})
+	})
+	mux.HandleFunc("GET /v2/apps", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{"apps": []map[string]any{
+			{"id": "app-123", "spec": map[string]any{"name": "my-project-web"}},
+		}})
+	})
+	mux.HandleFunc("GET /v2/droplets", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("name") != "my-project-api" {
+			writeJSON(w, map[string]any{"droplets": []any{}})
+			return
+		}
+		writeJSON(w, map[string]any{"droplets": []map[string]any{
+			{"id": 98765, "name": "my-project-api"},
+		}})
+	})
+	mux.HandleFunc("GET /v2/projects", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{"projects": []map[string]any{
+			{"id": "project-123", "name": "My Project"},
+		}})
+	})
+
+	// Hetzner
+	mux.HandleFunc("GET /v1/servers", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer hetzner-token", r.Header.Get("Authorization"))
+		if r.URL.Query().Get("name") != "my-project-cms" {
+			writeJSON(w, map[string]any{"servers": []any{}})
+			return
+		}
+		writeJSON(w, map[string]any{"servers": []map[string]any{
+			{"id": 4242, "name": "my-project-cms", "public_net": map[string]any{
+				"ipv4": map[string]any{"ip": "203.0.113.10"},
+				"ipv6": map[string]any{"ip": "2001:db8:1::/64"},
+			}},
+		}})
+	})
+	hetznerNamed := func(kind, name string, id int) {
+		mux.HandleFunc("GET /v1/"+kind, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer hetzner-token", r.Header.Get("Authorization"))
+			if r.URL.Query().Get("name") != name {
+				writeJSON(w, map[string]any{kind: []any{}})
+				return
+			}
+			writeJSON(w, map[string]any{kind: []map[string]any{{"id": id, "name": name}}})
+		})
+	}
+	hetznerNamed("ssh_keys", "my-project-cms-key", 11)
+	hetznerNamed("firewalls", "my-project-cms-firewall", 22)
+	hetznerNamed("volumes", "my-project-cms-data", 33)
+
+	// DNS
+	mux.HandleFunc("GET /api/v1/zones", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "hetzner-dns-token", r.Header.Get("Auth-API-Token"))
+		assert.Equal(t, "my-project.com", r.URL.Query().Get("name"))
+		writeJSON(w, map[string]any{"zones": []map[string]any{{"id": "zone-1", "name": "my-project.com"}}})
+	})
+	mux.HandleFunc("GET /api/v1/records", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "zone-1", r.URL.Query().Get("zone_id"))
+		writeJSON(w, map[string]any{"records": []map[string]any{
+			{"id": "rec-a", "name": "cms", "type": "A"},
+			{"id": "rec-aaaa", "name": "cms", "type": "AAAA"},
+			{"id": "rec-www", "name": "www", "type": "A"},
+		}})
+	})
+	mux.HandleFunc("GET /v2/domains/my-project.io/records", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "my-project.io", r.URL.Query().Get("name"))
+		if r.URL.Query().Get("type") != "A" {
+			writeJSON(w, map[string]any{"domain_records": []any{}})
+			return
+		}
+		writeJSON(w, map[string]any{"domain_records": []map[string]any{{"id": 44, "name": "@", "type": "A"}}})
+	})
+	mux.HandleFunc("GET /client/v4/zones", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer cf-token", r.Header.Get("Authorization"))
+		writeJSON(w, map[string]any{"result": []map[string]any{{"id": "cf-zone", "name": r.URL.Query().Get("name")}}})
+	})
+	mux.HandleFunc("GET /client/v4/zones/cf-zone/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("type") != "A" {
+			writeJSON(w, map[string]any{"result": []any{}})
+			return
+		}
+		writeJSON(w, map[string]any{"result": []map[string]any{{"id": "cf-a", "name": r.URL.Query().Get("name"), "type": "A"}}})
+	})
+
+	// BackBlaze
+	var srvURL string
+	mux.HandleFunc("GET /b2api/v2/b2_authorize_account", func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "b2-key-id", user)
+		assert.Equal(t, "b2-app-key", pass)
+		writeJSON(w, map[string]any{
+			"accountId":          "account-123",
+			"apiUrl":             srvURL,
+			"authorizationToken": "b2-auth-token",
+		})
+	})
+	mux.HandleFunc("POST /b2api/v2/b2_list_buckets", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "b2-auth-token", r.Header.Get("Authorization"))
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "account-123", body["accountId"])
+		if body["bucketName"] != "backups" {
+			writeJSON(w, map[string]any{"buckets": []any{}})
+			return
+		}
+		writeJSON(w, map[string]any{"buckets": []map[string]any{
+			{"bucketId": "bucket-123", "bucketName": "backups"},
+		}})
+	})
+
+	// Turso
+	mux.HandleFunc("GET /v1/organizations/my-org/databases/my-project-cache", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{"database": map[string]any{"Name": "my-project-cache"}})
+	})
+
+	// Cloudflare
+	mux.HandleFunc("GET /client/v4/accounts/cf-account/r2/buckets/my-project-assets", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer cf-token", r.Header.Get("Authorization"))
+		writeJSON(w, map[string]any{"success": true, "result": map[string]any{"name": "my-project-assets"}})
+	})
+
+	srv := httptest.NewServer(mux)
+	srvURL = srv.URL
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestDiscoverer_Discover(t *testing.T) {
+	t.Parallel()
+
+	srv := discoveryStandIn(t)
+	endpoints := DiscoveryEndpoints{
+		DigitalOcean: srv.URL,
+		Hetzner:      srv.URL,
+		HetznerDNS:   srv.URL,
+		BackBlaze:    srv.URL,
+		Turso:        srv.URL,
+		Cloudflare:   srv.URL,
+	}
+	tfEnv := TFEnvironment{
+		DigitalOceanAPIKey:      "do-token",
+		HetznerToken:            "hetzner-token",
+		HetznerDNSToken:         "hetzner-dns-token",
+		BackBlazeKeyID:          "b2-key-id",
+		BackBlazeApplicationKey: "b2-app-key",
+		TursoToken:              "turso-token",
+		CloudflareAPIToken:      "cf-token",
+		CloudflareAccountID:     "cf-account",
+	}
+
+	t.Run("Found", func(t *testing.T) {
+		t.Parallel()
+
+		def := &appdef.Definition{
+			Project: appdef.Project{Name: "my-project", Title: "My Project"},
+			Resources: []appdef.Resource{
+				{Name: "db", Type: appdef.ResourceTypePostgres, Provider: appdef.ResourceProviderDigitalOcean},
+				{Name: "media", Type: appdef.ResourceTypeS3, Provider: appdef.ResourceProviderDigitalOcean},
+				{Name: "backups", Type: appdef.ResourceTypeS3, Provider: appdef.ResourceProviderBackBlaze},
+				{
+					Name:     "cache",
+					Type:     appdef.ResourceTypeSQLite,
+					Provider: appdef.ResourceProviderTurso,
+					Config:   map[string]any{"organisation": "my-org"},
+				},
+				{Name: "assets", Type: appdef.ResourceTypeS3, Provider: appdef.ResourceProviderCloudflare},
+			},
+			Apps: []appdef.App{
+				{Name: "web", Infra: appdef.Infra{Provider: appdef.ResourceProviderDigitalOcean, Type: "container"}},
+				{Name: "api", Infra: appdef.Infra{Provider: appdef.ResourceProviderDigitalOcean, Type: "vm"}},
+				{
+					Name: "cms",
+					Infra: appdef.Infra{
+						Provider: appdef.ResourceProviderHetzner,
+						Type:     "vm",
+						Config:   map[string]any{"volume_size": float64(20)},
+					},
+					Domains: []appdef.Domain{
+						{Name: "cms.my-project.com", Type: appdef.DomainTypePrimary},
+						{Name: "cms.example.org", Type: appdef.DomainTypeAlias, DNS: appdef.DNSProviderCloudflare},
+						{Name: "my-project.io", Type: appdef.DomainTypeAlias, DNS: appdef.DNSProviderDigitalOcean},
+					},
+				},
+			},
+		}
+
+		got, err := NewDiscoverer(def, tfEnv).WithEndpoints(endpoints).Discover(t.Context())
+		require.NoError(t, err)
+
+		assert.Empty(t, got.Missing)
+		assert.Equal(t, []DiscoveredItem{
+			{Kind: ImportKindResource, Name: "db", Provider: appdef.ResourceProviderDigitalOcean, RemoteName: "my-project-db", ID: "cluster-123"},
+			{Kind: ImportKindResource, Name: "media", Provider: appdef.ResourceProviderDigitalOcean, RemoteName: "my-project-media", ID: "my-project-media", Options: map[string]string{"cdn_id": "cdn-123"}},
+			{Kind: ImportKindResource, Name: "backups", Provider: appdef.ResourceProviderBackBlaze, RemoteName: "backups", ID: "bucket-123"},
+			{Kind: ImportKindResource, Name: "cache", Provider: appdef.ResourceProviderTurso, RemoteName: "my-project-cache", ID: "my-org/my-project-cache"},
+			{Kind: ImportKindResource, Name: "assets", Provider: appdef.ResourceProviderCloudflare, RemoteName: "my-project-assets", ID: "cf-account/my-project-assets/default"},
+			{Kind: ImportKindApp, Name: "web", Provider: appdef.ResourceProviderDigitalOcean, RemoteName: "my-project-web", ID: "app-123"},
+			{Kind: ImportKindApp, Name: "api", Provider: appdef.ResourceProviderDigitalOcean, RemoteName: "my-project-api", ID: "98765"},
+			{Kind: ImportKindApp, Name: "cms", Provider: appdef.ResourceProviderHetzner, RemoteName: "my-project-cms", ID: "4242", Options: map[string]string{
+				"ssh_key_id":                         "11",
+				"firewall_id":                        "22",
+				"volume_id":                          "33",
+				"ipv4_address":                       "203.0.113.10",
+				"ipv6_address":                       "2001:db8:1::1",
+				"dns_record:cms.my-project.com/A":    "rec-a",
+				"dns_record:cms.my-project.com/AAAA": "rec-aaaa",
+				"dns_record:cms.example.org/A":       "cf-zone/cf-a",
+				"dns_record:my-project.io/A":         "my-project.io,44",
+			}},
+			{Kind: ImportKindProject, Provider: appdef.ResourceProviderDigitalOcean, RemoteName: "My Project", ID: "project-123"},
+		}, got.Found)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		t.Parallel()
+
+		def := &appdef.Definition{
+			Project: appdef.Project{Name: "missing", Title: "Missing"},
+			Resources: []appdef.Resource{
+				{Name: "db", Type: appdef.ResourceTypePostgres, Provider: appdef.ResourceProviderDigitalOcean},
+				{Name: "cache", Type: appdef.ResourceTypeSQLite, Provider: appdef.ResourceProviderTurso},
+			},
+			Apps: []appdef.App{
+				{Name: "cms", Infra: appdef.Infra{Provider: appdef.ResourceProviderHetzner, Type: "vm"}},
+			},
+		}
+
+		got, err := NewDiscoverer(def, tfEnv).WithEndpoints(endpoints).Discover(t.Context())
+		require.NoError(t, err)
+
+		assert.Empty(t, got.Found)
+		require.Len(t, got.Missing, 4)
+		assert.Equal(t, "missing-db", got.Missing[0].RemoteName)
+		assert.Equal(t, "not found", got.Missing[0].Reason)
+		assert.Contains(t, got.Missing[1].Reason, "organisation")
+		assert.Equal(t, "missing-cms", got.Missing[2].RemoteName)
+		assert.Equal(t, ImportKindProject, got.Missing[3].Kind)
+	})
+
+	t.Run("API Error", func(t *testing.T) {
+		t.Parallel()
+
+		errSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "unauthorised", http.StatusUnauthorized)
+		}))
+		defer errSrv.Close()
+
+		def := &appdef.Definition{
+			Project: appdef.Project{Name: "my-project"},
+			Apps: []appdef.App{
+				{Name: "cms", Infra: appdef.Infra{Provider: appdef.ResourceProviderHetzner, Type: "vm"}},
+			},
+		}
+
+		_, err := NewDiscoverer(def, tfEnv).
+			WithEndpoints(DiscoveryEndpoints{Hetzner: errSrv.URL}).
+			Discover(t.Context())
+		assert.ErrorContains(t, err, "unexpected status code 401")
+	})
+}
+
+func TestDiscoveredItem_Input(t *testing.T) {
+	t.Parallel()
+
+	item := DiscoveredItem{
+		Kind:    ImportKindResource,
+		Name:    "media",
+		ID:      "my-project-media",
+		Options: map[string]string{"cdn_id": "cdn-123"},
+	}
+
+	assert.Equal(t, ImportInput{
+		Kind:        ImportKindResource,
+		Name:        "media",
+		ID:          "my-project-media",
+		Environment: env.Production,
+		Options:     map[string]string{"cdn_id": "cdn-123"},
+	}, item.Input(env.Production))
+}
+
+func TestImportKind_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "resource", ImportKindResource.String())
+	assert.Equal(t, "app", ImportKindApp.String())
+	assert.Equal(t, "project", ImportKindProject.String())
+	assert.Equal(t, "unknown", ImportKind(99).String())
+}
+
+func TestWithImportOptions(t *testing.T) {
+	t.Parallel()
+
+	res := &appdef.Resource{Name: "media", Config: map[string]any{"region": "fra1"}}
+
+	assert.Same(t, res, withImportOptions(res, nil))
+
+	got := withImportOptions(res, map[string]string{"cdn_id": "cdn-123"})
+	assert.Equal(t, map[string]any{"region": "fra1", "cdn_id": "cdn-123"}, map[string]any(got.Config))
+	assert.NotContains(t, res.Config, "cdn_id")
+}
+
+func TestWithAppImportOptions(t *testing.T) {
+	t.Parallel()
+
+	app := &appdef.App{Name: "cms", Infra: appdef.Infra{Config: map[string]any{"size": "cx22"}}}
+
+	assert.Same(t, app, withAppImportOptions(app, nil))
+
+	got := withAppImportOptions(app, map[string]string{"firewall_id": "22", "volume_id": ""})
+	assert.Equal(t, map[string]any{"size": "cx22", "firewall_id": "22"}, map[string]any(got.Infra.Config))
+	assert.NotContains(t, app.Infra.Config, "firewall_id")
+}
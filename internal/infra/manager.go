@@ -17,6 +17,8 @@ type (
 		Destroy(ctx context.Context, env env.Environment) (DestroyOutput, error)
 		Output(ctx context.Context, env env.Environment) (OutputResult, error)
 		Import(ctx context.Context, input ImportInput) (ImportOutput, error)
+		Cost(ctx context.Context, env env.Environment) (CostReport, error)
+		Drift(ctx context.Context, env env.Environment) (DriftReport, error)
 		Cleanup()
 		WorkDir() string
 	}
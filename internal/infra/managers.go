@@ -0,0 +1,52 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/state/manifest"
+	"github.com/ainsleydev/webkit/pkg/enforce"
+)
+
+// ManagerFactory creates a Manager for the given app definition.
+type ManagerFactory func(ctx context.Context, appDef *appdef.Definition, manifest *manifest.Tracker, opts ...Option) (Manager, error)
+
+// managers is a registry of the infrastructure backends that can
+// be selected with infra.backend in app.json.
+var managers = map[appdef.InfraBackend]ManagerFactory{
+	appdef.InfraBackendTerraform: func(ctx context.Context, appDef *appdef.Definition, manifest *manifest.Tracker, opts ...Option) (Manager, error) {
+		tf, err := NewTerraform(ctx, appDef, manifest, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return tf, nil
+	},
+	appdef.InfraBackendOpenTofu: func(ctx context.Context, appDef *appdef.Definition, manifest *manifest.Tracker, opts ...Option) (Manager, error) {
+		tf, err := NewOpenTofu(ctx, appDef, manifest, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return tf, nil
+	},
+}
+
+// NewManager creates the Manager for the backend selected in
+// app.json, falling back to Terraform when none is set.
+//
+// Returns an error if the backend is not supported.
+func NewManager(ctx context.Context, appDef *appdef.Definition, manifest *manifest.Tracker, opts ...Option) (Manager, error) {
+	enforce.NotNil(appDef, "app definition is required")
+
+	backend := appDef.Infra.Backend
+	if backend == "" {
+		backend = appdef.InfraBackendTerraform
+	}
+
+	factory, ok := managers[backend]
+	if !ok {
+		return nil, fmt.Errorf("unsupported infra backend %q", backend)
+	}
+
+	return factory(ctx, appDef, manifest, opts...)
+}
@@ -0,0 +1,65 @@
+package infra
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/state/manifest"
+)
+
+func TestNewManager(t *testing.T) {
+	t.Run("Unsupported backend", func(t *testing.T) {
+		def := &appdef.Definition{Infra: appdef.ProjectInfra{Backend: "pulumi"}}
+
+		got, err := NewManager(t.Context(), def, manifest.NewTracker())
+		assert.Nil(t, got)
+		assert.ErrorContains(t, err, `unsupported infra backend "pulumi"`)
+	})
+
+	tt := map[string]struct {
+		backend appdef.InfraBackend
+		want    string
+	}{
+		"Defaults to Terraform": {backend: "", want: "locating terraform binary"},
+		"Terraform":             {backend: appdef.InfraBackendTerraform, want: "locating terraform binary"},
+		"OpenTofu":              {backend: appdef.InfraBackendOpenTofu, want: "locating tofu binary"},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("PATH", "/nonexistent")
+
+			def := &appdef.Definition{Infra: appdef.ProjectInfra{Backend: test.backend}}
+
+			got, err := NewManager(t.Context(), def, manifest.NewTracker())
+			assert.Nil(t, got)
+			assert.ErrorContains(t, err, test.want)
+		})
+	}
+}
+
+func TestTerraform_WriteOpenTofuOverride(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		tf := &Terraform{fs: afero.NewMemMapFs()}
+		require.NoError(t, tf.writeOpenTofuOverride("/base"))
+
+		got, err := afero.ReadFile(tf.fs, "/base/opentofu_override.tf")
+		require.NoError(t, err)
+		assert.Contains(t, string(got), "required_version")
+	})
+
+	t.Run("Write error", func(t *testing.T) {
+		t.Parallel()
+
+		tf := &Terraform{fs: afero.NewReadOnlyFs(afero.NewMemMapFs())}
+		assert.ErrorContains(t, tf.writeOpenTofuOverride("/base"), "writing opentofu override")
+	})
+}
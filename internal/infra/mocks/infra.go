@@ -69,6 +69,21 @@ func (mr *MockManagerMockRecorder) Cleanup() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cleanup", reflect.TypeOf((*MockManager)(nil).Cleanup))
 }
 
+// Cost mocks base method.
+func (m *MockManager) Cost(ctx context.Context, arg1 env.Environment) (infra.CostReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cost", ctx, arg1)
+	ret0, _ := ret[0].(infra.CostReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cost indicates an expected call of Cost.
+func (mr *MockManagerMockRecorder) Cost(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cost", reflect.TypeOf((*MockManager)(nil).Cost), ctx, arg1)
+}
+
 // Destroy mocks base method.
 func (m *MockManager) Destroy(ctx context.Context, arg1 env.Environment) (infra.DestroyOutput, error) {
 	m.ctrl.T.Helper()
@@ -84,6 +99,21 @@ func (mr *MockManagerMockRecorder) Destroy(ctx, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destroy", reflect.TypeOf((*MockManager)(nil).Destroy), ctx, arg1)
 }
 
+// Drift mocks base method.
+func (m *MockManager) Drift(ctx context.Context, arg1 env.Environment) (infra.DriftReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Drift", ctx, arg1)
+	ret0, _ := ret[0].(infra.DriftReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Drift indicates an expected call of Drift.
+func (mr *MockManagerMockRecorder) Drift(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drift", reflect.TypeOf((*MockManager)(nil).Drift), ctx, arg1)
+}
+
 // Import mocks base method.
 func (m *MockManager) Import(ctx context.Context, input infra.ImportInput) (infra.ImportOutput, error) {
 	m.ctrl.T.Helper()
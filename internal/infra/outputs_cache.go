@@ -0,0 +1,135 @@
+package infra
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"filippo.io/age"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+const (
+	// OutputCacheDir is the directory, relative to the project root,
+	// that Terraform outputs are cached in.
+	OutputCacheDir = ".webkit/cache/outputs"
+	// OutputCacheTTL is how long cached outputs are trusted for, even
+	// if the state version hasn't changed.
+	OutputCacheTTL = 24 * time.Hour
+)
+
+type (
+	// OutputCache stores Terraform outputs on disk, encrypted with an
+	// age identity, so that commands can resolve resource references
+	// without initialising Terraform every time.
+	//
+	// Entries are keyed by environment and state version, so they
+	// are invalidated as soon as the remote state changes.
+	OutputCache struct {
+		fs         afero.Fs
+		identities []*age.X25519Identity
+		now        func() time.Time
+	}
+	// outputCacheEntry is the plaintext contents of a cache file.
+	outputCacheEntry struct {
+		Version  string       `json:"version"`
+		CachedAt time.Time    `json:"cached_at"`
+		Outputs  OutputResult `json:"outputs"`
+	}
+)
+
+// NewOutputCache creates a cache that encrypts entries to, and
+// decrypts them with, the given identities. Any one of them can
+// read an entry, so adding a key doesn't invalidate the cache.
+func NewOutputCache(fs afero.Fs, identities []*age.X25519Identity) *OutputCache {
+	return &OutputCache{
+		fs:         fs,
+		identities: identities,
+		now:        time.Now,
+	}
+}
+
+// Get returns the cached outputs for the environment if they were
+// cached from the same state version and haven't expired.
+func (c *OutputCache) Get(environment env.Environment, version StateVersion) (OutputResult, bool) {
+	data, err := afero.ReadFile(c.fs, c.path(environment))
+	if err != nil {
+		return OutputResult{}, false
+	}
+
+	identities := make([]age.Identity, 0, len(c.identities))
+	for _, identity := range c.identities {
+		identities = append(identities, identity)
+	}
+
+	reader, err := age.Decrypt(bytes.NewReader(data), identities...)
+	if err != nil {
+		return OutputResult{}, false
+	}
+
+	plain, err := io.ReadAll(reader)
+	if err != nil {
+		return OutputResult{}, false
+	}
+
+	var entry outputCacheEntry
+	if err = json.Unmarshal(plain, &entry); err != nil {
+		return OutputResult{}, false
+	}
+
+	if entry.Version != version.String() || c.now().Sub(entry.CachedAt) > OutputCacheTTL {
+		return OutputResult{}, false
+	}
+
+	return entry.Outputs, true
+}
+
+// Set encrypts and writes the outputs for the environment,
+// replacing any previous entry.
+func (c *OutputCache) Set(environment env.Environment, version StateVersion, outputs OutputResult) error {
+	plain, err := json.Marshal(outputCacheEntry{
+		Version:  version.String(),
+		CachedAt: c.now(),
+		Outputs:  outputs,
+	})
+	if err != nil {
+		return errors.Wrap(err, "serializing outputs")
+	}
+
+	recipients := make([]age.Recipient, 0, len(c.identities))
+	for _, identity := range c.identities {
+		recipients = append(recipients, identity.Recipient())
+	}
+
+	var buf bytes.Buffer
+	writer, err := age.Encrypt(&buf, recipients...)
+	if err != nil {
+		return errors.Wrap(err, "encrypting outputs")
+	}
+	if _, err = writer.Write(plain); err != nil {
+		return errors.Wrap(err, "encrypting outputs")
+	}
+	if err = writer.Close(); err != nil {
+		return errors.Wrap(err, "encrypting outputs")
+	}
+
+	if err = c.fs.MkdirAll(OutputCacheDir, os.ModePerm); err != nil {
+		return errors.Wrap(err, "creating output cache dir")
+	}
+
+	if err = afero.WriteFile(c.fs, c.path(environment), buf.Bytes(), 0o600); err != nil {
+		return errors.Wrap(err, "writing output cache")
+	}
+
+	return nil
+}
+
+func (c *OutputCache) path(environment env.Environment) string {
+	return filepath.Join(OutputCacheDir, environment.String()+".json.age")
+}
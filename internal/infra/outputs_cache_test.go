@@ -0,0 +1,128 @@
+package infra
+
+import (
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+func TestOutputCache(t *testing.T) {
+	t.Parallel()
+
+	version := StateVersion{Lineage: "abc", Serial: 4}
+	outputs := OutputResult{
+		Resources: map[string]map[string]any{
+			"db": {"connection_url": "postgres://secret@host/db"},
+		},
+	}
+
+	setupCache := func(t *testing.T) *OutputCache {
+		t.Helper()
+		identity, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		return NewOutputCache(afero.NewMemMapFs(), []*age.X25519Identity{identity})
+	}
+
+	t.Run("Round Trip", func(t *testing.T) {
+		t.Parallel()
+
+		cache := setupCache(t)
+		require.NoError(t, cache.Set(env.Production, version, outputs))
+
+		got, ok := cache.Get(env.Production, version)
+		require.True(t, ok)
+		assert.Equal(t, outputs, got)
+	})
+
+	t.Run("Encrypted On Disk", func(t *testing.T) {
+		t.Parallel()
+
+		cache := setupCache(t)
+		require.NoError(t, cache.Set(env.Production, version, outputs))
+
+		data, err := afero.ReadFile(cache.fs, cache.path(env.Production))
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "postgres://secret")
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		t.Parallel()
+
+		_, ok := setupCache(t).Get(env.Production, version)
+		assert.False(t, ok)
+	})
+
+	t.Run("Keyed By Environment", func(t *testing.T) {
+		t.Parallel()
+
+		cache := setupCache(t)
+		require.NoError(t, cache.Set(env.Production, version, outputs))
+
+		_, ok := cache.Get(env.Staging, version)
+		assert.False(t, ok)
+	})
+
+	t.Run("State Changed", func(t *testing.T) {
+		t.Parallel()
+
+		cache := setupCache(t)
+		require.NoError(t, cache.Set(env.Production, version, outputs))
+
+		_, ok := cache.Get(env.Production, StateVersion{Lineage: "abc", Serial: 5})
+		assert.False(t, ok)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		t.Parallel()
+
+		cache := setupCache(t)
+		require.NoError(t, cache.Set(env.Production, version, outputs))
+
+		cache.now = func() time.Time { return time.Now().Add(OutputCacheTTL + time.Minute) }
+
+		_, ok := cache.Get(env.Production, version)
+		assert.False(t, ok)
+	})
+
+	t.Run("Different Identity", func(t *testing.T) {
+		t.Parallel()
+
+		cache := setupCache(t)
+		require.NoError(t, cache.Set(env.Production, version, outputs))
+
+		other := setupCache(t)
+		other.fs = cache.fs
+
+		_, ok := other.Get(env.Production, version)
+		assert.False(t, ok)
+	})
+
+	t.Run("Several Identities", func(t *testing.T) {
+		t.Parallel()
+
+		cache := setupCache(t)
+		other := setupCache(t)
+		cache.identities = append(cache.identities, other.identities...)
+		require.NoError(t, cache.Set(env.Production, version, outputs))
+
+		other.fs = cache.fs
+		got, ok := other.Get(env.Production, version)
+		require.True(t, ok, "Any of the identities should read the entry")
+		assert.Equal(t, outputs, got)
+	})
+
+	t.Run("Write Error", func(t *testing.T) {
+		t.Parallel()
+
+		cache := setupCache(t)
+		cache.fs = afero.NewReadOnlyFs(afero.NewMemMapFs())
+
+		assert.Error(t, cache.Set(env.Production, version, outputs))
+	})
+}
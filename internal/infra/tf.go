@@ -17,7 +17,7 @@ import (
 
 	"github.com/ainsleydev/webkit/internal/appdef"
 	"github.com/ainsleydev/webkit/internal/fsext"
-	"github.com/ainsleydev/webkit/internal/ghapi"
+	"github.com/ainsleydev/webkit/internal/registry"
 	"github.com/ainsleydev/webkit/internal/state/manifest"
 	"github.com/ainsleydev/webkit/internal/util/executil"
 	"github.com/ainsleydev/webkit/pkg/enforce"
@@ -31,20 +31,41 @@ const (
 	// - .github/actions/setup/action.yaml
 	// - platform/terraform/base/main.tf (required_version)
 	TerraformVersion = "1.13.0"
+
+	// OpenTofuVersion is the version of OpenTofu to use in CI/CD workflows
+	// when the opentofu backend is selected in app.json.
+	// This should be kept in sync with:
+	// - .github/actions/setup-infra/action.yaml
+	OpenTofuVersion = "1.10.6"
+)
+
+const (
+	// terraformBinary is the name of the Terraform CLI binary.
+	terraformBinary = "terraform"
+	// openTofuBinary is the name of the OpenTofu CLI binary, which is
+	// a drop-in replacement that runs the same embedded modules.
+	openTofuBinary = "tofu"
 )
 
 // Terraform represents the type for interacting with the
 // terraform exec CLI.
 type Terraform struct {
 	appDef          *appdef.Definition
+	binary          string
 	path            string
 	tmpDir          string
 	env             TFEnvironment
 	tf              terraformExecutor
 	manifest        *manifest.Tracker
 	fs              afero.Fs
-	ghClient        ghapi.Client
+	registry        registry.Registry
 	useLocalBackend bool
+	// backendEnv is the environment whose state backend is
+	// configured on Init, defaults to production.
+	backendEnv env.Environment
+	// targets limits Plan and Apply to a subset of apps
+	// and resources, see WithTargets.
+	targets Targets
 	// varsCache caches prepared variables per environment to avoid
 	// redundant API calls and file writes
 	varsCache    map[env.Environment]tfVars
@@ -68,17 +89,41 @@ type terraformExecutor interface {
 	ShowPlanFile(ctx context.Context, planPath string, opts ...tfexec.ShowOption) (*tfjson.Plan, error)
 }
 
+// Option configures a Terraform manager.
+type Option func(t *Terraform)
+
+// WithEnvironment binds the manager to the state backend of the given
+// environment. Managers for different environments can then be
+// initialised and run side by side, each in its own work directory.
+func WithEnvironment(environment env.Environment) Option {
+	return func(t *Terraform) {
+		t.backendEnv = environment
+	}
+}
+
 // NewTerraform creates a new Terraform manager by locating
 // the terraform binary on the system.
 //
 // Returns an error if terraform cannot be found in PATH.
-func NewTerraform(ctx context.Context, appDef *appdef.Definition, manifest *manifest.Tracker) (*Terraform, error) {
+func NewTerraform(ctx context.Context, appDef *appdef.Definition, manifest *manifest.Tracker, opts ...Option) (*Terraform, error) {
+	return newTerraform(ctx, appDef, manifest, terraformBinary, opts...)
+}
+
+// NewOpenTofu creates a new Terraform manager that runs the embedded
+// modules with the OpenTofu binary instead of Terraform.
+//
+// Returns an error if tofu cannot be found in PATH.
+func NewOpenTofu(ctx context.Context, appDef *appdef.Definition, manifest *manifest.Tracker, opts ...Option) (*Terraform, error) {
+	return newTerraform(ctx, appDef, manifest, openTofuBinary, opts...)
+}
+
+func newTerraform(ctx context.Context, appDef *appdef.Definition, manifest *manifest.Tracker, binary string, opts ...Option) (*Terraform, error) {
 	enforce.NotNil(appDef, "app definition is required")
 	enforce.NotNil(manifest, "manifest definition is required")
 
-	path, err := getTerraformPath(ctx)
+	path, err := getBinaryPath(ctx, binary)
 	if err != nil {
-		return nil, errors.Wrap(err, "locating terraform binary")
+		return nil, errors.Wrapf(err, "locating %s binary", binary)
 	}
 
 	tfEnv, err := ParseTFEnvironment()
@@ -86,17 +131,25 @@ func NewTerraform(ctx context.Context, appDef *appdef.Definition, manifest *mani
 		return nil, errors.Wrap(err, "validating terraform environment variables")
 	}
 
-	return &Terraform{
+	t := &Terraform{
 		appDef:          appDef,
+		binary:          binary,
 		path:            path,
 		fs:              afero.NewOsFs(),
 		env:             tfEnv,
-		ghClient:        ghapi.New(tfEnv.GithubTokenClassic),
+		registry:        registry.New(appDef),
 		useLocalBackend: false,
+		backendEnv:      env.Production,
 		manifest:        manifest,
 		varsCache:       make(map[env.Environment]tfVars),
 		varsPrepared:    make(map[env.Environment]bool),
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t, nil
 }
 
 const tmpFolderPattern = "webkit-tf"
@@ -123,6 +176,12 @@ func (t *Terraform) Init(ctx context.Context) error {
 
 	tfDir := filepath.Join(tmpDir, "base")
 
+	if t.binary == openTofuBinary {
+		if err = t.writeOpenTofuOverride(tfDir); err != nil {
+			return err
+		}
+	}
+
 	tf, err := tfexec.NewTerraform(tfDir, t.path)
 	if err != nil {
 		return errors.Wrap(err, "creating terraform executor")
@@ -131,12 +190,16 @@ func (t *Terraform) Init(ctx context.Context) error {
 
 	initOpts := []tfexec.InitOption{
 		tfexec.Upgrade(true),
-		tfexec.Backend(!t.useLocalBackend), // Only use backend on prod.
+		tfexec.Backend(!t.useLocalBackend),
 		tfexec.Reconfigure(true),
 	}
 
 	if !t.useLocalBackend {
-		backendPath, err := t.writeS3Backend(tfDir, env.Production)
+		backendEnv := t.backendEnv
+		if backendEnv == "" {
+			backendEnv = env.Production
+		}
+		backendPath, err := t.writeS3Backend(tfDir, backendEnv)
 		if err != nil {
 			return err
 		}
@@ -165,6 +228,21 @@ type PlanOutput struct {
 	Plan *tfjson.Plan
 }
 
+// planAction returns a single word describing the plan actions
+// of a resource change.
+func planAction(actions tfjson.Actions) string {
+	switch {
+	case actions.Replace():
+		return "replace"
+	case actions.Create():
+		return "create"
+	case actions.Delete():
+		return "delete"
+	default:
+		return "update"
+	}
+}
+
 // Plan generates a Terraform execution plan showing what actions Terraform
 // will take to reach the desired state defined in the definition.
 // If refreshOnly is true, it uses 'terraform plan -refresh-only' to show
@@ -183,10 +261,18 @@ func (t *Terraform) Plan(ctx context.Context, env env.Environment, refreshOnly b
 		opts = append(opts, tfexec.RefreshOnly(true))
 	}
 	opts = append(opts, tfexec.Out(planFilePath))
-	for _, v := range t.env.varStrings() {
+	for _, v := range t.varStrings() {
 		opts = append(opts, tfexec.Var(v))
 	}
 
+	targets, err := t.targetAddresses()
+	if err != nil {
+		return PlanOutput{}, err
+	}
+	for _, target := range targets {
+		opts = append(opts, tfexec.Target(target))
+	}
+
 	changes, err := t.tf.Plan(ctx, opts...)
 	if err != nil {
 		return PlanOutput{}, fmt.Errorf("terraform plan failed: %w", err)
@@ -236,10 +322,18 @@ func (t *Terraform) Apply(ctx context.Context, env env.Environment, refreshOnly
 	if refreshOnly {
 		opts = append(opts, tfexec.RefreshOnly(true))
 	}
-	for _, v := range t.env.varStrings() {
+	for _, v := range t.varStrings() {
 		opts = append(opts, tfexec.Var(v))
 	}
 
+	targets, err := t.targetAddresses()
+	if err != nil {
+		return ApplyOutput{}, err
+	}
+	for _, target := range targets {
+		opts = append(opts, tfexec.Target(target))
+	}
+
 	if err := t.tf.Apply(ctx, opts...); err != nil {
 		errMsg := "terraform apply failed"
 		if refreshOnly {
@@ -276,7 +370,7 @@ func (t *Terraform) Destroy(ctx context.Context, env env.Environment) (DestroyOu
 	t.tf.SetStderr(&outputBuf)
 
 	var vars []tfexec.DestroyOption
-	for _, v := range t.env.varStrings() {
+	for _, v := range t.varStrings() {
 		vars = append(vars, tfexec.Var(v))
 	}
 
@@ -379,6 +473,20 @@ const (
 	ImportKindProject
 )
 
+// String implements fmt.Stringer on ImportKind.
+func (k ImportKind) String() string {
+	switch k {
+	case ImportKindResource:
+		return "resource"
+	case ImportKindApp:
+		return "app"
+	case ImportKindProject:
+		return "project"
+	default:
+		return "unknown"
+	}
+}
+
 type (
 	// ImportInput contains the configuration for importing existing resources or apps.
 	ImportInput struct {
@@ -393,6 +501,12 @@ type (
 
 		// Environment specifies which environment to import into.
 		Environment env.Environment
+
+		// Options contains additional provider identifiers that are merged
+		// into the resource or app infra config when building import
+		// addresses (e.g., "cdn_id" for DigitalOcean Spaces or
+		// "firewall_id" for Hetzner servers).
+		Options map[string]string
 	}
 	// ImportOutput contains the results of an import operation.
 	ImportOutput struct {
@@ -433,6 +547,7 @@ func (t *Terraform) Import(ctx context.Context, input ImportInput) (ImportOutput
 		if app == nil {
 			return ImportOutput{}, fmt.Errorf("app %q not found in app.json", input.Name)
 		}
+		app = withAppImportOptions(app, input.Options)
 
 		// Build import addresses based on app type and provider.
 		addresses, err = buildAppImportAddresses(t.appDef.Project.Name, app, input.ID)
@@ -452,10 +567,18 @@ func (t *Terraform) Import(ctx context.Context, input ImportInput) (ImportOutput
 		if resource == nil {
 			return ImportOutput{}, fmt.Errorf("resource %q not found in app.json", input.Name)
 		}
+		resource = withImportOptions(resource, input.Options)
+
+		// Cloudflare IDs are scoped to an account, so allow the bucket
+		// name on its own and fall back to the configured account.
+		id := input.ID
+		if resource.Provider == appdef.ResourceProviderCloudflare && !strings.Contains(id, "/") {
+			id = t.env.CloudflareAccountID + "/" + id
+		}
 
 		// Build import addresses based on resource type and provider.
 		// Pass project name to build full resource names matching Terraform's naming convention.
-		addresses, err = buildImportAddresses(t.appDef.Project.Name, resource, input.ID)
+		addresses, err = buildImportAddresses(t.appDef.Project.Name, resource, id)
 		if err != nil {
 			return ImportOutput{}, err
 		}
@@ -466,7 +589,7 @@ func (t *Terraform) Import(ctx context.Context, input ImportInput) (ImportOutput
 	t.tf.SetStderr(&outputBuf)
 
 	var vars []tfexec.ImportOption
-	for _, v := range t.env.varStrings() {
+	for _, v := range t.varStrings() {
 		vars = append(vars, tfexec.Var(v))
 	}
 
@@ -487,6 +610,42 @@ func (t *Terraform) Import(ctx context.Context, input ImportInput) (ImportOutput
 	}, nil
 }
 
+// withImportOptions returns a copy of the resource with the given
+// options merged into its config, leaving the definition untouched.
+func withImportOptions(resource *appdef.Resource, opts map[string]string) *appdef.Resource {
+	if len(opts) == 0 {
+		return resource
+	}
+	r := *resource
+	r.Config = mergeImportOptions(resource.Config, opts)
+	return &r
+}
+
+// withAppImportOptions returns a copy of the app with the given
+// options merged into its infra config, leaving the definition
+// untouched.
+func withAppImportOptions(app *appdef.App, opts map[string]string) *appdef.App {
+	if len(opts) == 0 {
+		return app
+	}
+	a := *app
+	a.Infra.Config = mergeImportOptions(app.Infra.Config, opts)
+	return &a
+}
+
+func mergeImportOptions(config appdef.Config, opts map[string]string) appdef.Config {
+	merged := make(appdef.Config, len(config)+len(opts))
+	for k, v := range config {
+		merged[k] = v
+	}
+	for k, v := range opts {
+		if v != "" {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
 // Cleanup removes all the temporary directories that we're
 // created during the terraform init process.
 //
@@ -538,7 +697,7 @@ func (t *Terraform) prepareVars(ctx context.Context, env env.Environment) error
 // determineImageTag determines the appropriate image tag for an app.
 // Priority:
 //  1. GITHUB_SHA environment variable (when running in CI)
-//  2. Latest sha-* tag from GHCR via ghapi client (when running locally)
+//  2. Latest sha-* tag from the configured registry (when running locally)
 //  3. "latest" as fallback
 func (t *Terraform) determineImageTag(ctx context.Context, appName string) string {
 	// Check if we're in CI with GITHUB_SHA env var.
@@ -546,10 +705,14 @@ func (t *Terraform) determineImageTag(ctx context.Context, appName string) strin
 		return "sha-" + sha
 	}
 
-	// Try to get the latest sha tag from GHCR using the injected client.
-	tag, err := t.ghClient.GetLatestSHATag(ctx, t.appDef.Project.Repo.Owner, t.appDef.Project.Repo.Name, appName)
+	// Try to get the latest sha tag from the registry.
+	tag, err := t.registry.LatestSHATag(ctx, appName)
 	if err != nil {
-		slog.Error("Obtaining latest SHA tag for app %Q, error: %s", appName, err.Error())
+		slog.Error("Obtaining latest SHA tag for app",
+			slog.String("app", appName),
+			slog.String("registry", t.registry.Host()),
+			slog.String("error", err.Error()),
+		)
 
 		// Fallback to latest.
 		return "latest"
@@ -563,11 +726,45 @@ func (t *Terraform) determineImageTag(ctx context.Context, appName string) strin
 	return tag
 }
 
-func getTerraformPath(ctx context.Context) (string, error) {
-	whichCmd := executil.NewCommand("which", "terraform")
+// varStrings returns the Terraform variables passed on the command
+// line, which are the provider credentials from the environment and
+// the credentials used to pull images from the registry.
+func (t *Terraform) varStrings() []string {
+	vars := t.env.varStrings()
+	if t.registry == nil {
+		return vars
+	}
+	if creds := t.registry.Credentials(); !creds.IsZero() {
+		vars = append(vars, "registry_credentials="+creds.String())
+	}
+	return vars
+}
+
+// openTofuOverride relaxes the required_version constraint in the base
+// module so the OpenTofu release line, which is versioned separately
+// from Terraform, can run the same embedded modules.
+const openTofuOverride = `terraform {
+  required_version = ">= 1.10.0"
+}
+`
+
+// writeOpenTofuOverride writes an override file to the base module,
+// which both Terraform and OpenTofu merge into the configuration.
+func (t *Terraform) writeOpenTofuOverride(tfDir string) error {
+	path := filepath.Join(tfDir, "opentofu_override.tf")
+	if err := afero.WriteFile(t.fs, path, []byte(openTofuOverride), os.ModePerm); err != nil {
+		return errors.Wrap(err, "writing opentofu override")
+	}
+	return nil
+}
+
+// getBinaryPath locates an infrastructure CLI binary (terraform or tofu)
+// within the PATH.
+func getBinaryPath(ctx context.Context, binary string) (string, error) {
+	whichCmd := executil.NewCommand("which", binary)
 	run, err := executil.DefaultRunner().Run(ctx, whichCmd)
 	if err != nil {
-		return "", errors.Wrap(err, "terraform binary not found in PATH (install terraform or add it to your PATH)")
+		return "", errors.Wrapf(err, "%s binary not found in PATH (install %s or add it to your PATH)", binary, binary)
 	}
 	return strings.TrimSpace(run.Output), nil
 }
@@ -14,19 +14,28 @@ import (
 const (
 	backendTfFileName  = "backend.tf"
 	backendHclFileName = "backend.hcl"
+	// backendRegion is the BackBlaze region that state is stored in.
+	backendRegion = "eu-central-003"
+	// backendEndpoint is the S3 compatible endpoint for backendRegion.
+	backendEndpoint = "https://s3.eu-central-003.backblazeb2.com"
 )
 
+// stateKey returns the key of the state file within the backend bucket,
+// for example, project-name/environment/terraform.tfstate
+func stateKey(projectName string, environment env.Environment) string {
+	return fmt.Sprintf("%s/%s/terraform.tfstate", projectName, environment)
+}
+
 // writeS3Backend writes the complete Terraform backend configuration
 // with a dynamic key based on project name and environment
 func (t *Terraform) writeS3Backend(infraDir string, environment env.Environment) (string, error) {
 	gen := scaffold.New(t.fs, t.manifest, printer.New(io.Discard))
 
-	// For example, project-name/environment/terraform.tfstate
-	folder := fmt.Sprintf("%s/%s/terraform.tfstate", t.appDef.Project.Name, environment)
-
 	data := map[string]any{
 		"Bucket":    t.env.BackBlazeBucket,
-		"Key":       folder,
+		"Key":       stateKey(t.appDef.Project.Name, environment),
+		"Region":    backendRegion,
+		"Endpoint":  backendEndpoint,
 		"AccessKey": t.env.BackBlazeKeyID,
 		"SecretKey": t.env.BackBlazeApplicationKey,
 	}
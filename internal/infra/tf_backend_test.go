@@ -52,6 +52,8 @@ func TestWriteBackendConfig(t *testing.T) {
 			assert.Contains(t, contentStr, "bucket")
 			assert.Contains(t, contentStr, tf.env.BackBlazeBucket)
 			assert.Contains(t, contentStr, "test-project/production/terraform.tfstate")
+			assert.Contains(t, contentStr, backendEndpoint)
+			assert.Contains(t, contentStr, backendRegion)
 			assert.Contains(t, contentStr, tf.env.BackBlazeKeyID)
 			assert.Contains(t, contentStr, tf.env.BackBlazeApplicationKey)
 		}
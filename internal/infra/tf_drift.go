@@ -0,0 +1,119 @@
+package infra
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+type (
+	// DriftReport is the result of calling Drift, it lists every
+	// resource that has been changed outside of Terraform since
+	// the last apply.
+	DriftReport struct {
+		Environment env.Environment   `json:"environment"`
+		Resources   []DriftedResource `json:"resources"`
+		// Human-readable output of the refresh-only plan.
+		Output string `json:"-"`
+	}
+	// DriftedResource is a single Terraform resource whose real
+	// world state no longer matches the state file.
+	DriftedResource struct {
+		// Kind is either "app" or "resource", or empty if the address
+		// sits outside the modules generated from app.json.
+		Kind string `json:"kind"`
+		// Name is the name of the app or resource in app.json.
+		Name    string `json:"name"`
+		Address string `json:"address"`
+		// Action is how Terraform sees the change, "delete" meaning
+		// the resource no longer exists.
+		Action string `json:"action"`
+		// Attributes are the top-level attributes that changed.
+		Attributes []string `json:"attributes,omitempty"`
+	}
+)
+
+// HasDrift returns true if any resource has drifted.
+func (r DriftReport) HasDrift() bool {
+	return len(r.Resources) > 0
+}
+
+// Drift runs a refresh-only plan for the environment and reports
+// every resource that has been changed outside of Terraform, mapped
+// back to the app or resource in app.json that owns it.
+//
+// Must be called after Init().
+func (t *Terraform) Drift(ctx context.Context, environment env.Environment) (DriftReport, error) {
+	plan, err := t.Plan(ctx, environment, true)
+	if err != nil {
+		return DriftReport{}, err
+	}
+
+	return DriftReport{
+		Environment: environment,
+		Resources:   planDrift(plan.Plan),
+		Output:      plan.Output,
+	}, nil
+}
+
+// planDrift returns the resources that Terraform detected as
+// changed outside of Terraform when refreshing the plan.
+func planDrift(plan *tfjson.Plan) []DriftedResource {
+	drifted := []DriftedResource{}
+	if plan == nil {
+		return drifted
+	}
+
+	for _, rc := range plan.ResourceDrift {
+		if rc == nil || rc.Change == nil {
+			continue
+		}
+		actions := rc.Change.Actions
+		if actions.NoOp() || actions.Read() {
+			continue
+		}
+
+		resource := DriftedResource{
+			Address:    rc.Address,
+			Action:     planAction(actions),
+			Attributes: changedAttributes(rc.Change.Before, rc.Change.After),
+		}
+		resource.Kind, resource.Name = moduleOwner(rc.Address)
+		drifted = append(drifted, resource)
+	}
+
+	sort.Slice(drifted, func(i, j int) bool {
+		return drifted[i].Address < drifted[j].Address
+	})
+
+	return drifted
+}
+
+// changedAttributes returns the sorted top-level keys that differ
+// between the before and after values of a resource change.
+func changedAttributes(before, after any) []string {
+	b, _ := before.(map[string]any)
+	a, _ := after.(map[string]any)
+	if b == nil || a == nil {
+		return nil
+	}
+
+	var changed []string
+	for key, value := range b {
+		if !reflect.DeepEqual(value, a[key]) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range a {
+		if _, ok := b[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+
+	sort.Strings(changed)
+	return changed
+}
@@ -0,0 +1,84 @@
+package infra
+
+import (
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanDrift(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		ResourceDrift: []*tfjson.ResourceChange{
+			{
+				Address: `module.resources["db"].module.do_postgres[0].digitalocean_database_cluster.this`,
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionUpdate},
+					Before:  map[string]any{"size": "db-s-1vcpu-1gb", "node_count": float64(1), "tags": []any{"a"}},
+					After:   map[string]any{"size": "db-s-1vcpu-2gb", "node_count": float64(1), "tags": []any{"a"}, "region": "lon1"},
+				},
+			},
+			{
+				Address: `module.apps["web"].module.do_droplet[0].digitalocean_droplet.this`,
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionDelete},
+					Before:  map[string]any{"size": "s-1vcpu-1gb"},
+				},
+			},
+			{
+				Address: `module.apps["same"].module.do_droplet[0].digitalocean_droplet.this`,
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionNoop},
+				},
+			},
+			{
+				Address: `digitalocean_project.this`,
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionUpdate},
+					Before:  map[string]any{"description": "old"},
+					After:   map[string]any{"description": "new"},
+				},
+			},
+			nil,
+		},
+	}
+
+	got := planDrift(plan)
+	require.Len(t, got, 3)
+
+	assert.Equal(t, DriftedResource{
+		Address:    `digitalocean_project.this`,
+		Action:     "update",
+		Attributes: []string{"description"},
+	}, got[0])
+
+	assert.Equal(t, DriftedResource{
+		Kind:    "app",
+		Name:    "web",
+		Address: `module.apps["web"].module.do_droplet[0].digitalocean_droplet.this`,
+		Action:  "delete",
+	}, got[1])
+
+	assert.Equal(t, DriftedResource{
+		Kind:       "resource",
+		Name:       "db",
+		Address:    `module.resources["db"].module.do_postgres[0].digitalocean_database_cluster.this`,
+		Action:     "update",
+		Attributes: []string{"region", "size"},
+	}, got[2])
+
+	t.Run("Nil plan", func(t *testing.T) {
+		t.Parallel()
+		assert.Empty(t, planDrift(nil))
+	})
+}
+
+func TestDriftReport_HasDrift(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, DriftReport{}.HasDrift())
+	assert.True(t, DriftReport{Resources: []DriftedResource{{Address: "a"}}}.HasDrift())
+}
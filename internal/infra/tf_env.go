@@ -13,10 +13,16 @@ type TFEnvironment struct {
 	DigitalOceanSpacesAccessKey string `env:"DO_SPACES_ACCESS_KEY,required"`
 	DigitalOceanSpacesSecretKey string `env:"DO_SPACES_SECRET_KEY,required"`
 	HetznerToken                string `env:"HETZNER_TOKEN,required"`
+	HetznerDNSToken             string `env:"HETZNER_DNS_TOKEN"`
 	BackBlazeBucket             string `env:"BACK_BLAZE_BUCKET,required"`
 	BackBlazeKeyID              string `env:"BACK_BLAZE_KEY_ID,required"`
 	BackBlazeApplicationKey     string `env:"BACK_BLAZE_APPLICATION_KEY,required"`
 	TursoToken                  string `env:"TURSO_TOKEN,required"`
+	CloudflareAPIToken          string `env:"CLOUDFLARE_API_TOKEN"`
+	CloudflareAccountID         string `env:"CLOUDFLARE_ACCOUNT_ID"`
+	AWSAccessKeyID              string `env:"AWS_ACCESS_KEY_ID"`
+	AWSSecretAccessKey          string `env:"AWS_SECRET_ACCESS_KEY"`
+	AWSRegion                   string `env:"AWS_REGION"`
 	GithubToken                 string `env:"GITHUB_TOKEN,required"`
 	GithubTokenClassic          string `env:"GITHUB_TOKEN_CLASSIC,required"`
 	SlackBotToken               string `env:"SLACK_BOT_TOKEN,required"`
@@ -53,6 +59,32 @@ func (t *TFEnvironment) varStrings() []string {
 		"slack_user_token=" + t.SlackUserToken,
 	}
 
+	// Hetzner DNS uses a separate API token to Hetzner Cloud and is
+	// only required when apps have domains managed by Hetzner.
+	if t.HetznerDNSToken != "" {
+		vars = append(vars, "hetzner_dns_token="+t.HetznerDNSToken)
+	}
+
+	// Cloudflare is only required when domains use it for DNS
+	// or resources are hosted on R2.
+	if t.CloudflareAPIToken != "" {
+		vars = append(vars, "cloudflare_api_token="+t.CloudflareAPIToken)
+	}
+	if t.CloudflareAccountID != "" {
+		vars = append(vars, "cloudflare_account_id="+t.CloudflareAccountID)
+	}
+
+	// AWS is only required when resources are hosted on S3 or RDS.
+	if t.AWSAccessKeyID != "" {
+		vars = append(vars, "aws_access_key_id="+t.AWSAccessKeyID)
+	}
+	if t.AWSSecretAccessKey != "" {
+		vars = append(vars, "aws_secret_access_key="+t.AWSSecretAccessKey)
+	}
+	if t.AWSRegion != "" {
+		vars = append(vars, "aws_region="+t.AWSRegion)
+	}
+
 	// Only include Peekaping credentials if they are configured.
 	// This prevents provider initialization when monitoring is not in use.
 	if t.PeekapingEndpoint != "" {
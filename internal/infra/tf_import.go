@@ -2,8 +2,11 @@ package infra
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
+	"github.com/spf13/cast"
+
 	"github.com/ainsleydev/webkit/internal/appdef"
 )
 
@@ -18,6 +21,33 @@ type (
 	}
 )
 
+// resourceModuleAddress returns the address of the module that
+// provisions the resource with the given name in app.json.
+func resourceModuleAddress(name string) string {
+	return fmt.Sprintf("module.resources[%q]", name)
+}
+
+// appModuleAddress returns the address of the module that
+// provisions the app with the given name in app.json.
+func appModuleAddress(name string) string {
+	return fmt.Sprintf("module.apps[%q]", name)
+}
+
+// moduleAddressPattern extracts the kind and name of the app or
+// resource from a Terraform address.
+var moduleAddressPattern = regexp.MustCompile(`^module\.(apps|resources)\["([^"]+)"\]`)
+
+// moduleOwner returns the kind ("app" or "resource") and name in app.json
+// of the module that a Terraform address belongs to. Both are empty if
+// the address sits outside the app and resource modules.
+func moduleOwner(address string) (kind, name string) {
+	m := moduleAddressPattern.FindStringSubmatch(address)
+	if m == nil {
+		return "", ""
+	}
+	return strings.TrimSuffix(m[1], "s"), m[2]
+}
+
 // buildImportAddresses constructs the list of Terraform import addresses
 // for a given resource based on its type and provider.
 // The projectName is used to build the full resource name as Terraform modules do.
@@ -25,8 +55,14 @@ func buildImportAddresses(projectName string, resource *appdef.Resource, baseID
 	switch resource.Provider {
 	case appdef.ResourceProviderDigitalOcean:
 		return buildDigitalOceanImports(projectName, resource, baseID)
+	case appdef.ResourceProviderBackBlaze:
+		return buildBackBlazeImports(resource, baseID)
 	case appdef.ResourceProviderTurso:
 		return buildTursoImports(resource, baseID)
+	case appdef.ResourceProviderCloudflare:
+		return buildCloudflareImports(resource, baseID)
+	case appdef.ResourceProviderAWS:
+		return buildAWSImports(resource, baseID)
 	default:
 		return nil, fmt.Errorf("import not supported for provider %q", resource.Provider)
 	}
@@ -39,6 +75,8 @@ func buildAppImportAddresses(projectName string, app *appdef.App, appID string)
 	switch app.Infra.Provider {
 	case appdef.ResourceProviderDigitalOcean:
 		return buildDigitalOceanAppImports(projectName, app, appID)
+	case appdef.ResourceProviderHetzner:
+		return buildHetznerAppImports(app, appID)
 	default:
 		return nil, fmt.Errorf("import not supported for provider %q", app.Infra.Provider)
 	}
@@ -56,6 +94,71 @@ func buildDigitalOceanImports(projectName string, resource *appdef.Resource, clu
 	}
 }
 
+// buildBackBlazeImports creates import addresses for BackBlaze resources.
+func buildBackBlazeImports(resource *appdef.Resource, bucketID string) ([]importAddress, error) {
+	switch resource.Type {
+	case appdef.ResourceTypeS3:
+		baseModule := resourceModuleAddress(resource.Name) + ".module.b2_bucket[0]"
+		return []importAddress{
+			{
+				Address: fmt.Sprintf("%s.b2_bucket.this", baseModule),
+				ID:      bucketID,
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("import not supported for resource type %q with BackBlaze provider", resource.Type)
+	}
+}
+
+// buildCloudflareImports creates import addresses for Cloudflare resources.
+//
+// R2 buckets are imported with an ID of "{account_id}/{bucket_name}/{jurisdiction}",
+// the jurisdiction defaults to "default" when omitted.
+func buildCloudflareImports(resource *appdef.Resource, bucketID string) ([]importAddress, error) {
+	switch resource.Type {
+	case appdef.ResourceTypeS3:
+		if strings.Count(bucketID, "/") == 1 {
+			bucketID += "/default"
+		}
+		baseModule := resourceModuleAddress(resource.Name) + ".module.r2_bucket[0]"
+		return []importAddress{
+			{
+				Address: fmt.Sprintf("%s.cloudflare_r2_bucket.this", baseModule),
+				ID:      bucketID,
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("import not supported for resource type %q with Cloudflare provider", resource.Type)
+	}
+}
+
+// buildAWSImports creates import addresses for AWS resources.
+//
+// RDS instances are imported by their identifier and S3 buckets by
+// their name, both of which are ${project_name}-${resource_name}.
+func buildAWSImports(resource *appdef.Resource, id string) ([]importAddress, error) {
+	switch resource.Type {
+	case appdef.ResourceTypePostgres:
+		baseModule := resourceModuleAddress(resource.Name) + ".module.aws_postgres[0]"
+		return []importAddress{
+			{
+				Address: fmt.Sprintf("%s.aws_db_instance.this", baseModule),
+				ID:      id,
+			},
+		}, nil
+	case appdef.ResourceTypeS3:
+		baseModule := resourceModuleAddress(resource.Name) + ".module.aws_bucket[0]"
+		return []importAddress{
+			{
+				Address: fmt.Sprintf("%s.aws_s3_bucket.this", baseModule),
+				ID:      id,
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("import not supported for resource type %q with AWS provider", resource.Type)
+	}
+}
+
 // buildTursoImports creates import addresses for Turso resources.
 func buildTursoImports(resource *appdef.Resource, databaseID string) ([]importAddress, error) {
 	switch resource.Type {
@@ -84,7 +187,7 @@ func buildPostgresImports(projectName string, resource *appdef.Resource, cluster
 	// This matches platform/terraform/providers/digital_ocean/postgres/main.tf:2
 	dbPrefix := strings.ToLower(strings.ReplaceAll(fullName, "-", "_"))
 
-	baseModule := fmt.Sprintf("module.resources[\"%s\"].module.do_postgres[0]", resource.Name)
+	baseModule := resourceModuleAddress(resource.Name) + ".module.do_postgres[0]"
 
 	addresses := []importAddress{
 		{
@@ -121,7 +224,7 @@ func buildPostgresImports(projectName string, resource *appdef.Resource, cluster
 // Note: The CDN resource requires a different import ID format (just the CDN UUID)
 // compared to the bucket and CORS configuration (which use "region,bucket_name").
 func buildS3Imports(resource *appdef.Resource, bucketID string) []importAddress {
-	baseModule := fmt.Sprintf("module.resources[\"%s\"].module.do_bucket[0]", resource.Name)
+	baseModule := resourceModuleAddress(resource.Name) + ".module.do_bucket[0]"
 	region, ok := resource.Config["region"].(string)
 	if !ok {
 		region = "ams3"
@@ -159,7 +262,7 @@ func buildDigitalOceanAppImports(_ string, app *appdef.App, appID string) ([]imp
 	switch platformType {
 	case "container":
 		// DigitalOcean App Platform
-		baseModule := fmt.Sprintf("module.apps[\"%s\"].module.do_app[0]", app.Name)
+		baseModule := appModuleAddress(app.Name) + ".module.do_app[0]"
 		return []importAddress{
 			{
 				Address: fmt.Sprintf("%s.digitalocean_app.this", baseModule),
@@ -178,7 +281,7 @@ func buildDigitalOceanAppImports(_ string, app *appdef.App, appID string) ([]imp
 // Currently only imports the droplet resource itself. The SSH key and firewall
 // resources created by the module should be imported separately if they exist.
 func buildDropletImports(app *appdef.App, dropletID string) []importAddress {
-	baseModule := fmt.Sprintf("module.apps[\"%s\"].module.do_droplet[0]", app.Name)
+	baseModule := appModuleAddress(app.Name) + ".module.do_droplet[0]"
 
 	return []importAddress{
 		{
@@ -188,6 +291,190 @@ func buildDropletImports(app *appdef.App, dropletID string) []importAddress {
 	}
 }
 
+// buildHetznerAppImports creates import addresses for Hetzner apps.
+//
+// The server is imported by its ID. The resources created alongside it
+// are imported when their IDs are in the app's infra config, which the
+// discoverer fills in from the Hetzner API:
+//   - ssh_key_id: the SSH key added to the server.
+//   - firewall_id: the firewall and its attachment, which only exist
+//     when the app doesn't declare its own inbound rules.
+//   - ipv4_address / ipv6_address: the reverse DNS entries, which only
+//     exist when the app has a primary domain.
+//   - volume_id: the volume and its attachment, which only exist when
+//     a volume_size is configured.
+//   - dns_record:<fqdn>/<type>: the DNS records of each domain.
+//
+// The TLS key that the SSH key is generated from can't be imported,
+// so the SSH key is replaced on the next apply.
+func buildHetznerAppImports(app *appdef.App, serverID string) ([]importAddress, error) {
+	if app.Infra.Type != "vm" {
+		return nil, fmt.Errorf("import not supported for app platform type %q with Hetzner provider", app.Infra.Type)
+	}
+
+	config := app.Infra.Config
+	serverModule := appModuleAddress(app.Name) + ".module.hetzner_server[0]"
+
+	addresses := []importAddress{
+		{
+			Address: fmt.Sprintf("%s.hcloud_server.this", serverModule),
+			ID:      serverID,
+		},
+	}
+
+	if id, ok := config.String("ssh_key_id"); ok && id != "" {
+		addresses = append(addresses, importAddress{
+			Address: fmt.Sprintf("%s.hcloud_ssh_key.this", serverModule),
+			ID:      id,
+		})
+	}
+
+	// Firewall attachments are imported by the ID of the firewall.
+	if id, ok := config.String("firewall_id"); ok && id != "" && len(app.Network.Inbound) == 0 {
+		addresses = append(addresses,
+			importAddress{
+				Address: fmt.Sprintf("%s.hcloud_firewall.this[0]", serverModule),
+				ID:      id,
+			},
+			importAddress{
+				Address: fmt.Sprintf("%s.hcloud_firewall_attachment.this[0]", serverModule),
+				ID:      id,
+			},
+		)
+	}
+
+	// Reverse DNS entries are imported as s-<server_id>-<ip>.
+	if hasPrimaryDomain(app) {
+		for _, family := range []string{"ipv4", "ipv6"} {
+			ip, ok := config.String(family + "_address")
+			if !ok || ip == "" {
+				continue
+			}
+			addresses = append(addresses, importAddress{
+				Address: fmt.Sprintf("%s.hcloud_rdns.%s[0]", serverModule, family),
+				ID:      fmt.Sprintf("s-%s-%s", serverID, ip),
+			})
+		}
+	}
+
+	// Volume attachments are imported by the ID of the volume.
+	if id, ok := config.String("volume_id"); ok && id != "" && cast.ToInt(config["volume_size"]) > 0 {
+		volumeModule := appModuleAddress(app.Name) + ".module.hetzner_volume[0]"
+		addresses = append(addresses,
+			importAddress{
+				Address: fmt.Sprintf("%s.hcloud_volume.this", volumeModule),
+				ID:      id,
+			},
+			importAddress{
+				Address: fmt.Sprintf("%s.hcloud_volume_attachment.this", volumeModule),
+				ID:      id,
+			},
+		)
+	}
+
+	addresses = append(addresses, buildDNSRecordImports(app, []string{"A", "AAAA"})...)
+
+	return addresses, nil
+}
+
+// hasPrimaryDomain reports whether the app has a domain of the primary
+// type, which the apps module uses for reverse DNS.
+func hasPrimaryDomain(app *appdef.App) bool {
+	for _, domain := range app.Domains {
+		if domain.Type.Normalise() == appdef.DomainTypePrimary {
+			return true
+		}
+	}
+	return false
+}
+
+// dnsRecordModules maps the provider hosting a zone to the module and
+// resource that the apps module creates its records with.
+var dnsRecordModules = map[appdef.DNSProvider]struct{ Module, Resource string }{
+	appdef.DNSProviderDigitalOcean: {Module: "do_dns_record", Resource: "digitalocean_record"},
+	appdef.DNSProviderHetzner:      {Module: "hetzner_dns_record", Resource: "hetznerdns_record"},
+	appdef.DNSProviderCloudflare:   {Module: "cloudflare_dns_record", Resource: "cloudflare_dns_record"},
+}
+
+// dnsRecordKey is the key of a DNS record within the apps module, and
+// of its import ID in an app's infra config: "<fqdn>/<type>".
+func dnsRecordKey(fqdn, recordType string) string {
+	return fqdn + "/" + recordType
+}
+
+// dnsRecordOption is the infra config key that holds the import ID of
+// a DNS record.
+func dnsRecordOption(fqdn, recordType string) string {
+	return "dns_record:" + dnsRecordKey(fqdn, recordType)
+}
+
+// appDNSRecord is a DNS record that the apps module creates for one
+// of an app's managed domains.
+type appDNSRecord struct {
+	FQDN     string
+	Type     string
+	Zone     string
+	Provider appdef.DNSProvider
+}
+
+// appDNSRecords returns the records that the apps module creates for
+// the app's managed domains, including wildcard records, mirroring
+// dns_record_set in platform/terraform/modules/apps/main.tf.
+func appDNSRecords(app *appdef.App, recordTypes []string) []appDNSRecord {
+	var records []appDNSRecord
+	for _, domain := range app.Domains {
+		zone := domain.ZoneName()
+		if !domain.IsManaged() || zone == "" {
+			continue
+		}
+
+		provider := domain.DNS
+		if provider == "" {
+			provider = appdef.DNSProvider(app.Infra.Provider)
+		}
+
+		fqdns := []string{domain.Name}
+		if domain.Wildcard && !strings.HasPrefix(domain.Name, "*.") {
+			fqdns = append(fqdns, "*."+domain.Name)
+		}
+
+		for _, fqdn := range fqdns {
+			for _, recordType := range recordTypes {
+				records = append(records, appDNSRecord{
+					FQDN:     fqdn,
+					Type:     recordType,
+					Zone:     zone,
+					Provider: provider,
+				})
+			}
+		}
+	}
+	return records
+}
+
+// buildDNSRecordImports creates the import addresses for the DNS records
+// of an app whose import IDs are set in its infra config. The ID format
+// depends on the provider hosting the zone:
+//   - digitalocean: <zone>,<record_id>
+//   - hetzner: <record_id>
+//   - cloudflare: <zone_id>/<record_id>
+func buildDNSRecordImports(app *appdef.App, recordTypes []string) []importAddress {
+	var addresses []importAddress
+	for _, record := range appDNSRecords(app, recordTypes) {
+		id, ok := app.Infra.Config.String(dnsRecordOption(record.FQDN, record.Type))
+		module, supported := dnsRecordModules[record.Provider]
+		if !ok || id == "" || !supported {
+			continue
+		}
+		addresses = append(addresses, importAddress{
+			Address: fmt.Sprintf("%s.module.%s[%q].%s.this",
+				appModuleAddress(app.Name), module.Module, dnsRecordKey(record.FQDN, record.Type), module.Resource),
+			ID: id,
+		})
+	}
+	return addresses
+}
+
 // buildTursoSQLiteImports creates the import addresses for a Turso SQLite database.
 // The databaseID should be in the format "organization/database-name".
 //
@@ -203,7 +490,7 @@ func buildDropletImports(app *appdef.App, dropletID string) []importAddress {
 // The function builds the full resource address using the resource module pattern:
 //   - Database: module.resources["<name>"].module.turso_database[0].turso_database.this
 func buildTursoSQLiteImports(resource *appdef.Resource, databaseID string) []importAddress {
-	baseModule := fmt.Sprintf("module.resources[\"%s\"].module.turso_database[0]", resource.Name)
+	baseModule := resourceModuleAddress(resource.Name) + ".module.turso_database[0]"
 
 	return []importAddress{
 		{
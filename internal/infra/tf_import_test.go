@@ -175,6 +175,127 @@ func TestBuildImportAddresses(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		"BackBlaze S3 bucket": {
+			projectName: "test-project",
+			resource: &appdef.Resource{
+				Name:     "media",
+				Type:     appdef.ResourceTypeS3,
+				Provider: appdef.ResourceProviderBackBlaze,
+				Config:   map[string]any{},
+			},
+			baseID: "bucket-123",
+			want: []importAddress{
+				{
+					Address: "module.resources[\"media\"].module.b2_bucket[0].b2_bucket.this",
+					ID:      "bucket-123",
+				},
+			},
+			wantErr: false,
+		},
+		"BackBlaze with unsupported resource type": {
+			projectName: "test-project",
+			resource: &appdef.Resource{
+				Name:     "db",
+				Type:     appdef.ResourceTypePostgres,
+				Provider: appdef.ResourceProviderBackBlaze,
+				Config:   map[string]any{},
+			},
+			baseID:  "bucket-123",
+			want:    nil,
+			wantErr: true,
+		},
+		"Cloudflare R2 bucket": {
+			projectName: "test-project",
+			resource: &appdef.Resource{
+				Name:     "assets",
+				Type:     appdef.ResourceTypeS3,
+				Provider: appdef.ResourceProviderCloudflare,
+				Config:   map[string]any{},
+			},
+			baseID: "account-123/test-project-assets",
+			want: []importAddress{
+				{
+					Address: "module.resources[\"assets\"].module.r2_bucket[0].cloudflare_r2_bucket.this",
+					ID:      "account-123/test-project-assets/default",
+				},
+			},
+			wantErr: false,
+		},
+		"Cloudflare R2 bucket with jurisdiction": {
+			projectName: "test-project",
+			resource: &appdef.Resource{
+				Name:     "assets",
+				Type:     appdef.ResourceTypeS3,
+				Provider: appdef.ResourceProviderCloudflare,
+				Config:   map[string]any{},
+			},
+			baseID: "account-123/test-project-assets/eu",
+			want: []importAddress{
+				{
+					Address: "module.resources[\"assets\"].module.r2_bucket[0].cloudflare_r2_bucket.this",
+					ID:      "account-123/test-project-assets/eu",
+				},
+			},
+			wantErr: false,
+		},
+		"Cloudflare with unsupported resource type": {
+			projectName: "test-project",
+			resource: &appdef.Resource{
+				Name:     "db",
+				Type:     appdef.ResourceTypePostgres,
+				Provider: appdef.ResourceProviderCloudflare,
+				Config:   map[string]any{},
+			},
+			baseID:  "account-123/db",
+			want:    nil,
+			wantErr: true,
+		},
+		"AWS RDS Postgres": {
+			projectName: "test-project",
+			resource: &appdef.Resource{
+				Name:     "db",
+				Type:     appdef.ResourceTypePostgres,
+				Provider: appdef.ResourceProviderAWS,
+				Config:   map[string]any{},
+			},
+			baseID: "test-project-db",
+			want: []importAddress{
+				{
+					Address: "module.resources[\"db\"].module.aws_postgres[0].aws_db_instance.this",
+					ID:      "test-project-db",
+				},
+			},
+			wantErr: false,
+		},
+		"AWS S3 bucket": {
+			projectName: "test-project",
+			resource: &appdef.Resource{
+				Name:     "media",
+				Type:     appdef.ResourceTypeS3,
+				Provider: appdef.ResourceProviderAWS,
+				Config:   map[string]any{},
+			},
+			baseID: "test-project-media",
+			want: []importAddress{
+				{
+					Address: "module.resources[\"media\"].module.aws_bucket[0].aws_s3_bucket.this",
+					ID:      "test-project-media",
+				},
+			},
+			wantErr: false,
+		},
+		"AWS with unsupported resource type": {
+			projectName: "test-project",
+			resource: &appdef.Resource{
+				Name:     "cache",
+				Type:     appdef.ResourceTypeSQLite,
+				Provider: appdef.ResourceProviderAWS,
+				Config:   map[string]any{},
+			},
+			baseID:  "test-project-cache",
+			want:    nil,
+			wantErr: true,
+		},
 		"Turso with unsupported resource type": {
 			projectName: "test-project",
 			resource: &appdef.Resource{
@@ -436,6 +557,108 @@ func TestBuildAppImportAddresses(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		"Hetzner Server (vm)": {
+			projectName: "test-project",
+			app: &appdef.App{
+				Name: "api",
+				Infra: appdef.Infra{
+					Provider: appdef.ResourceProviderHetzner,
+					Type:     "vm",
+					Config:   map[string]any{},
+				},
+			},
+			appID: "12345",
+			want: []importAddress{
+				{
+					Address: "module.apps[\"api\"].module.hetzner_server[0].hcloud_server.this",
+					ID:      "12345",
+				},
+			},
+			wantErr: false,
+		},
+		"Hetzner Server With Related Resources": {
+			projectName: "test-project",
+			app: &appdef.App{
+				Name: "api",
+				Infra: appdef.Infra{
+					Provider: appdef.ResourceProviderHetzner,
+					Type:     "vm",
+					Config: map[string]any{
+						"volume_size":                     float64(20),
+						"ssh_key_id":                      "11",
+						"firewall_id":                     "22",
+						"volume_id":                       "33",
+						"ipv4_address":                    "203.0.113.10",
+						"ipv6_address":                    "2001:db8::1",
+						"dns_record:api.example.com/A":    "rec-a",
+						"dns_record:api.example.com/AAAA": "rec-aaaa",
+						"dns_record:*.api.example.com/A":  "rec-wildcard",
+						"dns_record:api.example.org/A":    "cf-zone/cf-a",
+					},
+				},
+				Domains: []appdef.Domain{
+					{Name: "api.example.com", Type: appdef.DomainTypePrimary, Wildcard: true},
+					{Name: "api.example.org", Type: appdef.DomainTypeAlias, DNS: appdef.DNSProviderCloudflare},
+					{Name: "api.example.net", Type: appdef.DomainTypeUnmanaged},
+				},
+			},
+			appID: "12345",
+			want: []importAddress{
+				{Address: `module.apps["api"].module.hetzner_server[0].hcloud_server.this`, ID: "12345"},
+				{Address: `module.apps["api"].module.hetzner_server[0].hcloud_ssh_key.this`, ID: "11"},
+				{Address: `module.apps["api"].module.hetzner_server[0].hcloud_firewall.this[0]`, ID: "22"},
+				{Address: `module.apps["api"].module.hetzner_server[0].hcloud_firewall_attachment.this[0]`, ID: "22"},
+				{Address: `module.apps["api"].module.hetzner_server[0].hcloud_rdns.ipv4[0]`, ID: "s-12345-203.0.113.10"},
+				{Address: `module.apps["api"].module.hetzner_server[0].hcloud_rdns.ipv6[0]`, ID: "s-12345-2001:db8::1"},
+				{Address: `module.apps["api"].module.hetzner_volume[0].hcloud_volume.this`, ID: "33"},
+				{Address: `module.apps["api"].module.hetzner_volume[0].hcloud_volume_attachment.this`, ID: "33"},
+				{Address: `module.apps["api"].module.hetzner_dns_record["api.example.com/A"].hetznerdns_record.this`, ID: "rec-a"},
+				{Address: `module.apps["api"].module.hetzner_dns_record["api.example.com/AAAA"].hetznerdns_record.this`, ID: "rec-aaaa"},
+				{Address: `module.apps["api"].module.hetzner_dns_record["*.api.example.com/A"].hetznerdns_record.this`, ID: "rec-wildcard"},
+				{Address: `module.apps["api"].module.cloudflare_dns_record["api.example.org/A"].cloudflare_dns_record.this`, ID: "cf-zone/cf-a"},
+			},
+			wantErr: false,
+		},
+		"Hetzner Server Skips Resources Not Created": {
+			projectName: "test-project",
+			app: &appdef.App{
+				Name: "api",
+				Infra: appdef.Infra{
+					Provider: appdef.ResourceProviderHetzner,
+					Type:     "vm",
+					Config: map[string]any{
+						"firewall_id":  "22",
+						"volume_id":    "33",
+						"ipv4_address": "203.0.113.10",
+					},
+				},
+				Domains: []appdef.Domain{
+					{Name: "api.example.com", Type: appdef.DomainTypeAlias},
+				},
+				Network: appdef.Network{
+					Inbound: []appdef.NetworkRule{{Port: 443}},
+				},
+			},
+			appID: "12345",
+			want: []importAddress{
+				{Address: `module.apps["api"].module.hetzner_server[0].hcloud_server.this`, ID: "12345"},
+			},
+			wantErr: false,
+		},
+		"Hetzner unsupported platform type": {
+			projectName: "test-project",
+			app: &appdef.App{
+				Name: "web",
+				Infra: appdef.Infra{
+					Provider: appdef.ResourceProviderHetzner,
+					Type:     "container",
+					Config:   map[string]any{},
+				},
+			},
+			appID:   "12345",
+			want:    nil,
+			wantErr: true,
+		},
 		"Unsupported provider": {
 			projectName: "test-project",
 			app: &appdef.App{
@@ -0,0 +1,66 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/ainsleydev/webkit/pkg/env"
+	"github.com/ainsleydev/webkit/pkg/storage"
+)
+
+// StateVersion identifies a single revision of the remote Terraform
+// state. Terraform increments the serial on every write, and the
+// lineage changes whenever the state is recreated from scratch.
+type StateVersion struct {
+	Lineage string `json:"lineage"`
+	Serial  int    `json:"serial"`
+}
+
+// String implements fmt.Stringer on StateVersion.
+func (v StateVersion) String() string {
+	return fmt.Sprintf("%s-%d", v.Lineage, v.Serial)
+}
+
+// newStateStorage creates the storage provider for the state backend,
+// replaced in tests.
+var newStateStorage = func(ctx context.Context, cfg storage.S3Config) (storage.Provider, error) {
+	return storage.NewS3Storage(ctx, cfg)
+}
+
+// RemoteStateVersion reads the lineage and serial of the environment's
+// state straight from the backend bucket. It is far cheaper than
+// initialising Terraform, so it can be used to tell whether anything
+// derived from the state, such as outputs, is stale.
+func RemoteStateVersion(ctx context.Context, projectName string, environment env.Environment) (StateVersion, error) {
+	tfEnv, err := ParseTFEnvironment()
+	if err != nil {
+		return StateVersion{}, errors.Wrap(err, "validating terraform environment variables")
+	}
+
+	provider, err := newStateStorage(ctx, storage.S3Config{
+		Bucket:          tfEnv.BackBlazeBucket,
+		Region:          backendRegion,
+		AccessKeyID:     tfEnv.BackBlazeKeyID,
+		SecretAccessKey: tfEnv.BackBlazeApplicationKey,
+		Endpoint:        backendEndpoint,
+	})
+	if err != nil {
+		return StateVersion{}, errors.Wrap(err, "creating state storage")
+	}
+
+	reader, err := provider.Download(ctx, stateKey(projectName, environment))
+	if err != nil {
+		return StateVersion{}, errors.Wrap(err, "downloading state")
+	}
+	defer reader.Close()
+
+	var version StateVersion
+	if err = json.NewDecoder(reader).Decode(&version); err != nil {
+		return StateVersion{}, errors.Wrap(err, "decoding state")
+	}
+
+	return version, nil
+}
@@ -0,0 +1,73 @@
+//go:build !race
+
+package infra
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/pkg/env"
+	"github.com/ainsleydev/webkit/pkg/storage"
+)
+
+func TestStateVersion_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "abc-4", StateVersion{Lineage: "abc", Serial: 4}.String())
+}
+
+func TestRemoteStateVersion(t *testing.T) {
+	setupStorage := func(t *testing.T, provider storage.Provider, err error) {
+		t.Helper()
+		setupEnv(t)
+
+		orig := newStateStorage
+		t.Cleanup(func() {
+			newStateStorage = orig
+		})
+		newStateStorage = func(_ context.Context, cfg storage.S3Config) (storage.Provider, error) {
+			assert.Equal(t, "bucket", cfg.Bucket)
+			assert.Equal(t, backendEndpoint, cfg.Endpoint)
+			return provider, err
+		}
+	}
+
+	t.Run("Storage Error", func(t *testing.T) {
+		setupStorage(t, nil, errors.New("storage error"))
+
+		_, err := RemoteStateVersion(t.Context(), "project", env.Production)
+		assert.ErrorContains(t, err, "creating state storage")
+	})
+
+	t.Run("Missing State", func(t *testing.T) {
+		setupStorage(t, storage.NewInMemory(), nil)
+
+		_, err := RemoteStateVersion(t.Context(), "project", env.Production)
+		assert.ErrorContains(t, err, "downloading state")
+	})
+
+	t.Run("Invalid State", func(t *testing.T) {
+		provider := storage.NewInMemory()
+		require.NoError(t, provider.Upload(t.Context(), "project/production/terraform.tfstate", strings.NewReader("invalid")))
+		setupStorage(t, provider, nil)
+
+		_, err := RemoteStateVersion(t.Context(), "project", env.Production)
+		assert.ErrorContains(t, err, "decoding state")
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		provider := storage.NewInMemory()
+		state := `{"version": 4, "serial": 12, "lineage": "abc-123", "outputs": {}}`
+		require.NoError(t, provider.Upload(t.Context(), "project/staging/terraform.tfstate", strings.NewReader(state)))
+		setupStorage(t, provider, nil)
+
+		got, err := RemoteStateVersion(t.Context(), "project", env.Staging)
+		require.NoError(t, err)
+		assert.Equal(t, StateVersion{Lineage: "abc-123", Serial: 12}, got)
+	})
+}
@@ -0,0 +1,66 @@
+package infra
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+)
+
+// Targets limits a plan or apply to a subset of the apps and
+// resources defined in app.json.
+type Targets struct {
+	// Apps are the names of the apps in app.json to target.
+	Apps []string
+	// Resources are the names of the resources in app.json to target.
+	Resources []string
+}
+
+// IsEmpty returns true if no apps or resources have been
+// targeted, meaning the whole project is planned or applied.
+func (t Targets) IsEmpty() bool {
+	return len(t.Apps) == 0 && len(t.Resources) == 0
+}
+
+// WithTargets limits Plan and Apply to the modules of the given
+// apps and resources, which are passed to Terraform as -target
+// addresses.
+func WithTargets(targets Targets) Option {
+	return func(t *Terraform) {
+		t.targets = targets
+	}
+}
+
+// TargetAddresses translates the app and resource names in targets
+// into Terraform -target addresses, using the same module addresses
+// that imports are built from.
+//
+// Returns an error if a name cannot be found in the definition.
+func TargetAddresses(def *appdef.Definition, targets Targets) ([]string, error) {
+	addresses := make([]string, 0, len(targets.Apps)+len(targets.Resources))
+
+	for _, name := range targets.Apps {
+		if !slices.ContainsFunc(def.Apps, func(app appdef.App) bool { return app.Name == name }) {
+			return nil, fmt.Errorf("app %q not found in app.json", name)
+		}
+		addresses = append(addresses, appModuleAddress(name))
+	}
+
+	for _, name := range targets.Resources {
+		if !slices.ContainsFunc(def.Resources, func(res appdef.Resource) bool { return res.Name == name }) {
+			return nil, fmt.Errorf("resource %q not found in app.json", name)
+		}
+		addresses = append(addresses, resourceModuleAddress(name))
+	}
+
+	return addresses, nil
+}
+
+// targetAddresses returns the -target addresses for
+// the manager's targets, if any.
+func (t *Terraform) targetAddresses() ([]string, error) {
+	if t.targets.IsEmpty() {
+		return nil, nil
+	}
+	return TargetAddresses(t.appDef, t.targets)
+}
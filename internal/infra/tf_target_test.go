@@ -0,0 +1,84 @@
+package infra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+)
+
+func TestTargets_IsEmpty(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Targets{}.IsEmpty())
+	assert.False(t, Targets{Apps: []string{"web"}}.IsEmpty())
+	assert.False(t, Targets{Resources: []string{"db"}}.IsEmpty())
+}
+
+func TestTargetAddresses(t *testing.T) {
+	t.Parallel()
+
+	def := &appdef.Definition{
+		Apps:      []appdef.App{{Name: "web"}, {Name: "cms"}},
+		Resources: []appdef.Resource{{Name: "db"}},
+	}
+
+	tt := map[string]struct {
+		input   Targets
+		want    []string
+		wantErr string
+	}{
+		"Empty": {
+			input: Targets{},
+			want:  []string{},
+		},
+		"Apps And Resources": {
+			input: Targets{Apps: []string{"web", "cms"}, Resources: []string{"db"}},
+			want: []string{
+				`module.apps["web"]`,
+				`module.apps["cms"]`,
+				`module.resources["db"]`,
+			},
+		},
+		"App Not Found": {
+			input:   Targets{Apps: []string{"api"}},
+			wantErr: `app "api" not found in app.json`,
+		},
+		"Resource Not Found": {
+			input:   Targets{Resources: []string{"cache"}},
+			wantErr: `resource "cache" not found in app.json`,
+		},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := TargetAddresses(def, test.input)
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestTargetAddresses_MatchImports(t *testing.T) {
+	t.Parallel()
+
+	resource := &appdef.Resource{Name: "db", Type: appdef.ResourceTypePostgres, Provider: appdef.ResourceProviderDigitalOcean}
+	def := &appdef.Definition{Resources: []appdef.Resource{*resource}}
+
+	targets, err := TargetAddresses(def, Targets{Resources: []string{"db"}})
+	assert.NoError(t, err)
+
+	imports, err := buildImportAddresses("project", resource, "cluster-id")
+	assert.NoError(t, err)
+
+	for _, addr := range imports {
+		assert.Contains(t, addr.Address, targets[0], "Import addresses should sit within the targeted module")
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/ainsleydev/webkit/internal/appdef"
 	"github.com/ainsleydev/webkit/internal/infra/internal/tfmocks"
 	"github.com/ainsleydev/webkit/internal/mocks"
+	"github.com/ainsleydev/webkit/internal/registry"
 	"github.com/ainsleydev/webkit/internal/state/manifest"
 	"github.com/ainsleydev/webkit/internal/util/executil"
 	"github.com/ainsleydev/webkit/pkg/env"
@@ -72,10 +73,16 @@ func teardownEnv(t *testing.T) {
 		"DO_SPACES_ACCESS_KEY",
 		"DO_SPACES_SECRET_KEY",
 		"HETZNER_TOKEN",
+		"HETZNER_DNS_TOKEN",
 		"BACK_BLAZE_BUCKET",
 		"BACK_BLAZE_KEY_ID",
 		"BACK_BLAZE_APPLICATION_KEY",
 		"TURSO_TOKEN",
+		"CLOUDFLARE_API_TOKEN",
+		"CLOUDFLARE_ACCOUNT_ID",
+		"AWS_ACCESS_KEY_ID",
+		"AWS_SECRET_ACCESS_KEY",
+		"AWS_REGION",
 		"GITHUB_TOKEN",
 		"GITHUB_TOKEN_CLASSIC",
 		"SLACK_BOT_TOKEN",
@@ -123,6 +130,16 @@ func TestNewTerraform(t *testing.T) {
 		assert.NotEmpty(t, got.env)
 		assert.NotEmpty(t, got.path)
 		assert.Contains(t, got.path, "terraform")
+		assert.Equal(t, env.Production, got.backendEnv)
+	})
+
+	t.Run("With Environment", func(t *testing.T) {
+		setupEnv(t)
+		defer teardownEnv(t)
+
+		got, err := NewTerraform(t.Context(), &appdef.Definition{}, manifest.NewTracker(), WithEnvironment(env.Staging))
+		require.NoError(t, err)
+		assert.Equal(t, env.Staging, got.backendEnv)
 	})
 }
 
@@ -298,6 +315,19 @@ func TestTerraform_Plan(t *testing.T) {
 		assert.ErrorContains(t, err, "show plan file error")
 	})
 
+	t.Run("Invalid Target", func(t *testing.T) {
+		tf, teardown := setup(t, appDef)
+		defer teardown()
+
+		err := tf.Init(t.Context())
+		require.NoError(t, err)
+
+		WithTargets(Targets{Resources: []string{"missing"}})(tf)
+
+		_, err = tf.Plan(t.Context(), env.Production, false)
+		assert.ErrorContains(t, err, `resource "missing" not found in app.json`)
+	})
+
 	t.Run("Success", func(t *testing.T) {
 		tf, teardown := setup(t, appDef)
 		defer teardown()
@@ -736,7 +766,7 @@ func TestTerraform_DetermineImageTag(t *testing.T) {
 
 		tf := &Terraform{
 			appDef:   appDef,
-			ghClient: mockClient,
+			registry: testRegistry(appDef, mockClient),
 		}
 
 		t.Setenv("GITHUB_SHA", "abc123def456")
@@ -765,7 +795,7 @@ func TestTerraform_DetermineImageTag(t *testing.T) {
 
 		tf := &Terraform{
 			appDef:   appDef,
-			ghClient: mockClient,
+			registry: testRegistry(appDef, mockClient),
 		}
 
 		tag := tf.determineImageTag(context.Background(), "web")
@@ -792,10 +822,38 @@ func TestTerraform_DetermineImageTag(t *testing.T) {
 
 		tf := &Terraform{
 			appDef:   appDef,
-			ghClient: mockClient,
+			registry: testRegistry(appDef, mockClient),
 		}
 
 		tag := tf.determineImageTag(context.Background(), "web")
 		assert.Equal(t, "latest", tag)
 	})
 }
+
+func TestTerraform_VarStrings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Registry Credentials", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		reg := mocks.NewMockRegistry(ctrl)
+		reg.EXPECT().Credentials().Return(registry.Credentials{Username: "owner", Password: "token"})
+
+		tf := &Terraform{registry: reg}
+		assert.Contains(t, tf.varStrings(), "registry_credentials=owner:token")
+	})
+
+	t.Run("No Registry Credentials", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		reg := mocks.NewMockRegistry(ctrl)
+		reg.EXPECT().Credentials().Return(registry.Credentials{})
+
+		tf := &Terraform{registry: reg}
+		for _, v := range tf.varStrings() {
+			assert.NotContains(t, v, "registry_credentials")
+		}
+	})
+}
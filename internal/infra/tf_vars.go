@@ -50,6 +50,7 @@ type (
 		PlatformType     string         `json:"platform_type"`
 		PlatformProvider string         `json:"platform_provider"`
 		Config           map[string]any `json:"config"`
+		Network          *tfNetwork     `json:"network,omitempty"`
 	}
 	// tfApp represents an application in Terraform variable format.
 	tfApp struct {
@@ -59,9 +60,12 @@ type (
 		AppType          string         `json:"app_type"`
 		Path             string         `json:"path"`
 		ImageTag         string         `json:"image_tag,omitempty"`
+		Image            *tfImage       `json:"image,omitempty"`
+		Port             int            `json:"port,omitempty"`
 		Config           map[string]any `json:"config"`
 		Environment      []tfEnvVar     `json:"env_vars,omitempty"`
 		Domains          []tfDomain     `json:"domains,omitempty"`
+		Network          *tfNetwork     `json:"network,omitempty"`
 	}
 	// tfDomain represents a domain configuration for Terraform.
 	tfDomain struct {
@@ -69,6 +73,24 @@ type (
 		Type     string `json:"type"`
 		Zone     string `json:"zone,omitempty"`
 		Wildcard bool   `json:"wildcard,omitempty"`
+		DNS      string `json:"dns_provider,omitempty"`
+		Proxied  bool   `json:"proxied,omitempty"`
+	}
+	// tfNetwork represents the network block of an app or resource
+	// with every source split into CIDR blocks and app names, which
+	// Terraform resolves to droplet IDs, app IDs or IPs.
+	tfNetwork struct {
+		Inbound          []tfNetworkRule `json:"inbound"`
+		TrustedAddresses []string        `json:"trusted_addresses"`
+		TrustedApps      []string        `json:"trusted_apps"`
+		VPC              bool            `json:"vpc"`
+	}
+	// tfNetworkRule represents a single inbound firewall rule.
+	tfNetworkRule struct {
+		Port      int      `json:"port"`
+		Protocol  string   `json:"protocol"`
+		Addresses []string `json:"addresses"`
+		Apps      []string `json:"apps"`
 	}
 	// tfEnvVar represents an environment variable for Terraform
 	tfEnvVar struct {
@@ -77,8 +99,15 @@ type (
 		Source string `json:"source,omitempty"`
 		Scope  string `json:"type,omitempty"`
 	}
-	// tfGithubConfig is used to pull image containers from GH
-	// container registry.
+	// tfImage represents where App Platform pulls an app's image from,
+	// matching the image block of the DigitalOcean app spec.
+	tfImage struct {
+		RegistryType string `json:"registry_type"`
+		Registry     string `json:"registry,omitempty"`
+		Repository   string `json:"repository"`
+	}
+	// tfGithubConfig identifies the GitHub repository that secrets
+	// and variables are written to.
 	tfGithubConfig struct {
 		Owner string `json:"owner"`
 		Repo  string `json:"repo"`
@@ -227,6 +256,7 @@ func (t *Terraform) generateResources() []tfResource {
 			PlatformType:     res.Type.String(),
 			PlatformProvider: res.Provider.String(),
 			Config:           encodeConfigForTerraform(res.Config),
+			Network:          networkForTerraform(res.Network),
 		})
 	}
 	return resources
@@ -242,11 +272,14 @@ func (t *Terraform) generateApps(ctx context.Context, env env.Environment) []tfA
 			AppType:          app.Type.String(),
 			Config:           encodeConfigForTerraform(app.Infra.Config),
 			Path:             app.Path,
+			Port:             app.Build.Port,
+			Network:          networkForTerraform(app.Network),
 		}
 
-		// Determine the image tag for container-based apps.
+		// Determine the image and tag for container-based apps.
 		if app.Infra.Type == "container" {
 			tfA.ImageTag = t.determineImageTag(ctx, app.Name)
+			tfA.Image = imageForTerraform(t.appDef, app.Name)
 		}
 
 		app.MergeEnvironments(t.appDef.Shared.Env).
@@ -270,8 +303,10 @@ func (t *Terraform) generateApps(ctx context.Context, env env.Environment) []tfA
 			tfA.Domains = append(tfA.Domains, tfDomain{
 				Name:     domain.Name,
 				Type:     strings.ToUpper(domain.Type.String()),
-				Zone:     domain.Zone,
+				Zone:     domain.ZoneName(),
 				Wildcard: domain.Wildcard,
+				DNS:      domain.DNS.String(),
+				Proxied:  domain.Proxied,
 			})
 		}
 
@@ -280,6 +315,51 @@ func (t *Terraform) generateApps(ctx context.Context, env env.Environment) []tfA
 	return apps
 }
 
+// networkForTerraform transforms a network block into Terraform
+// variables, returning nil when nothing has been declared so the
+// provider defaults are kept. Rules without sources are open to
+// anyone.
+func networkForTerraform(network appdef.Network) *tfNetwork {
+	if network.IsZero() {
+		return nil
+	}
+
+	out := &tfNetwork{
+		Inbound:          make([]tfNetworkRule, 0, len(network.Inbound)),
+		TrustedAddresses: []string{},
+		TrustedApps:      []string{},
+		VPC:              network.VPC,
+	}
+
+	for _, rule := range network.Inbound {
+		addresses, apps := appdef.SplitNetworkSources(rule.Sources)
+		if len(rule.Sources) == 0 {
+			addresses = appdef.NetworkAnywhere
+		}
+		out.Inbound = append(out.Inbound, tfNetworkRule{
+			Port:      rule.Port,
+			Protocol:  rule.ProtocolOrDefault(),
+			Addresses: nonNilStrings(addresses),
+			Apps:      nonNilStrings(apps),
+		})
+	}
+
+	addresses, apps := appdef.SplitNetworkSources(network.TrustedSources)
+	out.TrustedAddresses = nonNilStrings(addresses)
+	out.TrustedApps = nonNilStrings(apps)
+
+	return out
+}
+
+// nonNilStrings returns an empty slice in place of nil so that
+// lists are encoded as [] rather than null.
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
 func (t *Terraform) generateMonitors(e env.Environment) []tfMonitor {
 	appDefMonitors := t.appDef.GenerateMonitors()
 	monitors := make([]tfMonitor, len(appDefMonitors))
@@ -343,3 +423,26 @@ func stringPtrOrNil(s string) *string {
 	}
 	return &s
 }
+
+// imageForTerraform returns where App Platform pulls an app's image
+// from. App Platform names the registry differently to Docker, so
+// DOCR repositories are relative to the account's registry and Docker
+// Hub repositories are relative to the namespace.
+func imageForTerraform(def *appdef.Definition, app string) *tfImage {
+	cfg := def.Infra.Registry
+	name := appdef.ImageName(def.Project.Repo, app)
+	namespace := cfg.NamespaceOrOwner(def.Project.Repo.Owner)
+
+	switch cfg.Type {
+	case appdef.RegistryTypeDOCR:
+		return &tfImage{RegistryType: "DOCR", Repository: name}
+	case appdef.RegistryTypeDockerHub:
+		return &tfImage{RegistryType: "DOCKER_HUB", Registry: namespace, Repository: name}
+	case appdef.RegistryTypeCustom:
+		// App Platform can't pull from self-hosted registries,
+		// which is caught when validating app.json.
+		return nil
+	default:
+		return &tfImage{RegistryType: "GHCR", Registry: cfg.Hostname(), Repository: namespace + "/" + name}
+	}
+}
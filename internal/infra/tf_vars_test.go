@@ -12,7 +12,9 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/ghapi"
 	"github.com/ainsleydev/webkit/internal/mocks"
+	"github.com/ainsleydev/webkit/internal/registry"
 	"github.com/ainsleydev/webkit/pkg/env"
 	"github.com/ainsleydev/webkit/pkg/util/ptr"
 )
@@ -32,10 +34,22 @@ func setupTfVars(t *testing.T, appDef *appdef.Definition) *Terraform {
 	return &Terraform{
 		appDef:   appDef,
 		fs:       afero.NewMemMapFs(),
-		ghClient: mockClient,
+		registry: testRegistry(appDef, mockClient),
 	}
 }
 
+// testRegistry returns the registry of the definition, looking up GHCR
+// tags with the mock client.
+func testRegistry(appDef *appdef.Definition, client ghapi.Client) registry.Registry {
+	if appDef == nil {
+		return nil
+	}
+	return registry.New(appDef,
+		registry.WithGitHubClient(client),
+		registry.WithCredentials(registry.Credentials{}),
+	)
+}
+
 func TestTFVarsFromDefinition(t *testing.T) {
 	t.Run("Nil Definition", func(t *testing.T) {
 		tf := setupTfVars(t, nil)
@@ -336,9 +350,10 @@ func TestTFVarsFromDefinition(t *testing.T) {
 			},
 			Apps: []appdef.App{
 				{
-					Name: "web",
-					Type: appdef.AppTypeSvelteKit,
-					Path: "apps/web",
+					Name:  "web",
+					Type:  appdef.AppTypeSvelteKit,
+					Path:  "apps/web",
+					Build: appdef.Build{Port: 3001},
 					Infra: appdef.Infra{
 						Type:     "app",
 						Provider: appdef.ResourceProviderDigitalOcean,
@@ -358,6 +373,8 @@ func TestTFVarsFromDefinition(t *testing.T) {
 							Type:     appdef.DomainTypeAlias,
 							Zone:     "example.com",
 							Wildcard: false,
+							DNS:      appdef.DNSProviderCloudflare,
+							Proxied:  true,
 						},
 						{
 							Name:     "*.staging.example.com",
@@ -365,6 +382,10 @@ func TestTFVarsFromDefinition(t *testing.T) {
 							Zone:     "staging.example.com",
 							Wildcard: true,
 						},
+						{
+							Name: "shop.example.co.uk",
+							Type: appdef.DomainTypeAlias,
+						},
 					},
 				},
 			},
@@ -380,8 +401,9 @@ func TestTFVarsFromDefinition(t *testing.T) {
 
 			app := got.Apps[0]
 			assert.Equal(t, "web", app.Name)
+			assert.Equal(t, 3001, app.Port)
 
-			require.Len(t, app.Domains, 3)
+			require.Len(t, app.Domains, 4)
 			assert.Equal(t, "example.com", app.Domains[0].Name)
 			assert.Equal(t, "PRIMARY", app.Domains[0].Type)
 			assert.Equal(t, "example.com", app.Domains[0].Zone)
@@ -391,11 +413,16 @@ func TestTFVarsFromDefinition(t *testing.T) {
 			assert.Equal(t, "ALIAS", app.Domains[1].Type)
 			assert.Equal(t, "example.com", app.Domains[1].Zone)
 			assert.Equal(t, false, app.Domains[1].Wildcard)
+			assert.Equal(t, "cloudflare", app.Domains[1].DNS)
+			assert.True(t, app.Domains[1].Proxied)
 
 			assert.Equal(t, "*.staging.example.com", app.Domains[2].Name)
 			assert.Equal(t, "PRIMARY", app.Domains[2].Type)
 			assert.Equal(t, "staging.example.com", app.Domains[2].Zone)
 			assert.Equal(t, true, app.Domains[2].Wildcard)
+
+			assert.Equal(t, "shop.example.co.uk", app.Domains[3].Name)
+			assert.Equal(t, "example.co.uk", app.Domains[3].Zone, "Zone should be computed from the public suffix list")
 		}
 	})
 
@@ -873,6 +900,62 @@ func TestEncodeConfigForTerraform(t *testing.T) {
 	}
 }
 
+func TestNetworkForTerraform(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]struct {
+		input appdef.Network
+		want  *tfNetwork
+	}{
+		"Nothing Declared": {
+			input: appdef.Network{},
+			want:  nil,
+		},
+		"VPC Only": {
+			input: appdef.Network{VPC: true},
+			want: &tfNetwork{
+				Inbound:          []tfNetworkRule{},
+				TrustedAddresses: []string{},
+				TrustedApps:      []string{},
+				VPC:              true,
+			},
+		},
+		"Inbound Rules": {
+			input: appdef.Network{
+				Inbound: []appdef.NetworkRule{
+					{Port: 443},
+					{Port: 22, Sources: []string{"203.0.113.10"}},
+					{Port: 8080, Protocol: "udp", Sources: []string{"worker", "10.0.0.0/8"}},
+				},
+			},
+			want: &tfNetwork{
+				Inbound: []tfNetworkRule{
+					{Port: 443, Protocol: "tcp", Addresses: []string{"0.0.0.0/0", "::/0"}, Apps: []string{}},
+					{Port: 22, Protocol: "tcp", Addresses: []string{"203.0.113.10/32"}, Apps: []string{}},
+					{Port: 8080, Protocol: "udp", Addresses: []string{"10.0.0.0/8"}, Apps: []string{"worker"}},
+				},
+				TrustedAddresses: []string{},
+				TrustedApps:      []string{},
+			},
+		},
+		"Trusted Sources": {
+			input: appdef.Network{TrustedSources: []string{"web", "192.168.1.1"}},
+			want: &tfNetwork{
+				Inbound:          []tfNetworkRule{},
+				TrustedAddresses: []string{"192.168.1.1/32"},
+				TrustedApps:      []string{"web"},
+			},
+		},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.want, networkForTerraform(test.input))
+		})
+	}
+}
+
 func TestEncodeConfigValue(t *testing.T) {
 	t.Parallel()
 
@@ -965,7 +1048,7 @@ func TestTerraform_TFVarsFromDefinition_ImageTag(t *testing.T) {
 		tf := &Terraform{
 			appDef:   input,
 			fs:       afero.NewMemMapFs(),
-			ghClient: mockClient,
+			registry: testRegistry(input, mockClient),
 		}
 
 		// Ensure GITHUB_SHA is not set.
@@ -1248,3 +1331,41 @@ func TestGenerateMonitors(t *testing.T) {
 		assert.Equal(t, "PROD_CODEBASE_BACKUP_PING_URL", monitors[3].VariableName)
 	})
 }
+
+func TestImageForTerraform(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]struct {
+		input appdef.Registry
+		want  *tfImage
+	}{
+		"GHCR": {
+			input: appdef.Registry{Type: appdef.RegistryTypeGHCR},
+			want:  &tfImage{RegistryType: "GHCR", Registry: "ghcr.io", Repository: "ainsleydev/website-web"},
+		},
+		"DOCR": {
+			input: appdef.Registry{Type: appdef.RegistryTypeDOCR, Namespace: "acme"},
+			want:  &tfImage{RegistryType: "DOCR", Repository: "website-web"},
+		},
+		"Docker Hub": {
+			input: appdef.Registry{Type: appdef.RegistryTypeDockerHub, Namespace: "acme"},
+			want:  &tfImage{RegistryType: "DOCKER_HUB", Registry: "acme", Repository: "website-web"},
+		},
+		"Custom": {
+			input: appdef.Registry{Type: appdef.RegistryTypeCustom, Host: "registry.example.com"},
+			want:  nil,
+		},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			def := &appdef.Definition{
+				Project: appdef.Project{Repo: appdef.GitHubRepo{Owner: "ainsleydev", Name: "website"}},
+				Infra:   appdef.ProjectInfra{Registry: test.input},
+			}
+			assert.Equal(t, test.want, imageForTerraform(def, "web"))
+		})
+	}
+}
@@ -40,6 +40,20 @@ func (m *GHClient) EXPECT() *GHClientMockRecorder {
 	return m.recorder
 }
 
+// DispatchWorkflow mocks base method.
+func (m *GHClient) DispatchWorkflow(ctx context.Context, owner, repo, workflowFile, ref string, inputs map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DispatchWorkflow", ctx, owner, repo, workflowFile, ref, inputs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DispatchWorkflow indicates an expected call of DispatchWorkflow.
+func (mr *GHClientMockRecorder) DispatchWorkflow(ctx, owner, repo, workflowFile, ref, inputs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchWorkflow", reflect.TypeOf((*GHClient)(nil).DispatchWorkflow), ctx, owner, repo, workflowFile, ref, inputs)
+}
+
 // GetFileContent mocks base method.
 func (m *GHClient) GetFileContent(ctx context.Context, owner, repo, path, ref string) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -84,3 +98,17 @@ func (mr *GHClientMockRecorder) GetLatestSHATag(ctx, owner, repo, appName any) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestSHATag", reflect.TypeOf((*GHClient)(nil).GetLatestSHATag), ctx, owner, repo, appName)
 }
+
+// SetRepoSecret mocks base method.
+func (m *GHClient) SetRepoSecret(ctx context.Context, owner, repo, name, value string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRepoSecret", ctx, owner, repo, name, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRepoSecret indicates an expected call of SetRepoSecret.
+func (mr *GHClientMockRecorder) SetRepoSecret(ctx, owner, repo, name, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRepoSecret", reflect.TypeOf((*GHClient)(nil).SetRepoSecret), ctx, owner, repo, name, value)
+}
@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: registry.go
+//
+// Generated by this command:
+//
+//	mockgen -source=registry.go -destination ../mocks/registry.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	registry "github.com/ainsleydev/webkit/internal/registry"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRegistry is a mock of Registry interface.
+type MockRegistry struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegistryMockRecorder
+	isgomock struct{}
+}
+
+// MockRegistryMockRecorder is the mock recorder for MockRegistry.
+type MockRegistryMockRecorder struct {
+	mock *MockRegistry
+}
+
+// NewMockRegistry creates a new mock instance.
+func NewMockRegistry(ctrl *gomock.Controller) *MockRegistry {
+	mock := &MockRegistry{ctrl: ctrl}
+	mock.recorder = &MockRegistryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegistry) EXPECT() *MockRegistryMockRecorder {
+	return m.recorder
+}
+
+// Credentials mocks base method.
+func (m *MockRegistry) Credentials() registry.Credentials {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Credentials")
+	ret0, _ := ret[0].(registry.Credentials)
+	return ret0
+}
+
+// Credentials indicates an expected call of Credentials.
+func (mr *MockRegistryMockRecorder) Credentials() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Credentials", reflect.TypeOf((*MockRegistry)(nil).Credentials))
+}
+
+// Host mocks base method.
+func (m *MockRegistry) Host() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Host")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Host indicates an expected call of Host.
+func (mr *MockRegistryMockRecorder) Host() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Host", reflect.TypeOf((*MockRegistry)(nil).Host))
+}
+
+// Image mocks base method.
+func (m *MockRegistry) Image(app string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Image", app)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Image indicates an expected call of Image.
+func (mr *MockRegistryMockRecorder) Image(app any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Image", reflect.TypeOf((*MockRegistry)(nil).Image), app)
+}
+
+// LatestSHATag mocks base method.
+func (m *MockRegistry) LatestSHATag(ctx context.Context, app string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestSHATag", ctx, app)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestSHATag indicates an expected call of LatestSHATag.
+func (mr *MockRegistryMockRecorder) LatestSHATag(ctx, app any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestSHATag", reflect.TypeOf((*MockRegistry)(nil).LatestSHATag), ctx, app)
+}
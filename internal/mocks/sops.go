@@ -12,6 +12,7 @@ package mocks
 import (
 	reflect "reflect"
 
+	sops "github.com/ainsleydev/webkit/internal/secrets/sops"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -142,3 +143,162 @@ func (mr *MockEncrypterDecrypterMockRecorder) Encrypt(filePath any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encrypt", reflect.TypeOf((*MockEncrypterDecrypter)(nil).Encrypt), filePath)
 }
+
+// MockDataEncrypter is a mock of DataEncrypter interface.
+type MockDataEncrypter struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataEncrypterMockRecorder
+	isgomock struct{}
+}
+
+// MockDataEncrypterMockRecorder is the mock recorder for MockDataEncrypter.
+type MockDataEncrypterMockRecorder struct {
+	mock *MockDataEncrypter
+}
+
+// NewMockDataEncrypter creates a new mock instance.
+func NewMockDataEncrypter(ctrl *gomock.Controller) *MockDataEncrypter {
+	mock := &MockDataEncrypter{ctrl: ctrl}
+	mock.recorder = &MockDataEncrypterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataEncrypter) EXPECT() *MockDataEncrypterMockRecorder {
+	return m.recorder
+}
+
+// EncryptData mocks base method.
+func (m *MockDataEncrypter) EncryptData(plaintext []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EncryptData", plaintext)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EncryptData indicates an expected call of EncryptData.
+func (mr *MockDataEncrypterMockRecorder) EncryptData(plaintext any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EncryptData", reflect.TypeOf((*MockDataEncrypter)(nil).EncryptData), plaintext)
+}
+
+// MockDataDecrypter is a mock of DataDecrypter interface.
+type MockDataDecrypter struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataDecrypterMockRecorder
+	isgomock struct{}
+}
+
+// MockDataDecrypterMockRecorder is the mock recorder for MockDataDecrypter.
+type MockDataDecrypterMockRecorder struct {
+	mock *MockDataDecrypter
+}
+
+// NewMockDataDecrypter creates a new mock instance.
+func NewMockDataDecrypter(ctrl *gomock.Controller) *MockDataDecrypter {
+	mock := &MockDataDecrypter{ctrl: ctrl}
+	mock.recorder = &MockDataDecrypterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataDecrypter) EXPECT() *MockDataDecrypterMockRecorder {
+	return m.recorder
+}
+
+// DecryptData mocks base method.
+func (m *MockDataDecrypter) DecryptData(ciphertext []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecryptData", ciphertext)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecryptData indicates an expected call of DecryptData.
+func (mr *MockDataDecrypterMockRecorder) DecryptData(ciphertext any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecryptData", reflect.TypeOf((*MockDataDecrypter)(nil).DecryptData), ciphertext)
+}
+
+// MockFileDataDecrypter is a mock of FileDataDecrypter interface.
+type MockFileDataDecrypter struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileDataDecrypterMockRecorder
+	isgomock struct{}
+}
+
+// MockFileDataDecrypterMockRecorder is the mock recorder for MockFileDataDecrypter.
+type MockFileDataDecrypterMockRecorder struct {
+	mock *MockFileDataDecrypter
+}
+
+// NewMockFileDataDecrypter creates a new mock instance.
+func NewMockFileDataDecrypter(ctrl *gomock.Controller) *MockFileDataDecrypter {
+	mock := &MockFileDataDecrypter{ctrl: ctrl}
+	mock.recorder = &MockFileDataDecrypterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileDataDecrypter) EXPECT() *MockFileDataDecrypterMockRecorder {
+	return m.recorder
+}
+
+// DecryptFileData mocks base method.
+func (m *MockFileDataDecrypter) DecryptFileData(filePath string, ciphertext []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecryptFileData", filePath, ciphertext)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecryptFileData indicates an expected call of DecryptFileData.
+func (mr *MockFileDataDecrypterMockRecorder) DecryptFileData(filePath, ciphertext any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecryptFileData", reflect.TypeOf((*MockFileDataDecrypter)(nil).DecryptFileData), filePath, ciphertext)
+}
+
+// MockKeyScoper is a mock of KeyScoper interface.
+type MockKeyScoper struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyScoperMockRecorder
+	isgomock struct{}
+}
+
+// MockKeyScoperMockRecorder is the mock recorder for MockKeyScoper.
+type MockKeyScoperMockRecorder struct {
+	mock *MockKeyScoper
+}
+
+// NewMockKeyScoper creates a new mock instance.
+func NewMockKeyScoper(ctrl *gomock.Controller) *MockKeyScoper {
+	mock := &MockKeyScoper{ctrl: ctrl}
+	mock.recorder = &MockKeyScoperMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyScoper) EXPECT() *MockKeyScoperMockRecorder {
+	return m.recorder
+}
+
+// WithKeys mocks base method.
+func (m *MockKeyScoper) WithKeys(keys ...string) sops.EncrypterDecrypter {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WithKeys", varargs...)
+	ret0, _ := ret[0].(sops.EncrypterDecrypter)
+	return ret0
+}
+
+// WithKeys indicates an expected call of WithKeys.
+func (mr *MockKeyScoperMockRecorder) WithKeys(keys ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithKeys", reflect.TypeOf((*MockKeyScoper)(nil).WithKeys), keys...)
+}
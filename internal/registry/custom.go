@@ -0,0 +1,19 @@
+package registry
+
+import (
+	"context"
+)
+
+// custom is a self-hosted registry implementing the OCI distribution
+// API, e.g. registry.example.com.
+type custom struct {
+	base
+}
+
+var _ Registry = (*custom)(nil)
+
+// LatestSHATag always returns ErrTagLookupUnsupported, as the OCI
+// distribution API lists tags without when they were pushed.
+func (c *custom) LatestSHATag(_ context.Context, _ string) (string, error) {
+	return "", ErrTagLookupUnsupported
+}
@@ -0,0 +1,49 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/util/httputil"
+)
+
+// digitalOcean is the DigitalOcean Container Registry, where the
+// namespace is the name of the registry within the account.
+type digitalOcean struct {
+	base
+	endpoint string
+	client   *http.Client
+}
+
+var _ Registry = (*digitalOcean)(nil)
+
+// LatestSHATag lists the tags of the repository through the
+// DigitalOcean API, which returns up to 200 tags per page.
+//
+// Ref: https://docs.digitalocean.com/reference/api/digitalocean/#tag/Container-Registry
+func (d *digitalOcean) LatestSHATag(ctx context.Context, app string) (string, error) {
+	var resp struct {
+		Tags []struct {
+			Tag       string    `json:"tag"`
+			UpdatedAt time.Time `json:"updated_at"`
+		} `json:"tags"`
+	}
+
+	endpoint := fmt.Sprintf("%s/v2/registry/%s/repositories/%s/tags?per_page=200",
+		d.endpoint, url.PathEscape(d.namespace()), url.PathEscape(appdef.ImageName(d.repo, app)))
+
+	if err := httputil.GetJSON(ctx, d.client, endpoint, map[string]string{"Authorization": "Bearer " + d.credentials.Password}, &resp); err != nil {
+		return "", err
+	}
+
+	tags := make([]tag, 0, len(resp.Tags))
+	for _, t := range resp.Tags {
+		tags = append(tags, tag{Name: t.Tag, UpdatedAt: t.UpdatedAt})
+	}
+
+	return latestSHATag(tags)
+}
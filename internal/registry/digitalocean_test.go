@@ -0,0 +1,58 @@
+package registry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+)
+
+func TestDigitalOcean_LatestSHATag(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v2/registry/acme/repositories/website-web/tags", r.URL.Path)
+			assert.Equal(t, "Bearer do-token", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"tags":[
+				{"tag":"latest","updated_at":"2026-01-03T00:00:00Z"},
+				{"tag":"sha-new","updated_at":"2026-01-02T00:00:00Z"},
+				{"tag":"sha-old","updated_at":"2026-01-01T00:00:00Z"}
+			]}`))
+		}))
+		t.Cleanup(srv.Close)
+
+		reg := New(
+			testDefinition(appdef.Registry{Type: appdef.RegistryTypeDOCR, Namespace: "acme"}),
+			WithCredentials(Credentials{Username: "do-token", Password: "do-token"}),
+			WithEndpoints(Endpoints{DigitalOcean: srv.URL}),
+		)
+
+		got, err := reg.LatestSHATag(t.Context(), "web")
+		require.NoError(t, err)
+		assert.Equal(t, "sha-new", got)
+	})
+
+	t.Run("API Error", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+		}))
+		t.Cleanup(srv.Close)
+
+		reg := New(
+			testDefinition(appdef.Registry{Type: appdef.RegistryTypeDOCR, Namespace: "acme"}),
+			WithEndpoints(Endpoints{DigitalOcean: srv.URL}),
+		)
+
+		_, err := reg.LatestSHATag(t.Context(), "web")
+		assert.ErrorContains(t, err, "unexpected status 404")
+	})
+}
@@ -0,0 +1,8 @@
+package registry
+
+// Package registry provides access to the container registry that app
+// images are pushed to, abstracting how each provider names images,
+// authenticates and lists the tags of an image.
+//
+// The registry is configured with infra.registry in app.json and
+// defaults to GitHub Container Registry (GHCR).
@@ -0,0 +1,79 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/util/httputil"
+)
+
+// dockerHub is Docker Hub, where the namespace is the user or
+// organisation that owns the repository.
+type dockerHub struct {
+	base
+	endpoint string
+	client   *http.Client
+}
+
+var _ Registry = (*dockerHub)(nil)
+
+// LatestSHATag lists the most recently pushed tags of the repository
+// through the Docker Hub API. Private repositories require a login,
+// which is only made when credentials are set.
+//
+// Ref: https://docs.docker.com/reference/api/hub/latest/
+func (d *dockerHub) LatestSHATag(ctx context.Context, app string) (string, error) {
+	authorization := ""
+	if !d.credentials.IsZero() {
+		token, err := d.login(ctx)
+		if err != nil {
+			return "", errors.Wrap(err, "logging in to docker hub")
+		}
+		authorization = "Bearer " + token
+	}
+
+	var resp struct {
+		Results []struct {
+			Name        string    `json:"name"`
+			LastUpdated time.Time `json:"last_updated"`
+		} `json:"results"`
+	}
+
+	endpoint := fmt.Sprintf("%s/v2/namespaces/%s/repositories/%s/tags?page_size=100&ordering=last_updated",
+		d.endpoint, url.PathEscape(d.namespace()), url.PathEscape(appdef.ImageName(d.repo, app)))
+
+	if err := httputil.GetJSON(ctx, d.client, endpoint, map[string]string{"Authorization": authorization}, &resp); err != nil {
+		return "", err
+	}
+
+	tags := make([]tag, 0, len(resp.Results))
+	for _, t := range resp.Results {
+		tags = append(tags, tag{Name: t.Name, UpdatedAt: t.LastUpdated})
+	}
+
+	return latestSHATag(tags)
+}
+
+// login exchanges the username and access token for a short-lived
+// API token.
+func (d *dockerHub) login(ctx context.Context) (string, error) {
+	body := map[string]string{
+		"username": d.credentials.Username,
+		"password": d.credentials.Password,
+	}
+
+	var resp struct {
+		Token string `json:"token"`
+	}
+	if err := httputil.PostJSON(ctx, d.client, d.endpoint+"/v2/users/login", body, &resp); err != nil {
+		return "", err
+	}
+
+	return resp.Token, nil
+}
@@ -0,0 +1,90 @@
+package registry
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+)
+
+func TestDockerHub_LatestSHATag(t *testing.T) {
+	t.Parallel()
+
+	tags := `{"results":[
+		{"name":"sha-new","last_updated":"2026-01-02T00:00:00Z"},
+		{"name":"sha-old","last_updated":"2026-01-01T00:00:00Z"}
+	]}`
+
+	t.Run("Public", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v2/namespaces/acme/repositories/website-web/tags", r.URL.Path)
+			assert.Empty(t, r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(tags))
+		}))
+		t.Cleanup(srv.Close)
+
+		reg := New(
+			testDefinition(appdef.Registry{Type: appdef.RegistryTypeDockerHub, Namespace: "acme"}),
+			WithCredentials(Credentials{}),
+			WithEndpoints(Endpoints{DockerHub: srv.URL}),
+		)
+
+		got, err := reg.LatestSHATag(t.Context(), "web")
+		require.NoError(t, err)
+		assert.Equal(t, "sha-new", got)
+	})
+
+	t.Run("Private", func(t *testing.T) {
+		t.Parallel()
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /v2/users/login", func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "hub-user", body["username"])
+			assert.Equal(t, "hub-token", body["password"])
+			_, _ = w.Write([]byte(`{"token":"jwt"}`))
+		})
+		mux.HandleFunc("GET /v2/namespaces/acme/repositories/website-web/tags", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer jwt", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(tags))
+		})
+		srv := httptest.NewServer(mux)
+		t.Cleanup(srv.Close)
+
+		reg := New(
+			testDefinition(appdef.Registry{Type: appdef.RegistryTypeDockerHub, Namespace: "acme"}),
+			WithCredentials(Credentials{Username: "hub-user", Password: "hub-token"}),
+			WithEndpoints(Endpoints{DockerHub: srv.URL}),
+		)
+
+		got, err := reg.LatestSHATag(t.Context(), "web")
+		require.NoError(t, err)
+		assert.Equal(t, "sha-new", got)
+	})
+
+	t.Run("Login Failure", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		}))
+		t.Cleanup(srv.Close)
+
+		reg := New(
+			testDefinition(appdef.Registry{Type: appdef.RegistryTypeDockerHub, Namespace: "acme"}),
+			WithCredentials(Credentials{Username: "hub-user", Password: "wrong"}),
+			WithEndpoints(Endpoints{DockerHub: srv.URL}),
+		)
+
+		_, err := reg.LatestSHATag(t.Context(), "web")
+		assert.ErrorContains(t, err, "logging in to docker hub")
+	})
+}
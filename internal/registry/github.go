@@ -0,0 +1,20 @@
+package registry
+
+import (
+	"context"
+
+	"github.com/ainsleydev/webkit/internal/ghapi"
+)
+
+// github is the GitHub Container Registry (ghcr.io), where tags are
+// looked up through the packages API of the namespace.
+type github struct {
+	base
+	client ghapi.Client
+}
+
+var _ Registry = (*github)(nil)
+
+func (g *github) LatestSHATag(ctx context.Context, app string) (string, error) {
+	return g.client.GetLatestSHATag(ctx, g.namespace(), g.repo.Name, app)
+}
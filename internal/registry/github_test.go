@@ -0,0 +1,33 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/mocks"
+	"github.com/ainsleydev/webkit/internal/registry"
+)
+
+func TestGitHub_LatestSHATag(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	client := mocks.NewGHClient(ctrl)
+	client.EXPECT().
+		GetLatestSHATag(gomock.Any(), "acme", "website", "web").
+		Return("sha-abc", nil)
+
+	def := &appdef.Definition{
+		Project: appdef.Project{Repo: appdef.GitHubRepo{Owner: "ainsleydev", Name: "website"}},
+		Infra:   appdef.ProjectInfra{Registry: appdef.Registry{Namespace: "acme"}},
+	}
+	reg := registry.New(def, registry.WithGitHubClient(client))
+
+	got, err := reg.LatestSHATag(t.Context(), "web")
+	require.NoError(t, err)
+	assert.Equal(t, "sha-abc", got)
+}
@@ -0,0 +1,237 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/ghapi"
+	"github.com/ainsleydev/webkit/pkg/enforce"
+)
+
+//go:generate go tool go.uber.org/mock/mockgen -source=registry.go -destination ../mocks/registry.go -package=mocks
+
+// Registry provides the image naming, credentials and tag lookup
+// of a container registry.
+type Registry interface {
+	// Host returns the hostname images are pushed to, e.g. ghcr.io.
+	Host() string
+
+	// Image returns the fully qualified image name of an app
+	// without a tag, e.g. ghcr.io/ainsleydev/my-website-web.
+	Image(app string) string
+
+	// Credentials returns the credentials used to push and
+	// pull images.
+	Credentials() Credentials
+
+	// LatestSHATag returns the most recently pushed sha-* tag of an
+	// app's image, which the release workflow tags every image with.
+	LatestSHATag(ctx context.Context, app string) (string, error)
+}
+
+type (
+	// Credentials are the username and password (or token) used to
+	// log in to a registry.
+	Credentials struct {
+		Username string
+		Password string
+	}
+	// Endpoints defines the base URLs of the registry APIs used to look
+	// up tags. They're configurable so that lookups can be pointed at a
+	// local stand-in server when testing.
+	Endpoints struct {
+		DigitalOcean string
+		DockerHub    string
+	}
+	// Option configures a Registry returned by New.
+	Option func(*options)
+)
+
+// ErrTagLookupUnsupported is returned by LatestSHATag when the registry
+// doesn't expose when tags were pushed, so the latest can't be found.
+var ErrTagLookupUnsupported = errors.New("registry does not support looking up the latest tag")
+
+// DefaultEndpoints returns the public API endpoints of each registry.
+func DefaultEndpoints() Endpoints {
+	return Endpoints{
+		DigitalOcean: "https://api.digitalocean.com",
+		DockerHub:    "https://hub.docker.com",
+	}
+}
+
+// IsZero returns true if no username or password is set.
+func (c Credentials) IsZero() bool {
+	return c.Username == "" && c.Password == ""
+}
+
+// String returns the credentials in username:password form, or an
+// empty string if they're not set.
+func (c Credentials) String() string {
+	if c.IsZero() {
+		return ""
+	}
+	return c.Username + ":" + c.Password
+}
+
+// WithCredentials overrides the credentials read from the environment.
+func WithCredentials(creds Credentials) Option {
+	return func(o *options) {
+		o.credentials = &creds
+	}
+}
+
+// WithGitHubClient sets the GitHub API client used to look up
+// GHCR tags.
+func WithGitHubClient(client ghapi.Client) Option {
+	return func(o *options) {
+		o.ghClient = client
+	}
+}
+
+// WithEndpoints overrides the registry API endpoints.
+func WithEndpoints(endpoints Endpoints) Option {
+	return func(o *options) {
+		o.endpoints = endpoints
+	}
+}
+
+// WithHTTPClient overrides the HTTP client used for registry APIs.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+type options struct {
+	credentials *Credentials
+	ghClient    ghapi.Client
+	endpoints   Endpoints
+	client      *http.Client
+}
+
+// New returns the registry configured in app.json.
+//
+// Credentials are read from the environment variables of the registry
+// unless they're set with WithCredentials:
+//   - ghcr: GITHUB_TOKEN_CLASSIC, with the repo owner as the username.
+//   - docr: DO_API_KEY, used as both the username and password.
+//   - dockerhub: DOCKERHUB_USERNAME and DOCKERHUB_TOKEN.
+//   - custom: REGISTRY_USERNAME and REGISTRY_PASSWORD.
+func New(def *appdef.Definition, opts ...Option) Registry {
+	enforce.NotNil(def, "app definition is required")
+
+	o := &options{
+		endpoints: DefaultEndpoints(),
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	cfg := def.Infra.Registry
+	creds := credentialsFromEnv(cfg.Type, def.Project.Repo.Owner)
+	if o.credentials != nil {
+		creds = *o.credentials
+	}
+
+	b := base{
+		config:      cfg,
+		repo:        def.Project.Repo,
+		credentials: creds,
+	}
+
+	switch cfg.Type {
+	case appdef.RegistryTypeDOCR:
+		return &digitalOcean{base: b, endpoint: o.endpoints.DigitalOcean, client: o.client}
+	case appdef.RegistryTypeDockerHub:
+		return &dockerHub{base: b, endpoint: o.endpoints.DockerHub, client: o.client}
+	case appdef.RegistryTypeCustom:
+		return &custom{base: b}
+	default:
+		client := o.ghClient
+		if client == nil {
+			client = ghapi.NewWithoutAuth()
+			if creds.Password != "" {
+				client = ghapi.New(creds.Password)
+			}
+		}
+		return &github{base: b, client: client}
+	}
+}
+
+// credentialsFromEnv reads the credentials of a registry type from
+// the environment.
+func credentialsFromEnv(t appdef.RegistryType, owner string) Credentials {
+	switch t {
+	case appdef.RegistryTypeDOCR:
+		token := os.Getenv("DO_API_KEY")
+		return Credentials{Username: token, Password: token}
+	case appdef.RegistryTypeDockerHub:
+		return Credentials{Username: os.Getenv("DOCKERHUB_USERNAME"), Password: os.Getenv("DOCKERHUB_TOKEN")}
+	case appdef.RegistryTypeCustom:
+		return Credentials{Username: os.Getenv("REGISTRY_USERNAME"), Password: os.Getenv("REGISTRY_PASSWORD")}
+	default:
+		token := os.Getenv("GITHUB_TOKEN_CLASSIC")
+		if token == "" {
+			return Credentials{}
+		}
+		return Credentials{Username: owner, Password: token}
+	}
+}
+
+// base implements the naming and credentials shared by every registry.
+type base struct {
+	config      appdef.Registry
+	repo        appdef.GitHubRepo
+	credentials Credentials
+}
+
+func (b base) Host() string {
+	return b.config.Hostname()
+}
+
+func (b base) Image(app string) string {
+	return b.config.Image(b.repo, app)
+}
+
+func (b base) Credentials() Credentials {
+	return b.credentials
+}
+
+// namespace returns the namespace images are stored under.
+func (b base) namespace() string {
+	return b.config.NamespaceOrOwner(b.repo.Owner)
+}
+
+// tag is a single image tag and when it was pushed.
+type tag struct {
+	Name      string
+	UpdatedAt time.Time
+}
+
+// latestSHATag returns the most recently pushed sha-* tag.
+func latestSHATag(tags []tag) (string, error) {
+	var shaTags []tag
+	for _, t := range tags {
+		if strings.HasPrefix(t.Name, "sha-") {
+			shaTags = append(shaTags, t)
+		}
+	}
+
+	if len(shaTags) == 0 {
+		return "", errors.New("no sha-tags found")
+	}
+
+	sort.SliceStable(shaTags, func(i, j int) bool {
+		return shaTags[i].UpdatedAt.After(shaTags[j].UpdatedAt)
+	})
+
+	return shaTags[0].Name, nil
+}
@@ -0,0 +1,123 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+)
+
+func testDefinition(registry appdef.Registry) *appdef.Definition {
+	return &appdef.Definition{
+		Project: appdef.Project{Repo: appdef.GitHubRepo{Owner: "ainsleydev", Name: "website"}},
+		Infra:   appdef.ProjectInfra{Registry: registry},
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]struct {
+		input     appdef.Registry
+		wantHost  string
+		wantImage string
+	}{
+		"Default": {
+			input:     appdef.Registry{},
+			wantHost:  "ghcr.io",
+			wantImage: "ghcr.io/ainsleydev/website-web",
+		},
+		"DOCR": {
+			input:     appdef.Registry{Type: appdef.RegistryTypeDOCR, Namespace: "acme"},
+			wantHost:  "registry.digitalocean.com",
+			wantImage: "registry.digitalocean.com/acme/website-web",
+		},
+		"Docker Hub": {
+			input:     appdef.Registry{Type: appdef.RegistryTypeDockerHub, Namespace: "acme"},
+			wantHost:  "docker.io",
+			wantImage: "docker.io/acme/website-web",
+		},
+		"Custom": {
+			input:     appdef.Registry{Type: appdef.RegistryTypeCustom, Host: "registry.example.com"},
+			wantHost:  "registry.example.com",
+			wantImage: "registry.example.com/website-web",
+		},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := New(testDefinition(test.input), WithCredentials(Credentials{Username: "user", Password: "pass"}))
+			assert.Equal(t, test.wantHost, got.Host())
+			assert.Equal(t, test.wantImage, got.Image("web"))
+			assert.Equal(t, "user:pass", got.Credentials().String())
+		})
+	}
+}
+
+func TestCredentialsFromEnv(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN_CLASSIC", "gh-token")
+	t.Setenv("DO_API_KEY", "do-token")
+	t.Setenv("DOCKERHUB_USERNAME", "hub-user")
+	t.Setenv("DOCKERHUB_TOKEN", "hub-token")
+	t.Setenv("REGISTRY_USERNAME", "registry-user")
+	t.Setenv("REGISTRY_PASSWORD", "registry-pass")
+
+	tt := map[appdef.RegistryType]Credentials{
+		appdef.RegistryTypeGHCR:      {Username: "ainsleydev", Password: "gh-token"},
+		appdef.RegistryTypeDOCR:      {Username: "do-token", Password: "do-token"},
+		appdef.RegistryTypeDockerHub: {Username: "hub-user", Password: "hub-token"},
+		appdef.RegistryTypeCustom:    {Username: "registry-user", Password: "registry-pass"},
+	}
+
+	for input, want := range tt {
+		t.Run(input.String(), func(t *testing.T) {
+			assert.Equal(t, want, credentialsFromEnv(input, "ainsleydev"))
+		})
+	}
+
+	t.Run("GHCR Without Token", func(t *testing.T) {
+		t.Setenv("GITHUB_TOKEN_CLASSIC", "")
+		got := credentialsFromEnv(appdef.RegistryTypeGHCR, "ainsleydev")
+		assert.True(t, got.IsZero())
+		assert.Empty(t, got.String())
+	})
+}
+
+func TestLatestSHATag(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	t.Run("Newest SHA Tag", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := latestSHATag([]tag{
+			{Name: "sha-old", UpdatedAt: now.Add(-time.Hour)},
+			{Name: "latest", UpdatedAt: now.Add(time.Hour)},
+			{Name: "sha-new", UpdatedAt: now},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "sha-new", got)
+	})
+
+	t.Run("No SHA Tags", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := latestSHATag([]tag{{Name: "latest", UpdatedAt: now}})
+		assert.ErrorContains(t, err, "no sha-tags found")
+	})
+}
+
+func TestCustom_LatestSHATag(t *testing.T) {
+	t.Parallel()
+
+	reg := New(testDefinition(appdef.Registry{Type: appdef.RegistryTypeCustom, Host: "registry.example.com"}))
+
+	_, err := reg.LatestSHATag(t.Context(), "web")
+	assert.ErrorIs(t, err, ErrTagLookupUnsupported)
+}
@@ -24,9 +24,64 @@ const (
 // 1. SOPS_AGE_KEY environment variable.
 // 2. ~/.config/webkit/age.key (local dev)
 func ReadIdentity() (*age.X25519Identity, error) {
-	key := ""    //nolint
-	source := "" //nolint
+	key, source, err := readKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// Sometimes editors add some random stuff.
+	stripped := strings.ReplaceAll(strings.TrimSpace(key), "\n", "")
+	identity, err := age.ParseX25519Identity(strings.TrimSpace(stripped))
+	if err != nil {
+		// Key files with several identities use the first.
+		if identities, perr := parseIdentities(key, source); perr == nil {
+			return identities[0], nil
+		}
+		return nil, fmt.Errorf("invalid age key format in %s: %w", source, err)
+	}
+
+	return identity, nil
+}
+
+// ReadIdentities returns every age identity in the environment or
+// key file, one per line, in the same format as age key files.
+//
+// Holding several keys lets a single machine decrypt environments
+// that are encrypted for different recipients, such as production.
+func ReadIdentities() ([]*age.X25519Identity, error) {
+	key, source, err := readKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseIdentities(key, source)
+}
+
+// parseIdentities parses one identity per line, skipping blank lines
+// and comments.
+func parseIdentities(key, source string) ([]*age.X25519Identity, error) {
+	var identities []*age.X25519Identity
+	for _, line := range strings.Split(key, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		identity, err := age.ParseX25519Identity(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid age key format in %s: %w", source, err)
+		}
+		identities = append(identities, identity)
+	}
 
+	if len(identities) == 0 {
+		return nil, fmt.Errorf("no age keys found in %s", source)
+	}
+
+	return identities, nil
+}
+
+// readKey returns the raw age key and where it was read from.
+func readKey() (key, source string, err error) {
 	// Check environment variable first (used in CI/CD)
 	if envKey := os.Getenv(KeyEnvVar); envKey != "" {
 		key = envKey
@@ -36,7 +91,7 @@ func ReadIdentity() (*age.X25519Identity, error) {
 		data, err := config.Read(KeyFileName)
 		if err != nil {
 			path, _ := config.Path(KeyFileName)
-			return nil, fmt.Errorf("reading age key from %s: %w", path, err)
+			return "", "", fmt.Errorf("reading age key from %s: %w", path, err)
 		}
 		key = string(data)
 		keyPath, _ := config.Path(KeyFileName)
@@ -44,17 +99,10 @@ func ReadIdentity() (*age.X25519Identity, error) {
 	}
 
 	if key == "" {
-		return nil, errors.New("no SOPS_AGE_KEY key found")
+		return "", "", errors.New("no SOPS_AGE_KEY key found")
 	}
 
-	// Sometimes editors add some random stuff.
-	key = strings.ReplaceAll(strings.TrimSpace(key), "\n", "")
-	identity, err := age.ParseX25519Identity(strings.TrimSpace(key))
-	if err != nil {
-		return nil, fmt.Errorf("invalid age key format in %s: %w", source, err)
-	}
-
-	return identity, nil
+	return key, source, nil
 }
 
 // WritePrivateKey writes an age private key to the config directory.
@@ -71,6 +119,32 @@ func WritePrivateKey(key string) error {
 	return nil
 }
 
+// AppendPrivateKey adds an age private key to the key file in the
+// config directory, keeping any keys already in it, and returns the
+// path of the file.
+func AppendPrivateKey(key string) (string, error) {
+	if _, err := age.ParseX25519Identity(key); err != nil {
+		return "", fmt.Errorf("invalid age key format: %w", err)
+	}
+
+	existing, err := config.Read(KeyFileName)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("reading age key: %w", err)
+	}
+
+	data := strings.TrimRight(string(existing), "\n")
+	if data != "" {
+		data += "\n"
+	}
+	data += key + "\n"
+
+	if err = config.Write(KeyFileName, []byte(data), 0o600); err != nil {
+		return "", fmt.Errorf("writing age key: %w", err)
+	}
+
+	return config.Path(KeyFileName)
+}
+
 // NewIdentity is a helper function for generating age.X25519Identity.
 func NewIdentity() (*age.X25519Identity, error) {
 	return age.GenerateX25519Identity()
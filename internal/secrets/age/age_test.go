@@ -72,6 +72,16 @@ func TestReadIdentity(t *testing.T) {
 		assert.Contains(t, err.Error(), "age.key")
 	})
 
+	t.Run("First Of Several Keys", func(t *testing.T) {
+		other, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		t.Setenv(KeyEnvVar, "# shared\n"+identity.String()+"\n"+other.String()+"\n")
+
+		got, err := ReadIdentity()
+		require.NoError(t, err)
+		assert.Equal(t, identity.String(), got.String())
+	})
+
 	t.Run("Invalid Key From Environment", func(t *testing.T) {
 		t.Setenv(KeyEnvVar, "not-a-valid-age-key")
 
@@ -121,6 +131,90 @@ func TestReadIdentity(t *testing.T) {
 	})
 }
 
+func TestReadIdentities(t *testing.T) {
+	first, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	second, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	t.Run("Single Key", func(t *testing.T) {
+		t.Setenv(KeyEnvVar, first.String())
+
+		got, err := ReadIdentities()
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, first.String(), got[0].String())
+	})
+
+	t.Run("Multiple Keys With Comments", func(t *testing.T) {
+		t.Setenv(KeyEnvVar, "# created: 2026-01-01\n"+first.String()+"\n\n# production\n"+second.String()+"\n")
+
+		got, err := ReadIdentities()
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, first.String(), got[0].String())
+		assert.Equal(t, second.String(), got[1].String())
+	})
+
+	t.Run("Only Comments", func(t *testing.T) {
+		t.Setenv(KeyEnvVar, "# nothing here")
+
+		_, err := ReadIdentities()
+		assert.ErrorContains(t, err, "no age keys found")
+	})
+
+	t.Run("Invalid Key", func(t *testing.T) {
+		t.Setenv(KeyEnvVar, first.String()+"\nnot-a-valid-age-key")
+
+		_, err := ReadIdentities()
+		assert.ErrorContains(t, err, "invalid age key format")
+	})
+
+	t.Run("File Not Found", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		require.NoError(t, os.Unsetenv(KeyEnvVar))
+
+		_, err := ReadIdentities()
+		assert.ErrorContains(t, err, "reading age key from")
+	})
+}
+
+func TestAppendPrivateKey(t *testing.T) {
+	first, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	second, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	t.Run("Creates And Appends", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		require.NoError(t, os.Unsetenv(KeyEnvVar))
+
+		path, err := AppendPrivateKey(first.String())
+		require.NoError(t, err)
+		assert.Contains(t, path, KeyFileName)
+
+		_, err = AppendPrivateKey(second.String())
+		require.NoError(t, err)
+
+		got, err := ReadIdentities()
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, first.String(), got[0].String())
+		assert.Equal(t, second.String(), got[1].String())
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("Invalid Key", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+
+		_, err := AppendPrivateKey("not-a-valid-age-key")
+		assert.ErrorContains(t, err, "invalid age key format")
+	})
+}
+
 func TestWritePrivateKey(t *testing.T) {
 	identity, err := age.GenerateX25519Identity()
 	require.NoError(t, err)
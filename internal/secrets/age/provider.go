@@ -1,6 +1,10 @@
 package age
 
-import "github.com/ainsleydev/webkit/internal/secrets/sops"
+import (
+	"filippo.io/age"
+
+	"github.com/ainsleydev/webkit/internal/secrets/sops"
+)
 
 // Provider implements the SOPS Provider interface for
 // age encryption, keys are lazy loaded.
@@ -39,3 +43,22 @@ func (p *Provider) Environment() map[string]string {
 		"SOPS_AGE_KEY": p.privateKey,
 	}
 }
+
+// NewClient creates a native SOPS client that decrypts files with
+// every identity from ReadIdentities and, unless a creation rule says
+// otherwise, encrypts them for the recipient of the first, so the sops
+// binary isn't needed.
+func NewClient() (*sops.NativeClient, error) {
+	keys, err := ReadIdentities()
+	if err != nil {
+		return nil, err
+	}
+	identities := make([]age.Identity, 0, len(keys))
+	for _, key := range keys {
+		identities = append(identities, key)
+	}
+	return sops.NewNativeClient(
+		identities,
+		[]age.Recipient{keys[0].Recipient()},
+	), nil
+}
@@ -6,6 +6,8 @@ import (
 	"filippo.io/age"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/internal/secrets/sops"
 )
 
 func TestNewProvider(t *testing.T) {
@@ -91,3 +93,47 @@ func TestProvider_Environment(t *testing.T) {
 	assert.NotContains(t, val, identity.Recipient().String(), "Should not include public key")
 	assert.Equal(t, env, provider.Environment(), "Should return same result on multiple calls")
 }
+
+func TestNewClient(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	t.Run("Private Key Error", func(t *testing.T) {
+		t.Setenv(KeyEnvVar, "invalid-key-format")
+
+		client, err := NewClient()
+		assert.Nil(t, client)
+		assert.ErrorContains(t, err, "invalid age key format")
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		t.Setenv(KeyEnvVar, identity.String())
+
+		client, err := NewClient()
+		require.NoError(t, err)
+
+		encrypted, err := client.EncryptData([]byte("KEY: value\n"))
+		require.NoError(t, err)
+		assert.Contains(t, string(encrypted), identity.Recipient().String())
+
+		decrypted, err := client.DecryptData(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, "KEY: value\n", string(decrypted))
+	})
+	t.Run("Multiple Keys", func(t *testing.T) {
+		production, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		t.Setenv(KeyEnvVar, identity.String()+"\n"+production.String())
+
+		client, err := NewClient()
+		require.NoError(t, err)
+
+		encrypted, err := sops.NewNativeClient(nil, []age.Recipient{production.Recipient()}).
+			EncryptData([]byte("KEY: value\n"))
+		require.NoError(t, err)
+
+		decrypted, err := client.DecryptData(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, "KEY: value\n", string(decrypted))
+	})
+}
@@ -0,0 +1,61 @@
+package audit
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ainsleydev/webkit/internal/config"
+)
+
+const (
+	// FileEnvVar is the environment variable that overrides the path of
+	// the local audit log.
+	FileEnvVar = "WEBKIT_AUDIT_FILE"
+	// URLEnvVar is the environment variable that sends entries to an
+	// HTTP endpoint instead of the local audit log.
+	URLEnvVar = "WEBKIT_AUDIT_URL"
+	// TokenEnvVar is the environment variable holding the bearer token
+	// sent to the HTTP endpoint.
+	TokenEnvVar = "WEBKIT_AUDIT_TOKEN"
+	// DefaultFileName is the name of the audit log in the WebKit config
+	// directory.
+	DefaultFileName = "audit.jsonl"
+)
+
+type (
+	// Sink records audit entries.
+	Sink interface {
+		Write(ctx context.Context, entry Entry) error
+	}
+	// Entry is a single access to the secrets of an environment.
+	Entry struct {
+		Time        time.Time `json:"time"`
+		User        string    `json:"user"`
+		Host        string    `json:"host"`
+		Environment string    `json:"environment"`
+		File        string    `json:"file,omitempty"`
+		Keys        []string  `json:"keys"`
+		Command     string    `json:"command"`
+	}
+)
+
+// SinkFromEnv returns the HTTP sink if WEBKIT_AUDIT_URL is set, and
+// otherwise the local audit log at WEBKIT_AUDIT_FILE, defaulting to
+// ~/.config/webkit/audit.jsonl.
+func SinkFromEnv() (Sink, error) {
+	if url := strings.TrimSpace(os.Getenv(URLEnvVar)); url != "" {
+		return NewHTTPSink(url, os.Getenv(TokenEnvVar)), nil
+	}
+
+	path := os.Getenv(FileEnvVar)
+	if path == "" {
+		var err error
+		if path, err = config.Path(DefaultFileName); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewFileSink(path), nil
+}
@@ -0,0 +1,45 @@
+package audit
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSinkFromEnv(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		t.Setenv(URLEnvVar, "")
+		t.Setenv(FileEnvVar, "")
+
+		got, err := SinkFromEnv()
+		require.NoError(t, err)
+		assert.Equal(t, NewFileSink(filepath.Join(home, ".config", "webkit", "audit.jsonl")), got)
+	})
+
+	t.Run("File", func(t *testing.T) {
+		t.Setenv(URLEnvVar, "")
+		t.Setenv(FileEnvVar, "/var/log/webkit.jsonl")
+
+		got, err := SinkFromEnv()
+		require.NoError(t, err)
+		assert.Equal(t, NewFileSink("/var/log/webkit.jsonl"), got)
+	})
+
+	t.Run("HTTP", func(t *testing.T) {
+		t.Setenv(URLEnvVar, "https://audit.example.com")
+		t.Setenv(TokenEnvVar, "token")
+		t.Setenv(FileEnvVar, "/var/log/webkit.jsonl")
+
+		got, err := SinkFromEnv()
+		require.NoError(t, err)
+
+		sink, ok := got.(*HTTPSink)
+		require.True(t, ok)
+		assert.Equal(t, "https://audit.example.com", sink.url)
+		assert.Equal(t, "token", sink.token)
+	})
+}
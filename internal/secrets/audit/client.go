@@ -0,0 +1,173 @@
+package audit
+
+import (
+	"context"
+	"os"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ainsleydev/webkit/internal/secrets/sops"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+// Decrypter is a SOPS client that can decrypt files in place and in
+// memory.
+type Decrypter interface {
+	sops.EncrypterDecrypter
+	sops.DataDecrypter
+}
+
+// Client wraps a SOPS client, recording an entry for every successful
+// decrypt. If the entry can't be recorded the decrypted secrets aren't
+// returned.
+type Client struct {
+	client  Decrypter
+	sink    Sink
+	command string
+	keys    []string
+	now     func() time.Time
+}
+
+var (
+	_ sops.EncrypterDecrypter = (*Client)(nil)
+	_ sops.DataDecrypter      = (*Client)(nil)
+	_ sops.FileDataDecrypter  = (*Client)(nil)
+	_ sops.KeyScoper          = (*Client)(nil)
+)
+
+// NewClient creates a SOPS client that records every decrypt by the
+// given client to the sink, along with the command that ran it, for
+// example "webkit secrets get".
+func NewClient(client Decrypter, sink Sink, command string) *Client {
+	return &Client{
+		client:  client,
+		sink:    sink,
+		command: command,
+		now:     time.Now,
+	}
+}
+
+// WithKeys returns a copy of the client that records only the given
+// keys, for commands that read specific secrets such as
+// "webkit secrets get --key STRIPE". Without keys every top level key
+// of the decrypted file is recorded.
+func (c *Client) WithKeys(keys ...string) sops.EncrypterDecrypter {
+	scoped := *c
+	scoped.keys = slices.Sorted(slices.Values(keys))
+	return &scoped
+}
+
+// Encrypt encrypts a SOPS file in place, encrypting isn't recorded.
+func (c *Client) Encrypt(filePath string) error {
+	return c.client.Encrypt(filePath)
+}
+
+// Decrypt decrypts a SOPS file in place and records the keys accessed.
+func (c *Client) Decrypt(filePath string) error {
+	if err := c.client.Decrypt(filePath); err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return errors.Wrap(err, "reading decrypted file")
+	}
+
+	return c.record(filePath, content)
+}
+
+// DecryptData decrypts SOPS YAML in memory. The file the data was read
+// from is unknown, so the entry has no environment, callers should use
+// sops.DecryptFileData where possible.
+func (c *Client) DecryptData(ciphertext []byte) ([]byte, error) {
+	return c.DecryptFileData("", ciphertext)
+}
+
+// DecryptFileData decrypts the contents of the SOPS file at filePath in
+// memory and records the keys accessed.
+func (c *Client) DecryptFileData(filePath string, ciphertext []byte) ([]byte, error) {
+	plaintext, err := c.client.DecryptData(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.record(filePath, plaintext); err != nil {
+		return nil, err
+	}
+
+	return plaintext, nil
+}
+
+// record writes an entry for the decrypted contents of the file.
+func (c *Client) record(filePath string, plaintext []byte) error {
+	entry := Entry{
+		Time:        c.now().UTC(),
+		User:        currentUser(),
+		Host:        hostname(),
+		Environment: environment(filePath),
+		File:        filePath,
+		Keys:        c.accessedKeys(plaintext),
+		Command:     c.command,
+	}
+
+	if err := c.sink.Write(context.Background(), entry); err != nil {
+		return errors.Wrap(err, "recording secret access in audit log")
+	}
+
+	return nil
+}
+
+// environment returns the environment of a secret file from its name,
+// e.g. resources/secrets/production.yaml.
+func environment(filePath string) string {
+	name := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	if slices.Contains(env.All, env.Environment(name)) {
+		return name
+	}
+	return ""
+}
+
+// accessedKeys returns the keys the client was scoped to, falling
+// back to every key of the file for whole file decrypts.
+func (c *Client) accessedKeys(plaintext []byte) []string {
+	if len(c.keys) > 0 {
+		return slices.Clone(c.keys)
+	}
+	return keys(plaintext)
+}
+
+// keys returns the sorted top level keys of decrypted YAML, without
+// any values.
+func keys(plaintext []byte) []string {
+	values := map[string]any{}
+	if err := yaml.Unmarshal(plaintext, &values); err != nil {
+		return []string{}
+	}
+	delete(values, "sops")
+
+	out := make([]string, 0, len(values))
+	for key := range values {
+		out = append(out, key)
+	}
+	slices.Sort(out)
+
+	return out
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+func hostname() string {
+	host, _ := os.Hostname()
+	return host
+}
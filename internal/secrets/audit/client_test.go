@@ -0,0 +1,164 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/internal/secrets/sops"
+)
+
+// memSink records entries in memory, failing with err if set.
+type memSink struct {
+	entries []Entry
+	err     error
+}
+
+func (s *memSink) Write(_ context.Context, entry Entry) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	setup := func(t *testing.T, sink *memSink) (*Client, []byte) {
+		t.Helper()
+
+		identity, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+
+		native := sops.NewNativeClient([]age.Identity{identity}, []age.Recipient{identity.Recipient()})
+		encrypted, err := native.EncryptData([]byte("STRIPE: sk_live\nAPI_KEY: secret\n"))
+		require.NoError(t, err)
+
+		client := NewClient(native, sink, "webkit secrets get")
+		client.now = func() time.Time { return now }
+
+		return client, encrypted
+	}
+
+	t.Run("Decrypt File Data", func(t *testing.T) {
+		t.Parallel()
+
+		sink := &memSink{}
+		client, encrypted := setup(t, sink)
+
+		got, err := sops.DecryptFileData(client, "resources/secrets/production.yaml", encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, "STRIPE: sk_live\nAPI_KEY: secret\n", string(got))
+
+		require.Len(t, sink.entries, 1)
+		entry := sink.entries[0]
+		assert.Equal(t, now, entry.Time)
+		assert.Equal(t, "production", entry.Environment)
+		assert.Equal(t, "resources/secrets/production.yaml", entry.File)
+		assert.Equal(t, []string{"API_KEY", "STRIPE"}, entry.Keys)
+		assert.Equal(t, "webkit secrets get", entry.Command)
+		assert.NotEmpty(t, entry.User)
+		assert.NotEmpty(t, entry.Host)
+	})
+
+	t.Run("Decrypt Data", func(t *testing.T) {
+		t.Parallel()
+
+		sink := &memSink{}
+		client, encrypted := setup(t, sink)
+
+		_, err := client.DecryptData(encrypted)
+		require.NoError(t, err)
+
+		require.Len(t, sink.entries, 1)
+		assert.Empty(t, sink.entries[0].Environment)
+		assert.Equal(t, []string{"API_KEY", "STRIPE"}, sink.entries[0].Keys)
+	})
+
+	t.Run("Decrypt In Place", func(t *testing.T) {
+		t.Parallel()
+
+		sink := &memSink{}
+		client, encrypted := setup(t, sink)
+
+		path := filepath.Join(t.TempDir(), "staging.yaml")
+		require.NoError(t, os.WriteFile(path, encrypted, 0o600))
+
+		require.NoError(t, client.Decrypt(path))
+
+		require.Len(t, sink.entries, 1)
+		assert.Equal(t, "staging", sink.entries[0].Environment)
+		assert.Equal(t, []string{"API_KEY", "STRIPE"}, sink.entries[0].Keys)
+
+		require.NoError(t, client.Encrypt(path))
+		assert.Len(t, sink.entries, 1, "Encrypting shouldn't be recorded")
+	})
+
+	t.Run("Scoped To Keys", func(t *testing.T) {
+		t.Parallel()
+
+		sink := &memSink{}
+		client, encrypted := setup(t, sink)
+
+		scoped, ok := client.WithKeys("STRIPE").(sops.DataDecrypter)
+		require.True(t, ok)
+
+		_, err := sops.DecryptFileData(scoped, "resources/secrets/production.yaml", encrypted)
+		require.NoError(t, err)
+		_, err = sops.DecryptFileData(client, "resources/secrets/production.yaml", encrypted)
+		require.NoError(t, err)
+
+		require.Len(t, sink.entries, 2)
+		assert.Equal(t, []string{"STRIPE"}, sink.entries[0].Keys)
+		assert.Equal(t, []string{"API_KEY", "STRIPE"}, sink.entries[1].Keys, "Scoping shouldn't change the original client")
+	})
+
+	t.Run("Failed Decrypt Not Recorded", func(t *testing.T) {
+		t.Parallel()
+
+		sink := &memSink{}
+		client, _ := setup(t, sink)
+
+		_, err := client.DecryptData([]byte("KEY: value\n"))
+		assert.ErrorIs(t, err, sops.ErrNotEncrypted)
+		assert.Empty(t, sink.entries)
+	})
+
+	t.Run("Sink Error", func(t *testing.T) {
+		t.Parallel()
+
+		client, encrypted := setup(t, &memSink{err: errors.New("unavailable")})
+
+		got, err := client.DecryptData(encrypted)
+		assert.ErrorContains(t, err, "recording secret access in audit log: unavailable")
+		assert.Nil(t, got, "Secrets shouldn't be returned if the access isn't recorded")
+	})
+}
+
+func TestEnvironment(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]string{
+		"resources/secrets/production.yaml": "production",
+		"/tmp/staging.yaml":                 "staging",
+		"resources/secrets/preview.yaml":    "",
+		"":                                  "",
+	}
+
+	for path, want := range tt {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, want, environment(path))
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package audit
+
+// Package audit records who decrypted which environment's secrets,
+// appending an entry with the user, host, environment, keys, command
+// and time of every decrypt through the SOPS client to a sink.
+//
+// Entries are appended to ~/.config/webkit/audit.jsonl by default, set
+// WEBKIT_AUDIT_FILE to write to another file, or WEBKIT_AUDIT_URL (and
+// optionally WEBKIT_AUDIT_TOKEN) to send them to an HTTP endpoint.
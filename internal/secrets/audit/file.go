@@ -0,0 +1,48 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// FileSink appends entries to a local file, one JSON object per line.
+type FileSink struct {
+	path string
+}
+
+var _ Sink = (*FileSink)(nil)
+
+// NewFileSink creates a sink that appends to the file at path,
+// creating it and its directory if they don't exist.
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+// Write appends the entry to the audit log. The log is only readable
+// by the current user as it lists which secrets were accessed.
+func (s *FileSink) Write(_ context.Context, entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "marshalling audit entry")
+	}
+
+	if err = os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return errors.Wrap(err, "creating audit log directory")
+	}
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "opening audit log")
+	}
+	defer f.Close()
+
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		return errors.Wrap(err, "writing audit log")
+	}
+
+	return nil
+}
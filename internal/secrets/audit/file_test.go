@@ -0,0 +1,42 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSink_Write(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "nested", "audit.jsonl")
+	sink := NewFileSink(path)
+
+	require.NoError(t, sink.Write(t.Context(), Entry{Environment: "staging", Keys: []string{"A"}}))
+	require.NoError(t, sink.Write(t.Context(), Entry{Environment: "production", Keys: []string{"B"}}))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var got []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry Entry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		got = append(got, entry)
+	}
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "staging", got[0].Environment)
+	assert.Equal(t, "production", got[1].Environment)
+}
@@ -0,0 +1,63 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// HTTPSink sends each entry to an HTTP endpoint as a JSON POST request.
+type HTTPSink struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+var _ Sink = (*HTTPSink)(nil)
+
+// NewHTTPSink creates a sink that posts entries to the URL, sending the
+// token as a bearer token if it isn't empty.
+func NewHTTPSink(url, token string) *HTTPSink {
+	return &HTTPSink{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Write posts the entry to the endpoint, failing on any non 2xx
+// response.
+func (s *HTTPSink) Write(ctx context.Context, entry Entry) error {
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "marshalling audit entry")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "creating audit request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "sending audit entry")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("sending audit entry: unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSink_Write(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		var got Entry
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		t.Cleanup(srv.Close)
+
+		err := NewHTTPSink(srv.URL, "token").Write(t.Context(), Entry{Environment: "production", Keys: []string{"KEY"}})
+		require.NoError(t, err)
+		assert.Equal(t, "production", got.Environment)
+		assert.Equal(t, []string{"KEY"}, got.Keys)
+	})
+
+	t.Run("No Token", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.Header.Get("Authorization"))
+		}))
+		t.Cleanup(srv.Close)
+
+		err := NewHTTPSink(srv.URL, "").Write(t.Context(), Entry{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Error Status", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+		}))
+		t.Cleanup(srv.Close)
+
+		err := NewHTTPSink(srv.URL, "").Write(t.Context(), Entry{})
+		assert.ErrorContains(t, err, "unexpected status 403: forbidden")
+	})
+}
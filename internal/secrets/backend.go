@@ -0,0 +1,37 @@
+package secrets
+
+import (
+	"slices"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/secrets/backend"
+)
+
+// BackendEnvVars returns the environment variables that CI workflows
+// must expose to resolve the secret backends used in app.json, sorted
+// by name. GitHub secrets are exposed under their own name, as their
+// values can't be read through the API.
+func BackendEnvVars(def *appdef.Definition) []string {
+	var vars []string
+
+	add := func(entry appdef.EnvWalkEntry) {
+		if !entry.Source.IsSecretBackend() {
+			return
+		}
+		if entry.Source == appdef.EnvSourceGitHub {
+			if _, name, ok := appdef.ParseSecretReference(entry.Value); ok {
+				vars = append(vars, name)
+			}
+			return
+		}
+		vars = append(vars, backend.EnvVars(entry.Source)...)
+	}
+
+	def.Shared.Env.Walk(add)
+	for _, app := range def.Apps {
+		app.Env.Walk(add)
+	}
+
+	slices.Sort(vars)
+	return slices.Compact(vars)
+}
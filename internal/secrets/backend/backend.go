@@ -0,0 +1,158 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+)
+
+// Backend fetches secrets from an external secret manager.
+type Backend interface {
+	// Get returns the value of the secret a reference points to. The
+	// format of the reference depends on the backend, for example
+	// "path#key" for Vault.
+	Get(ctx context.Context, ref string) (string, error)
+}
+
+type (
+	// Credentials are the address and token used to connect to a
+	// secret manager.
+	Credentials struct {
+		// Address is the base URL of the secret manager's API.
+		Address string
+		// Token authenticates requests to the API.
+		Token string
+		// Namespace is the Vault Enterprise namespace, if any.
+		Namespace string
+	}
+	// Option configures a Backend returned by New.
+	Option func(*options)
+)
+
+// DopplerAddress is the base URL of the Doppler API.
+const DopplerAddress = "https://api.doppler.com"
+
+// WithCredentials overrides the credentials read from the environment,
+// for example to point a backend at a local stand-in server.
+func WithCredentials(creds Credentials) Option {
+	return func(o *options) {
+		o.credentials = &creds
+	}
+}
+
+// WithHTTPClient overrides the HTTP client used for backend APIs.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+type options struct {
+	credentials *Credentials
+	client      *http.Client
+}
+
+// New returns the backend of a secret source.
+//
+// Credentials are read from the environment variables of the backend
+// unless they're set with WithCredentials:
+//   - 1password: OP_CONNECT_HOST and OP_CONNECT_TOKEN of a Connect server.
+//   - doppler: DOPPLER_TOKEN.
+//   - vault: VAULT_ADDR, VAULT_TOKEN and optionally VAULT_NAMESPACE.
+//   - github: none, secrets are read from environment variables.
+func New(source appdef.EnvSource, opts ...Option) (Backend, error) {
+	o := &options{
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	creds := credentialsFromEnv(source)
+	if o.credentials != nil {
+		creds = *o.credentials
+	}
+	creds.Address = strings.TrimSuffix(creds.Address, "/")
+
+	switch source {
+	case appdef.EnvSourceOnePassword:
+		if creds.Address == "" || creds.Token == "" {
+			return nil, missingCredentials(source)
+		}
+		return &onePassword{credentials: creds, client: o.client}, nil
+	case appdef.EnvSourceDoppler:
+		if creds.Token == "" {
+			return nil, missingCredentials(source)
+		}
+		if creds.Address == "" {
+			creds.Address = DopplerAddress
+		}
+		return &doppler{credentials: creds, client: o.client}, nil
+	case appdef.EnvSourceVault:
+		if creds.Address == "" || creds.Token == "" {
+			return nil, missingCredentials(source)
+		}
+		return &vault{credentials: creds, client: o.client}, nil
+	case appdef.EnvSourceGitHub:
+		return &github{lookup: os.LookupEnv}, nil
+	default:
+		return nil, fmt.Errorf("unsupported secret backend: %s", source)
+	}
+}
+
+// EnvVars returns the environment variables that the backend of a
+// secret source reads its credentials from.
+func EnvVars(source appdef.EnvSource) []string {
+	switch source {
+	case appdef.EnvSourceOnePassword:
+		return []string{"OP_CONNECT_HOST", "OP_CONNECT_TOKEN"}
+	case appdef.EnvSourceDoppler:
+		return []string{"DOPPLER_TOKEN"}
+	case appdef.EnvSourceVault:
+		return []string{"VAULT_ADDR", "VAULT_TOKEN", "VAULT_NAMESPACE"}
+	default:
+		return nil
+	}
+}
+
+// credentialsFromEnv reads the credentials of a secret source from
+// the environment.
+func credentialsFromEnv(source appdef.EnvSource) Credentials {
+	switch source {
+	case appdef.EnvSourceOnePassword:
+		return Credentials{Address: os.Getenv("OP_CONNECT_HOST"), Token: os.Getenv("OP_CONNECT_TOKEN")}
+	case appdef.EnvSourceDoppler:
+		return Credentials{Token: os.Getenv("DOPPLER_TOKEN")}
+	case appdef.EnvSourceVault:
+		return Credentials{
+			Address:   os.Getenv("VAULT_ADDR"),
+			Token:     os.Getenv("VAULT_TOKEN"),
+			Namespace: os.Getenv("VAULT_NAMESPACE"),
+		}
+	default:
+		return Credentials{}
+	}
+}
+
+func missingCredentials(source appdef.EnvSource) error {
+	vars := EnvVars(source)
+	if source == appdef.EnvSourceVault {
+		vars = vars[:2]
+	}
+	return fmt.Errorf("%s must be set to resolve %s secrets", strings.Join(vars, " and "), source)
+}
+
+// parseReference parses a reference in the "path#key" format, returning
+// an error naming the format the backend expects if it's invalid.
+func parseReference(source appdef.EnvSource, ref, expected string) (path, key string, err error) {
+	path, key, ok := appdef.ParseSecretReference(ref)
+	if !ok {
+		return "", "", fmt.Errorf("invalid %s reference %q, expected %s", source, ref, expected)
+	}
+	return path, key, nil
+}
@@ -0,0 +1,60 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]struct {
+		source  appdef.EnvSource
+		creds   Credentials
+		wantErr string
+	}{
+		"1Password":         {source: appdef.EnvSourceOnePassword, creds: Credentials{Address: "http://op", Token: "t"}},
+		"1Password Missing": {source: appdef.EnvSourceOnePassword, wantErr: "OP_CONNECT_HOST and OP_CONNECT_TOKEN must be set"},
+		"Doppler":           {source: appdef.EnvSourceDoppler, creds: Credentials{Token: "t"}},
+		"Doppler Missing":   {source: appdef.EnvSourceDoppler, wantErr: "DOPPLER_TOKEN must be set to resolve doppler secrets"},
+		"Vault":             {source: appdef.EnvSourceVault, creds: Credentials{Address: "http://vault", Token: "t"}},
+		"Vault Missing":     {source: appdef.EnvSourceVault, creds: Credentials{Token: "t"}, wantErr: "VAULT_ADDR and VAULT_TOKEN must be set"},
+		"GitHub":            {source: appdef.EnvSourceGitHub},
+		"Unsupported":       {source: appdef.EnvSourceSOPS, wantErr: "unsupported secret backend: sops"},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := New(test.source, WithCredentials(test.creds))
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, got)
+		})
+	}
+
+	t.Run("Doppler Default Address", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := New(appdef.EnvSourceDoppler, WithCredentials(Credentials{Token: "t"}))
+		require.NoError(t, err)
+		assert.Equal(t, DopplerAddress, got.(*doppler).credentials.Address)
+	})
+}
+
+func TestEnvVars(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"OP_CONNECT_HOST", "OP_CONNECT_TOKEN"}, EnvVars(appdef.EnvSourceOnePassword))
+	assert.Equal(t, []string{"DOPPLER_TOKEN"}, EnvVars(appdef.EnvSourceDoppler))
+	assert.Equal(t, []string{"VAULT_ADDR", "VAULT_TOKEN", "VAULT_NAMESPACE"}, EnvVars(appdef.EnvSourceVault))
+	assert.Nil(t, EnvVars(appdef.EnvSourceGitHub))
+}
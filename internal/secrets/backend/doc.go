@@ -0,0 +1,9 @@
+package backend
+
+// Package backend provides access to the external secret managers that
+// environment variables can be read from, as an alternative to SOPS
+// encrypted files: 1Password, Doppler, HashiCorp Vault and GitHub
+// Actions secrets.
+//
+// Backends are selected with the source of a variable in app.json, for
+// example "source": "vault", and resolved by secrets.Resolve.
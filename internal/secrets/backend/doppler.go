@@ -0,0 +1,65 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/util/httputil"
+)
+
+// doppler reads secrets from Doppler.
+type doppler struct {
+	credentials Credentials
+	client      *http.Client
+}
+
+var _ Backend = (*doppler)(nil)
+
+// Get returns a secret referenced by name, e.g. "STRIPE_KEY", when the
+// token is a service token scoped to a config, or as
+// "project/config#NAME" otherwise, e.g. "website/prd#STRIPE_KEY".
+//
+// Ref: https://docs.doppler.com/reference/secrets-get
+func (d *doppler) Get(ctx context.Context, ref string) (string, error) {
+	const expected = "NAME or project/config#NAME"
+
+	path, name, err := parseReference(appdef.EnvSourceDoppler, ref, expected)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{"name": {name}}
+	if path != "" {
+		project, config, ok := strings.Cut(path, "/")
+		if !ok || project == "" || config == "" {
+			return "", fmt.Errorf("invalid doppler reference %q, expected %s", ref, expected)
+		}
+		query.Set("project", project)
+		query.Set("config", config)
+	}
+
+	var resp struct {
+		Value struct {
+			Raw      string  `json:"raw"`
+			Computed *string `json:"computed"`
+		} `json:"value"`
+	}
+
+	headers := map[string]string{"Authorization": "Bearer " + d.credentials.Token}
+	endpoint := d.credentials.Address + "/v3/configs/config/secret?" + query.Encode()
+	if err = httputil.GetJSON(ctx, d.client, endpoint, headers, &resp); err != nil {
+		return "", err
+	}
+
+	// Computed values have references to other
+	// secrets expanded.
+	if resp.Value.Computed != nil {
+		return *resp.Value.Computed, nil
+	}
+
+	return resp.Value.Raw, nil
+}
@@ -0,0 +1,62 @@
+package backend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+)
+
+func TestDoppler_Get(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/configs/config/secret", r.URL.Path)
+		assert.Equal(t, "Bearer dp.st.token", r.Header.Get("Authorization"))
+
+		q := r.URL.Query()
+		switch {
+		case q.Get("name") == "MISSING":
+			http.Error(w, `{"messages":["Could not find secret"]}`, http.StatusNotFound)
+		case q.Get("project") == "website" && q.Get("config") == "prd":
+			_, _ = w.Write([]byte(`{"name":"STRIPE_KEY","value":{"raw":"${OTHER}","computed":"sk_prd"}}`))
+		case q.Get("project") == "":
+			_, _ = w.Write([]byte(`{"name":"STRIPE_KEY","value":{"raw":"sk_raw"}}`))
+		default:
+			http.Error(w, "unexpected project", http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	b, err := New(appdef.EnvSourceDoppler, WithCredentials(Credentials{Address: srv.URL, Token: "dp.st.token"}))
+	require.NoError(t, err)
+
+	tt := map[string]struct {
+		ref     string
+		want    string
+		wantErr string
+	}{
+		"Scoped Token":      {ref: "STRIPE_KEY", want: "sk_raw"},
+		"Project Config":    {ref: "website/prd#STRIPE_KEY", want: "sk_prd"},
+		"Missing":           {ref: "MISSING", wantErr: "unexpected status 404"},
+		"Invalid Reference": {ref: "website#STRIPE_KEY", wantErr: `invalid doppler reference "website#STRIPE_KEY"`},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := b.Get(t.Context(), test.ref)
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
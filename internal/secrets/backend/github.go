@@ -0,0 +1,33 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+)
+
+// github reads GitHub Actions secrets. The GitHub API never returns
+// secret values, so they're read from the environment variables that
+// workflows expose them as, under the secret's own name.
+type github struct {
+	lookup func(key string) (string, bool)
+}
+
+var _ Backend = (*github)(nil)
+
+// Get returns the secret referenced by name, e.g. "STRIPE_KEY".
+func (g *github) Get(_ context.Context, ref string) (string, error) {
+	path, name, ok := appdef.ParseSecretReference(ref)
+	if !ok || path != "" {
+		return "", fmt.Errorf("invalid github reference %q, expected the secret name", ref)
+	}
+
+	value, ok := g.lookup(name)
+	if !ok {
+		return "", fmt.Errorf("github secret %s is not set, GitHub secrets can only be read in "+
+			"workflows that expose them as environment variables", name)
+	}
+
+	return value, nil
+}
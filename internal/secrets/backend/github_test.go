@@ -0,0 +1,41 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHub_Get(t *testing.T) {
+	t.Parallel()
+
+	b := &github{lookup: func(key string) (string, bool) {
+		if key == "STRIPE_KEY" {
+			return "sk_live", true
+		}
+		return "", false
+	}}
+
+	t.Run("Exposed", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := b.Get(t.Context(), "STRIPE_KEY")
+		require.NoError(t, err)
+		assert.Equal(t, "sk_live", got)
+	})
+
+	t.Run("Not Exposed", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := b.Get(t.Context(), "OTHER")
+		assert.ErrorContains(t, err, "github secret OTHER is not set")
+	})
+
+	t.Run("Invalid Reference", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := b.Get(t.Context(), "repo#STRIPE_KEY")
+		assert.ErrorContains(t, err, `invalid github reference "repo#STRIPE_KEY"`)
+	})
+}
@@ -0,0 +1,98 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/util/httputil"
+)
+
+// onePassword reads secrets from 1Password through a Connect server,
+// which exposes the vaults its token has been granted access to.
+type onePassword struct {
+	credentials Credentials
+	client      *http.Client
+}
+
+var _ Backend = (*onePassword)(nil)
+
+// Get returns a field of an item, referenced as "vault/item#field"
+// where the vault and item are names (or IDs) and the field is a label
+// (or ID), e.g. "production/stripe#secret_key".
+//
+// Ref: https://developer.1password.com/docs/connect/api-reference/
+func (o *onePassword) Get(ctx context.Context, ref string) (string, error) {
+	const expected = "vault/item#field"
+
+	path, field, err := parseReference(appdef.EnvSourceOnePassword, ref, expected)
+	if err != nil {
+		return "", err
+	}
+
+	vaultName, itemName, ok := strings.Cut(path, "/")
+	if !ok || vaultName == "" || itemName == "" {
+		return "", fmt.Errorf("invalid 1password reference %q, expected %s", ref, expected)
+	}
+
+	vaultID, err := o.find(ctx, "/v1/vaults", "name", vaultName)
+	if err != nil {
+		return "", err
+	}
+
+	itemID, err := o.find(ctx, "/v1/vaults/"+url.PathEscape(vaultID)+"/items", "title", itemName)
+	if err != nil {
+		return "", err
+	}
+
+	var item struct {
+		Fields []struct {
+			ID    string `json:"id"`
+			Label string `json:"label"`
+			Value string `json:"value"`
+		} `json:"fields"`
+	}
+
+	endpoint := o.credentials.Address + "/v1/vaults/" + url.PathEscape(vaultID) + "/items/" + url.PathEscape(itemID)
+	if err = httputil.GetJSON(ctx, o.client, endpoint, o.headers(), &item); err != nil {
+		return "", err
+	}
+
+	for _, f := range item.Fields {
+		if f.Label == field || f.ID == field {
+			return f.Value, nil
+		}
+	}
+
+	return "", fmt.Errorf("field %q not found in 1password item %s", field, path)
+}
+
+// find returns the ID of the vault or item whose attribute matches
+// the name, falling back to the name as an ID when nothing matches.
+func (o *onePassword) find(ctx context.Context, path, attribute, name string) (string, error) {
+	var resp []struct {
+		ID string `json:"id"`
+	}
+
+	query := url.Values{"filter": {fmt.Sprintf("%s eq %q", attribute, name)}}
+	endpoint := o.credentials.Address + path + "?" + query.Encode()
+	if err := httputil.GetJSON(ctx, o.client, endpoint, o.headers(), &resp); err != nil {
+		return "", err
+	}
+
+	switch len(resp) {
+	case 0:
+		return name, nil
+	case 1:
+		return resp[0].ID, nil
+	default:
+		return "", fmt.Errorf("found %d 1password entries with the %s %q, reference it by ID instead", len(resp), attribute, name)
+	}
+}
+
+func (o *onePassword) headers() map[string]string {
+	return map[string]string{"Authorization": "Bearer " + o.credentials.Token}
+}
@@ -0,0 +1,74 @@
+package backend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+)
+
+func TestOnePassword_Get(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/vaults", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer op-token", r.Header.Get("Authorization"))
+		switch r.URL.Query().Get("filter") {
+		case `name eq "production"`:
+			_, _ = w.Write([]byte(`[{"id":"vault1"}]`))
+		case `name eq "shared"`:
+			_, _ = w.Write([]byte(`[{"id":"a"},{"id":"b"}]`))
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	})
+	mux.HandleFunc("GET /v1/vaults/vault1/items", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("filter") == `title eq "stripe"` {
+			_, _ = w.Write([]byte(`[{"id":"item1"}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	})
+	mux.HandleFunc("GET /v1/vaults/vault1/items/item1", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"fields":[
+			{"id":"username","label":"username","value":"acme"},
+			{"id":"f7","label":"secret_key","value":"sk_live"}
+		]}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	b, err := New(appdef.EnvSourceOnePassword, WithCredentials(Credentials{Address: srv.URL, Token: "op-token"}))
+	require.NoError(t, err)
+
+	tt := map[string]struct {
+		ref     string
+		want    string
+		wantErr string
+	}{
+		"By Label":          {ref: "production/stripe#secret_key", want: "sk_live"},
+		"By ID":             {ref: "vault1/item1#f7", want: "sk_live"},
+		"Missing Field":     {ref: "production/stripe#nope", wantErr: `field "nope" not found in 1password item production/stripe`},
+		"Missing Item":      {ref: "production/nope#secret_key", wantErr: "unexpected status 404"},
+		"Ambiguous Vault":   {ref: "shared/stripe#secret_key", wantErr: `found 2 1password entries with the name "shared"`},
+		"Invalid Reference": {ref: "stripe#secret_key", wantErr: `invalid 1password reference "stripe#secret_key", expected vault/item#field`},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := b.Get(t.Context(), test.ref)
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/util/httputil"
+)
+
+// vault reads secrets from HashiCorp Vault, supporting both versions
+// of the KV secrets engine.
+type vault struct {
+	credentials Credentials
+	client      *http.Client
+}
+
+var _ Backend = (*vault)(nil)
+
+// Get returns the key of the secret at a path, referenced as
+// "path#key", e.g. "secret/data/website#stripe_key". KV version 2
+// paths include the "data/" segment after the mount.
+//
+// Ref: https://developer.hashicorp.com/vault/api-docs/secret/kv/kv-v2#read-secret-version
+func (v *vault) Get(ctx context.Context, ref string) (string, error) {
+	path, key, err := parseReference(appdef.EnvSourceVault, ref, "path#key")
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		return "", fmt.Errorf("invalid vault reference %q, expected path#key", ref)
+	}
+
+	var resp struct {
+		Data map[string]any `json:"data"`
+	}
+
+	headers := map[string]string{
+		"X-Vault-Token":     v.credentials.Token,
+		"X-Vault-Namespace": v.credentials.Namespace,
+	}
+
+	endpoint := v.credentials.Address + "/v1/" + strings.TrimPrefix(path, "/")
+	if err = httputil.GetJSON(ctx, v.client, endpoint, headers, &resp); err != nil {
+		return "", err
+	}
+
+	// KV version 2 nests the secret under data alongside
+	// its metadata.
+	data := resp.Data
+	if nested, ok := data["data"].(map[string]any); ok {
+		if _, ok = data["metadata"]; ok {
+			data = nested
+		}
+	}
+
+	value, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in vault secret %s", key, path)
+	}
+
+	return stringify(value), nil
+}
+
+// stringify returns a secret value as a string, as Vault secrets
+// can hold any JSON value. Numbers are decoded as json.Number, so
+// they're written exactly as they're stored.
+func stringify(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	case json.Number, bool:
+		return fmt.Sprint(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	}
+}
@@ -0,0 +1,69 @@
+package backend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+)
+
+func TestVault_Get(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/secret/data/website", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "vault-token", r.Header.Get("X-Vault-Token"))
+		assert.Equal(t, "team", r.Header.Get("X-Vault-Namespace"))
+		_, _ = w.Write([]byte(`{"data":{"data":{"stripe_key":"sk_live","port":8080,"limit":1000000,"ratio":1.5,"debug":true,"hosts":["a","b"]},"metadata":{"version":3}}}`))
+	})
+	mux.HandleFunc("GET /v1/kv/website", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"stripe_key":"sk_v1"}}`))
+	})
+	mux.HandleFunc("GET /v1/secret/data/missing", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"errors":[]}`, http.StatusNotFound)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	b, err := New(appdef.EnvSourceVault, WithCredentials(Credentials{
+		Address:   srv.URL + "/",
+		Token:     "vault-token",
+		Namespace: "team",
+	}))
+	require.NoError(t, err)
+
+	tt := map[string]struct {
+		ref     string
+		want    string
+		wantErr string
+	}{
+		"KV Version 2":      {ref: "secret/data/website#stripe_key", want: "sk_live"},
+		"KV Version 1":      {ref: "kv/website#stripe_key", want: "sk_v1"},
+		"Number":            {ref: "secret/data/website#port", want: "8080"},
+		"Large Number":      {ref: "secret/data/website#limit", want: "1000000"},
+		"Float":             {ref: "secret/data/website#ratio", want: "1.5"},
+		"Bool":              {ref: "secret/data/website#debug", want: "true"},
+		"List":              {ref: "secret/data/website#hosts", want: `["a","b"]`},
+		"Missing Key":       {ref: "secret/data/website#nope", wantErr: `key "nope" not found in vault secret secret/data/website`},
+		"Missing Path":      {ref: "secret/data/missing#key", wantErr: "unexpected status 404"},
+		"Invalid Reference": {ref: "stripe_key", wantErr: `invalid vault reference "stripe_key", expected path#key`},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := b.Get(t.Context(), test.ref)
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
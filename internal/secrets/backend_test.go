@@ -0,0 +1,58 @@
+package secrets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+)
+
+func TestBackendEnvVars(t *testing.T) {
+	t.Parallel()
+
+	t.Run("None", func(t *testing.T) {
+		t.Parallel()
+
+		def := &appdef.Definition{
+			Shared: appdef.Shared{
+				Env: appdef.Environment{
+					Production: appdef.EnvVar{"API_KEY": {Source: appdef.EnvSourceSOPS}},
+				},
+			},
+		}
+		assert.Empty(t, BackendEnvVars(def))
+	})
+
+	t.Run("Backends", func(t *testing.T) {
+		t.Parallel()
+
+		def := &appdef.Definition{
+			Shared: appdef.Shared{
+				Env: appdef.Environment{
+					Default: appdef.EnvVar{"STRIPE": {Source: appdef.EnvSourceVault, Value: "secret/data/web#stripe"}},
+				},
+			},
+			Apps: []appdef.App{
+				{
+					Name: "web",
+					Env: appdef.Environment{
+						Production: appdef.EnvVar{
+							"OTHER":   {Source: appdef.EnvSourceVault, Value: "secret/data/web#other"},
+							"DOPPLER": {Source: appdef.EnvSourceDoppler, Value: "DOPPLER_KEY"},
+							"GITHUB":  {Source: appdef.EnvSourceGitHub, Value: "MAILGUN_KEY"},
+						},
+					},
+				},
+			},
+		}
+
+		assert.Equal(t, []string{
+			"DOPPLER_TOKEN",
+			"MAILGUN_KEY",
+			"VAULT_ADDR",
+			"VAULT_NAMESPACE",
+			"VAULT_TOKEN",
+		}, BackendEnvVars(def))
+	})
+}
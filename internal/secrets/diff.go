@@ -0,0 +1,46 @@
+package secrets
+
+import "fmt"
+
+// Diff is the difference between the secrets of two environments.
+type Diff struct {
+	// Missing are the keys that only exist in the second environment.
+	Missing []string
+	// Extra are the keys that only exist in the first environment.
+	Extra []string
+	// Identical are the keys with the same, non-empty, value in both
+	// environments, usually a sign that credentials have been copied
+	// from one environment to another.
+	Identical []string
+}
+
+// HasChanges returns true if the environments have different keys or
+// share any values.
+func (d Diff) HasChanges() bool {
+	return len(d.Missing) > 0 || len(d.Extra) > 0 || len(d.Identical) > 0
+}
+
+// Compare returns the difference between the decrypted secrets of two
+// environments, with the keys of each list sorted.
+func Compare(from, to map[string]any) Diff {
+	var diff Diff
+
+	for _, key := range sortedKeys(from) {
+		other, ok := to[key]
+		if !ok {
+			diff.Extra = append(diff.Extra, key)
+			continue
+		}
+		if !isEmptyValue(from[key]) && fmt.Sprint(from[key]) == fmt.Sprint(other) {
+			diff.Identical = append(diff.Identical, key)
+		}
+	}
+
+	for _, key := range sortedKeys(to) {
+		if _, ok := from[key]; !ok {
+			diff.Missing = append(diff.Missing, key)
+		}
+	}
+
+	return diff
+}
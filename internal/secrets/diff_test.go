@@ -0,0 +1,57 @@
+package secrets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]struct {
+		from map[string]any
+		to   map[string]any
+		want Diff
+	}{
+		"Same Keys": {
+			from: map[string]any{"A": "1", "B": "2"},
+			to:   map[string]any{"A": "3", "B": "4"},
+			want: Diff{},
+		},
+		"Missing And Extra": {
+			from: map[string]any{"A": "1", "OLD": "2"},
+			to:   map[string]any{"A": "3", "NEW": "4", "NEWER": "5"},
+			want: Diff{Missing: []string{"NEW", "NEWER"}, Extra: []string{"OLD"}},
+		},
+		"Identical": {
+			from: map[string]any{"A": "sk_live", "B": 8080, "C": "x"},
+			to:   map[string]any{"A": "sk_live", "B": 8080, "C": "y"},
+			want: Diff{Identical: []string{"A", "B"}},
+		},
+		"Empty Values Not Identical": {
+			from: map[string]any{"A": "", "B": nil},
+			to:   map[string]any{"A": "", "B": nil},
+			want: Diff{},
+		},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Compare(test.from, test.to)
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.want.HasChanges(), got.HasChanges())
+		})
+	}
+
+	t.Run("Has Changes", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, Diff{}.HasChanges())
+		assert.True(t, Diff{Missing: []string{"A"}}.HasChanges())
+		assert.True(t, Diff{Extra: []string{"A"}}.HasChanges())
+		assert.True(t, Diff{Identical: []string{"A"}}.HasChanges())
+	})
+}
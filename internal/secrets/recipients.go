@@ -0,0 +1,135 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/ainsleydev/webkit/internal/secrets/sops"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+// SOPSConfigPath defines the path of the SOPS configuration file that
+// holds the recipients of each environment. Needs a base path prepended.
+var SOPSConfigPath = filepath.Join("resources", sops.ConfigFileName)
+
+// Recipients maps each environment to the age public keys that its
+// secret file is encrypted for.
+type Recipients map[env.Environment][]string
+
+// DefaultRecipients returns recipients where every environment is
+// encrypted for AgePublicKey.
+func DefaultRecipients() Recipients {
+	r := make(Recipients, len(env.All))
+	for _, e := range env.All {
+		r[e] = []string{AgePublicKey}
+	}
+	return r
+}
+
+// ReadRecipients reads the recipients of each environment from the
+// creation rules in .sops.yaml.
+//
+// Environments that don't match a rule, or projects without a
+// .sops.yaml, fall back to AgePublicKey so older configurations that
+// use a single rule for every file keep working.
+func ReadRecipients(fs afero.Fs) (Recipients, error) {
+	cfg, err := ReadSOPSConfig(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	r := DefaultRecipients()
+	if cfg == nil {
+		return r, nil
+	}
+
+	for _, e := range env.All {
+		keys, err := cfg.Recipients(FilePathFromEnv(e))
+		if err != nil {
+			return nil, err
+		}
+		if len(keys) > 0 {
+			r[e] = keys
+		}
+	}
+
+	return r, nil
+}
+
+// ReadSOPSConfig reads and parses .sops.yaml, returning nil if the
+// file doesn't exist.
+func ReadSOPSConfig(fs afero.Fs) (*sops.Config, error) {
+	data, err := afero.ReadFile(fs, SOPSConfigPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", SOPSConfigPath, err)
+	}
+	return sops.ParseConfig(data)
+}
+
+// SOPSConfig returns the .sops.yaml configuration with a creation rule
+// for each environment, followed by a rule for any other secret file
+// that uses the development recipients.
+func (r Recipients) SOPSConfig() sops.Config {
+	cfg := sops.Config{}
+	for _, e := range env.All {
+		cfg.CreationRules = append(cfg.CreationRules, sops.CreationRule{
+			PathRegex: `secrets/` + regexp.QuoteMeta(e.String()+".yaml") + `$`,
+			Age:       strings.Join(r[e], ","),
+		})
+	}
+	cfg.CreationRules = append(cfg.CreationRules, sops.CreationRule{
+		PathRegex: `secrets/.*\.yaml$`,
+		Age:       strings.Join(r[env.Development], ","),
+	})
+	return cfg
+}
+
+// Add adds the public key to the recipients of the environment.
+// Returns false if it's already a recipient.
+func (r Recipients) Add(e env.Environment, key string) bool {
+	if slices.Contains(r[e], key) {
+		return false
+	}
+	r[e] = append(slices.Clone(r[e]), key)
+	return true
+}
+
+// Remove removes the public key from the recipients of the
+// environment. The last recipient can't be removed, as the secrets
+// would no longer be decryptable.
+func (r Recipients) Remove(e env.Environment, key string) error {
+	idx := slices.Index(r[e], key)
+	if idx == -1 {
+		return fmt.Errorf("%s is not a recipient of %s", key, e)
+	}
+	if len(r[e]) == 1 {
+		return fmt.Errorf("can't remove the last recipient of %s", e)
+	}
+	r[e] = slices.Delete(slices.Clone(r[e]), idx, idx+1)
+	return nil
+}
+
+// SharedWithProduction returns the production recipients that can
+// also decrypt another environment. Production secrets should only be
+// decryptable by a separate key.
+func (r Recipients) SharedWithProduction() []string {
+	var shared []string
+	for _, key := range r[env.Production] {
+		for _, e := range env.All {
+			if e != env.Production && slices.Contains(r[e], key) {
+				shared = append(shared, key)
+				break
+			}
+		}
+	}
+	return shared
+}
@@ -0,0 +1,161 @@
+package secrets
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ainsleydev/webkit/internal/secrets/sops"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+func TestReadRecipients(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]struct {
+		config string
+		want   Recipients
+	}{
+		"No Config": {
+			want: DefaultRecipients(),
+		},
+		"Legacy Single Rule": {
+			config: "creation_rules:\n  - path_regex: secrets/.*\\.yaml$\n    age: age1legacy\n",
+			want: Recipients{
+				env.Development: {"age1legacy"},
+				env.Staging:     {"age1legacy"},
+				env.Production:  {"age1legacy"},
+			},
+		},
+		"Per Environment": {
+			config: "creation_rules:\n" +
+				"  - path_regex: secrets/production\\.yaml$\n    age: age1prod\n" +
+				"  - path_regex: secrets/.*\\.yaml$\n    age: age1dev,age1ci\n",
+			want: Recipients{
+				env.Development: {"age1dev", "age1ci"},
+				env.Staging:     {"age1dev", "age1ci"},
+				env.Production:  {"age1prod"},
+			},
+		},
+		"Unmatched Environment": {
+			config: "creation_rules:\n  - path_regex: secrets/production\\.yaml$\n    age: age1prod\n",
+			want: Recipients{
+				env.Development: {AgePublicKey},
+				env.Staging:     {AgePublicKey},
+				env.Production:  {"age1prod"},
+			},
+		},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := afero.NewMemMapFs()
+			if test.config != "" {
+				require.NoError(t, afero.WriteFile(fs, SOPSConfigPath, []byte(test.config), 0o644))
+			}
+
+			got, err := ReadRecipients(fs)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("Invalid Config", func(t *testing.T) {
+		t.Parallel()
+
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, SOPSConfigPath, []byte("creation_rules: [\n"), 0o644))
+
+		_, err := ReadRecipients(fs)
+		assert.ErrorContains(t, err, "parsing sops config")
+	})
+}
+
+func TestRecipients_SOPSConfig(t *testing.T) {
+	t.Parallel()
+
+	r := Recipients{
+		env.Development: {"age1dev"},
+		env.Staging:     {"age1dev", "age1ci"},
+		env.Production:  {"age1prod"},
+	}
+
+	cfg := r.SOPSConfig()
+	assert.Equal(t, []sops.CreationRule{
+		{PathRegex: `secrets/development\.yaml$`, Age: "age1dev"},
+		{PathRegex: `secrets/staging\.yaml$`, Age: "age1dev,age1ci"},
+		{PathRegex: `secrets/production\.yaml$`, Age: "age1prod"},
+		{PathRegex: `secrets/.*\.yaml$`, Age: "age1dev"},
+	}, cfg.CreationRules)
+
+	t.Log("Round Trips")
+	{
+		for _, e := range env.All {
+			got, err := cfg.Recipients(FilePathFromEnv(e))
+			require.NoError(t, err)
+			assert.Equal(t, r[e], got)
+		}
+	}
+}
+
+func TestRecipients_Add(t *testing.T) {
+	t.Parallel()
+
+	r := DefaultRecipients()
+
+	assert.True(t, r.Add(env.Production, "age1prod"))
+	assert.False(t, r.Add(env.Production, "age1prod"))
+	assert.Equal(t, []string{AgePublicKey, "age1prod"}, r[env.Production])
+	assert.Equal(t, []string{AgePublicKey}, r[env.Staging])
+}
+
+func TestRecipients_Remove(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		r := Recipients{env.Production: {AgePublicKey, "age1prod"}}
+		require.NoError(t, r.Remove(env.Production, AgePublicKey))
+		assert.Equal(t, []string{"age1prod"}, r[env.Production])
+	})
+
+	t.Run("Not A Recipient", func(t *testing.T) {
+		t.Parallel()
+
+		r := Recipients{env.Production: {"age1prod"}}
+		assert.ErrorContains(t, r.Remove(env.Production, "age1other"), "is not a recipient of production")
+	})
+
+	t.Run("Last Recipient", func(t *testing.T) {
+		t.Parallel()
+
+		r := Recipients{env.Production: {"age1prod"}}
+		assert.ErrorContains(t, r.Remove(env.Production, "age1prod"), "last recipient of production")
+	})
+}
+
+func TestRecipients_SharedWithProduction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Default", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []string{AgePublicKey}, DefaultRecipients().SharedWithProduction())
+	})
+
+	t.Run("Separate Key", func(t *testing.T) {
+		t.Parallel()
+
+		r := Recipients{
+			env.Development: {"age1dev"},
+			env.Staging:     {"age1dev"},
+			env.Production:  {"age1prod"},
+		}
+		assert.Nil(t, r.SharedWithProduction())
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 
 	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/secrets/backend"
 	"github.com/ainsleydev/webkit/internal/secrets/sops"
 	"github.com/ainsleydev/webkit/pkg/env"
 )
@@ -21,12 +22,38 @@ type OutputKey struct {
 // TerraformOutputProvider provides access to Terraform outputs for resource resolution.
 type TerraformOutputProvider map[OutputKey]any
 
+// hasEnvironment returns true if any outputs have been
+// provided for the given environment.
+func (p *TerraformOutputProvider) hasEnvironment(environment env.Environment) bool {
+	if p == nil {
+		return false
+	}
+	for key := range *p {
+		if key.Environment == environment {
+			return true
+		}
+	}
+	return false
+}
+
 // ResolveConfig defines the data needed in order to decrypt the
 // definitions environments secrets.
 type ResolveConfig struct {
 	SOPSClient      sops.EncrypterDecrypter
 	BaseDir         string
 	TerraformOutput *TerraformOutputProvider
+	// Backends overrides the secret backends of each source, those
+	// without one are created from the environment with backend.New.
+	Backends map[appdef.EnvSource]backend.Backend
+}
+
+// backendFor returns the backend that secrets of the source are
+// fetched from.
+func (c ResolveConfig) backendFor(source appdef.EnvSource) (backend.Backend, error) {
+	if b, ok := c.Backends[source]; ok {
+		return b, nil
+	}
+	return backend.New(source)
 }
 
 func Resolve(ctx context.Context, def *appdef.Definition, cfg ResolveConfig) error {
@@ -155,8 +182,10 @@ var resolver = map[appdef.EnvSource]resolveFunc{
 	// Resource reference - resolves infrastructure outputs by querying Terraform state.
 	// This allows environment variables to reference resources defined in app.json (e.g., "db.connection_url").
 	appdef.EnvSourceResource: func(_ context.Context, rc resolveContext) error {
-		// Don't resolve anything that's not production now.
-		if rc.env != env.Production {
+		// Only production is provisioned by Terraform, other environments
+		// are resolved when outputs have been provided for them, such as
+		// the local Docker Compose stack used by webkit dev.
+		if rc.env != env.Production && !rc.cfg.TerraformOutput.hasEnvironment(rc.env) {
 			return nil
 		}
 
@@ -193,7 +222,7 @@ var resolver = map[appdef.EnvSource]resolveFunc{
 
 		// If it's an internal error and not decrypted, bail early
 		// as we can't resolve much!
-		resolvedMap, err := sops.DecryptFileToMap(rc.cfg.SOPSClient, path)
+		resolvedMap, err := sops.DecryptFileToMap(sops.WithKeys(rc.cfg.SOPSClient, rc.key), path)
 		if err != nil && !errors.Is(err, sops.ErrNotEncrypted) {
 			return err
 		}
@@ -210,4 +239,35 @@ var resolver = map[appdef.EnvSource]resolveFunc{
 
 		return nil
 	},
+	// External secret managers - fetch from the backend of the source.
+	appdef.EnvSourceOnePassword: resolveBackend,
+	appdef.EnvSourceDoppler:     resolveBackend,
+	appdef.EnvSourceVault:       resolveBackend,
+	appdef.EnvSourceGitHub:      resolveBackend,
+}
+
+// resolveBackend fetches a secret from the external secret manager of
+// its source, using the value as the reference.
+func resolveBackend(ctx context.Context, rc resolveContext) error {
+	ref, ok := rc.config.Value.(string)
+	if !ok {
+		return fmt.Errorf("invalid %s reference for key '%s': expected a string, got '%v'", rc.config.Source, rc.key, rc.config.Value)
+	}
+
+	b, err := rc.cfg.backendFor(rc.config.Source)
+	if err != nil {
+		return err
+	}
+
+	secret, err := b.Get(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("resolving %s secret for key '%s': %w", rc.config.Source, rc.key, err)
+	}
+
+	rc.vars[rc.key] = appdef.EnvValue{
+		Source: rc.config.Source,
+		Value:  secret,
+	}
+
+	return nil
 }
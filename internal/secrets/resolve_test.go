@@ -1,6 +1,8 @@
 package secrets
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/ainsleydev/webkit/internal/appdef"
 	"github.com/ainsleydev/webkit/internal/mocks"
+	"github.com/ainsleydev/webkit/internal/secrets/backend"
 	"github.com/ainsleydev/webkit/pkg/env"
 )
 
@@ -176,6 +179,90 @@ DB_PASS: dbpass123
 		assert.Equal(t, def.Apps[0].Env.Dev["DB_PASS"].Value, "dbpass123")
 	})
 
+	t.Run("Secret Backends", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/secret/data/website", r.URL.Path)
+			_, _ = w.Write([]byte(`{"data":{"data":{"stripe_key":"sk_live"},"metadata":{}}}`))
+		}))
+		t.Cleanup(srv.Close)
+
+		vault, err := backend.New(appdef.EnvSourceVault, backend.WithCredentials(backend.Credentials{
+			Address: srv.URL,
+			Token:   "token",
+		}))
+		require.NoError(t, err)
+
+		t.Setenv("GITHUB_SECRET", "from-actions")
+
+		def := &appdef.Definition{
+			Apps: []appdef.App{
+				{
+					Name: "web",
+					Env: appdef.Environment{
+						Production: map[string]appdef.EnvValue{
+							"STRIPE_KEY": {Source: appdef.EnvSourceVault, Value: "secret/data/website#stripe_key"},
+							"GITHUB":     {Source: appdef.EnvSourceGitHub, Value: "GITHUB_SECRET"},
+						},
+					},
+				},
+			},
+		}
+
+		err = ResolveForEnvironment(t.Context(), def, env.Production, ResolveConfig{
+			Backends: map[appdef.EnvSource]backend.Backend{appdef.EnvSourceVault: vault},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "sk_live", def.Apps[0].Env.Production["STRIPE_KEY"].Value)
+		assert.Equal(t, appdef.EnvSourceVault, def.Apps[0].Env.Production["STRIPE_KEY"].Source)
+		assert.Equal(t, "from-actions", def.Apps[0].Env.Production["GITHUB"].Value)
+	})
+
+	t.Run("Secret Backend Error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "permission denied", http.StatusForbidden)
+		}))
+		t.Cleanup(srv.Close)
+
+		vault, err := backend.New(appdef.EnvSourceVault, backend.WithCredentials(backend.Credentials{
+			Address: srv.URL,
+			Token:   "token",
+		}))
+		require.NoError(t, err)
+
+		def := &appdef.Definition{
+			Shared: appdef.Shared{
+				Env: appdef.Environment{
+					Dev: map[string]appdef.EnvValue{
+						"STRIPE_KEY": {Source: appdef.EnvSourceVault, Value: "secret/data/website#stripe_key"},
+					},
+				},
+			},
+		}
+
+		err = Resolve(t.Context(), def, ResolveConfig{
+			Backends: map[appdef.EnvSource]backend.Backend{appdef.EnvSourceVault: vault},
+		})
+		assert.ErrorContains(t, err, "resolving vault secret for key 'STRIPE_KEY'")
+		assert.ErrorContains(t, err, "unexpected status 403")
+	})
+
+	t.Run("Secret Backend Missing Credentials", func(t *testing.T) {
+		t.Setenv("DOPPLER_TOKEN", "")
+
+		def := &appdef.Definition{
+			Shared: appdef.Shared{
+				Env: appdef.Environment{
+					Dev: map[string]appdef.EnvValue{
+						"STRIPE_KEY": {Source: appdef.EnvSourceDoppler, Value: "STRIPE_KEY"},
+					},
+				},
+			},
+		}
+
+		err := Resolve(t.Context(), def, ResolveConfig{})
+		assert.ErrorContains(t, err, "DOPPLER_TOKEN must be set to resolve doppler secrets")
+	})
+
 	t.Run("Default SOPS Does Not Mutate Across Environments", func(t *testing.T) {
 		// This test ensures that SOPS secrets defined in the Default section
 		// are resolved independently for each environment using their respective
@@ -399,4 +486,32 @@ func TestResolveForEnvironment(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "terraform output not found")
 	})
+
+	t.Run("Resolves Development When Outputs Provided", func(t *testing.T) {
+		def := &appdef.Definition{
+			Apps: []appdef.App{
+				{
+					Name: "test-app",
+					Env: appdef.Environment{
+						Dev: map[string]appdef.EnvValue{
+							"DATABASE_URI": {Source: appdef.EnvSourceResource, Value: "db.connection_url"},
+						},
+					},
+				},
+			},
+		}
+
+		// Outputs for the local stack (webkit dev).
+		tfOutputs := &TerraformOutputProvider{
+			OutputKey{
+				Environment:  env.Development,
+				ResourceName: "db",
+				OutputName:   "connection_url",
+			}: "postgresql://db:5432",
+		}
+
+		err := ResolveForEnvironment(t.Context(), def, env.Development, ResolveConfig{TerraformOutput: tfOutputs})
+		require.NoError(t, err)
+		assert.Equal(t, "postgresql://db:5432", def.Apps[0].Env.Dev["DATABASE_URI"].Value)
+	})
 }
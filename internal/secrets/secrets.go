@@ -2,11 +2,13 @@ package secrets
 
 import (
 	"path/filepath"
+	"strings"
 
 	"github.com/ainsleydev/webkit/pkg/env"
 )
 
-// AgePublicKey is the public key for encrypting SOPS files.
+// AgePublicKey is the default public key for encrypting SOPS files,
+// used by environments without their own recipients in .sops.yaml.
 const AgePublicKey = "age1mcl448l48v0e4t5ljek8htn5s07amz5zwr54hkq5qpawgapc9dpstnhksq"
 
 // FilePath defines the path where SOPS encrypted YAML files
@@ -19,3 +21,10 @@ var FilePath = filepath.Join("resources", "secrets")
 func FilePathFromEnv(e env.Environment) string {
 	return filepath.Join(FilePath, e.String()+".yaml")
 }
+
+// AgeKeySecretName returns the name of the GitHub Actions secret that
+// holds the age private key of the environment, for example
+// REPO_AGE_SECRET_PRODUCTION.
+func AgeKeySecretName(e env.Environment) string {
+	return "REPO_AGE_SECRET_" + strings.ToUpper(e.String())
+}
@@ -0,0 +1,131 @@
+package sops
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Value types stored alongside each encrypted value so they can be
+// decoded back to their original YAML type.
+const (
+	valueTypeString = "str"
+	valueTypeInt    = "int"
+	valueTypeFloat  = "float"
+	valueTypeBool   = "bool"
+)
+
+const (
+	// dataKeySize is the size of the AES-256 key that encrypts every
+	// value in a file, itself encrypted for each age recipient.
+	dataKeySize = 32
+	// ivSize is the nonce size used by SOPS for AES-GCM, which is
+	// larger than the standard 12 bytes.
+	ivSize = 32
+	// tagSize is the size of the GCM authentication tag.
+	tagSize = 16
+)
+
+// encryptedValueRegex matches values encrypted by SOPS, e.g.
+// ENC[AES256_GCM,data:...,iv:...,tag:...,type:str].
+var encryptedValueRegex = regexp.MustCompile(`^ENC\[AES256_GCM,data:(.*),iv:(.+),tag:(.+),type:(.+)\]$`)
+
+// isEncryptedValue returns true if the value is in the SOPS encrypted
+// value format.
+func isEncryptedValue(value string) bool {
+	return strings.HasPrefix(value, "ENC[")
+}
+
+// encryptValue encrypts a plaintext value with AES-256-GCM, using the
+// path of the value as additional data so values can't be moved
+// between keys.
+//
+// Empty values are left empty, matching SOPS.
+func encryptValue(plaintext, valueType string, key []byte, aad string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	iv := make([]byte, ivSize)
+	if _, err = rand.Read(iv); err != nil {
+		return "", fmt.Errorf("generating iv: %w", err)
+	}
+
+	sealed := gcm.Seal(nil, iv, []byte(plaintext), []byte(aad))
+	data, tag := sealed[:len(sealed)-tagSize], sealed[len(sealed)-tagSize:]
+
+	return fmt.Sprintf("ENC[AES256_GCM,data:%s,iv:%s,tag:%s,type:%s]",
+		base64.StdEncoding.EncodeToString(data),
+		base64.StdEncoding.EncodeToString(iv),
+		base64.StdEncoding.EncodeToString(tag),
+		valueType,
+	), nil
+}
+
+// decryptValue decrypts a value in the SOPS encrypted value format,
+// returning the plaintext and the type of the original value.
+func decryptValue(value string, key []byte, aad string) (plaintext, valueType string, err error) {
+	if value == "" {
+		return "", valueTypeString, nil
+	}
+
+	matches := encryptedValueRegex.FindStringSubmatch(value)
+	if matches == nil {
+		return "", "", fmt.Errorf("malformed encrypted value %q", value)
+	}
+
+	parts := make([][]byte, 3)
+	for i, part := range matches[1:4] {
+		parts[i], err = base64.StdEncoding.DecodeString(part)
+		if err != nil {
+			return "", "", fmt.Errorf("decoding encrypted value: %w", err)
+		}
+	}
+	data, iv, tag := parts[0], parts[1], parts[2]
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", "", err
+	}
+
+	opened, err := gcm.Open(nil, iv, append(data, tag...), []byte(aad))
+	if err != nil {
+		return "", "", fmt.Errorf("decrypting value at %q: %w", aad, err)
+	}
+
+	return string(opened), matches[4], nil
+}
+
+// newGCM returns an AES-GCM cipher with the SOPS nonce size.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %w", err)
+	}
+	return cipher.NewGCMWithNonceSize(block, ivSize)
+}
+
+// macBytes returns the bytes of a plaintext value that are added to
+// the message authentication code. Booleans are hashed as True or
+// False to match SOPS.
+func macBytes(plaintext, valueType string) []byte {
+	if valueType == valueTypeBool {
+		if b, err := strconv.ParseBool(plaintext); err == nil {
+			if b {
+				return []byte("True")
+			}
+			return []byte("False")
+		}
+	}
+	return []byte(plaintext)
+}
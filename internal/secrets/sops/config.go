@@ -0,0 +1,87 @@
+package sops
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"filippo.io/age"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileName is the name of the file sops reads creation rules
+// from, which determine the keys a file is encrypted for.
+const ConfigFileName = ".sops.yaml"
+
+type (
+	// Config is the contents of a .sops.yaml file. Only creation
+	// rules with age keys are supported.
+	Config struct {
+		CreationRules []CreationRule `yaml:"creation_rules"`
+	}
+	// CreationRule defines the age recipients that files matching
+	// PathRegex are encrypted for. Age is a comma separated list of
+	// public keys, as expected by sops.
+	CreationRule struct {
+		PathRegex string `yaml:"path_regex"`
+		Age       string `yaml:"age"`
+	}
+)
+
+// ParseConfig parses the contents of a .sops.yaml file.
+func ParseConfig(data []byte) (*Config, error) {
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parsing sops config: %w", err)
+	}
+	return cfg, nil
+}
+
+// Recipients returns the age public keys of the first creation rule
+// that matches the file path, in the same way sops picks a rule.
+//
+// Returns nil if no rule matches.
+func (c Config) Recipients(filePath string) ([]string, error) {
+	filePath = filepath.ToSlash(filePath)
+
+	for _, rule := range c.CreationRules {
+		if rule.PathRegex != "" {
+			re, err := regexp.Compile(rule.PathRegex)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path_regex %q in sops config: %w", rule.PathRegex, err)
+			}
+			if !re.MatchString(filePath) {
+				continue
+			}
+		}
+		return rule.Recipients(), nil
+	}
+
+	return nil, nil
+}
+
+// Recipients returns the public keys in the comma separated Age
+// list of the rule.
+func (r CreationRule) Recipients() []string {
+	var keys []string
+	for _, key := range strings.Split(r.Age, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// ParseRecipients parses age public keys, e.g. age1ql3z7hjy54pw3....
+func ParseRecipients(keys []string) ([]age.Recipient, error) {
+	recipients := make([]age.Recipient, 0, len(keys))
+	for _, key := range keys {
+		recipient, err := age.ParseX25519Recipient(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid age public key %q: %w", key, err)
+		}
+		recipients = append(recipients, recipient)
+	}
+	return recipients, nil
+}
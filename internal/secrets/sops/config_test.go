@@ -0,0 +1,93 @@
+package sops
+
+import (
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := ParseConfig([]byte("creation_rules:\n  - path_regex: secrets/.*\\.yaml$\n    age: age1abc,age1def\n"))
+		require.NoError(t, err)
+		assert.Equal(t, []CreationRule{{PathRegex: `secrets/.*\.yaml$`, Age: "age1abc,age1def"}}, got.CreationRules)
+	})
+
+	t.Run("Invalid YAML", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseConfig([]byte("creation_rules: [\n"))
+		assert.ErrorContains(t, err, "parsing sops config")
+	})
+}
+
+func TestConfig_Recipients(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{CreationRules: []CreationRule{
+		{PathRegex: `secrets/production\.yaml$`, Age: "age1prod"},
+		{PathRegex: `secrets/.*\.yaml$`, Age: "age1dev, age1ci"},
+	}}
+
+	tt := map[string]struct {
+		path string
+		want []string
+	}{
+		"First Match Wins": {path: "/project/resources/secrets/production.yaml", want: []string{"age1prod"}},
+		"Catch All":        {path: "resources/secrets/staging.yaml", want: []string{"age1dev", "age1ci"}},
+		"No Match":         {path: "resources/other.json", want: nil},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := cfg.Recipients(test.path)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("Rule Without Regex", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := Config{CreationRules: []CreationRule{{Age: "age1all"}}}.Recipients("any.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"age1all"}, got)
+	})
+
+	t.Run("Invalid Regex", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Config{CreationRules: []CreationRule{{PathRegex: "("}}}.Recipients("any.yaml")
+		assert.ErrorContains(t, err, "invalid path_regex")
+	})
+}
+
+func TestParseRecipients(t *testing.T) {
+	t.Parallel()
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := ParseRecipients([]string{identity.Recipient().String()})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseRecipients([]string{"age1nope"})
+		assert.ErrorContains(t, err, `invalid age public key "age1nope"`)
+	})
+}
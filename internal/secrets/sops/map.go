@@ -10,7 +10,14 @@ import (
 
 // DecryptFileToMap decrypts a file using the provided Decrypter and
 // returns the content as a map[string]any.
+//
+// Clients that implement DataDecrypter decrypt the file in memory,
+// otherwise the file is decrypted in place and encrypted again.
 func DecryptFileToMap(ec EncrypterDecrypter, filePath string) (map[string]any, error) {
+	if dd, ok := ec.(DataDecrypter); ok {
+		return decryptDataToMap(dd, filePath)
+	}
+
 	decryptErr := ec.Decrypt(filePath)
 	if decryptErr != nil && !errors.Is(decryptErr, ErrNotEncrypted) {
 		return nil, decryptErr
@@ -35,3 +42,26 @@ func DecryptFileToMap(ec EncrypterDecrypter, filePath string) (map[string]any, e
 
 	return data, nil
 }
+
+// decryptDataToMap reads and decrypts a file in memory, unencrypted
+// files are parsed as they are.
+func decryptDataToMap(dd DataDecrypter, filePath string) (map[string]any, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sops file: %w", err)
+	}
+
+	decrypted, err := DecryptFileData(dd, filePath, content)
+	if err == nil {
+		content = decrypted
+	} else if !errors.Is(err, ErrNotEncrypted) {
+		return nil, err
+	}
+
+	var data map[string]any
+	if err = yaml.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse sops content: %w", err)
+	}
+
+	return data, nil
+}
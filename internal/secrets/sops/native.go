@@ -0,0 +1,544 @@
+package sops
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"gopkg.in/yaml.v3"
+)
+
+// NativeClient encrypts and decrypts SOPS files in process using age,
+// without the sops binary. Files are read and written in the same
+// format as sops, so either can be used on the same files.
+//
+// Comments aren't carried over when encrypting or decrypting, and
+// only age keys are supported.
+type NativeClient struct {
+	identities []age.Identity
+	recipients []age.Recipient
+	config     *Config
+}
+
+var (
+	_ EncrypterDecrypter = (*NativeClient)(nil)
+	_ DataEncrypter      = (*NativeClient)(nil)
+	_ DataDecrypter      = (*NativeClient)(nil)
+)
+
+// ErrNoMatchingIdentity is returned when none of the identities of
+// the client can decrypt the data key of a file.
+var ErrNoMatchingIdentity = errors.New("no age identity can decrypt the file")
+
+const (
+	// metadataKey is the top-level key SOPS stores its metadata under.
+	metadataKey = "sops"
+	// defaultUnencryptedSuffix is the suffix of keys that are left in
+	// plaintext, the SOPS default.
+	defaultUnencryptedSuffix = "_unencrypted"
+	// metadataVersion is the SOPS version written to the metadata of
+	// encrypted files, the format is compatible with sops >= 3.9.
+	metadataVersion = "3.9.0"
+)
+
+// NewNativeClient creates a SOPS client that decrypts files with the
+// given identities and encrypts them for the given recipients.
+func NewNativeClient(identities []age.Identity, recipients []age.Recipient) *NativeClient {
+	return &NativeClient{
+		identities: identities,
+		recipients: recipients,
+	}
+}
+
+// WithConfig returns a copy of the client that encrypts files for the
+// recipients of the first creation rule in the config that matches
+// the file path. Files without a matching rule are encrypted for the
+// client's recipients.
+func (c *NativeClient) WithConfig(cfg *Config) *NativeClient {
+	clone := *c
+	clone.config = cfg
+	return &clone
+}
+
+type (
+	// metadata is the sops block of an encrypted file.
+	metadata struct {
+		Age               []ageStanza `yaml:"age"`
+		LastModified      string      `yaml:"lastmodified"`
+		MAC               string      `yaml:"mac"`
+		UnencryptedSuffix string      `yaml:"unencrypted_suffix,omitempty"`
+		EncryptedSuffix   string      `yaml:"encrypted_suffix,omitempty"`
+		UnencryptedRegex  string      `yaml:"unencrypted_regex,omitempty"`
+		EncryptedRegex    string      `yaml:"encrypted_regex,omitempty"`
+		MACOnlyEncrypted  bool        `yaml:"mac_only_encrypted,omitempty"`
+		Version           string      `yaml:"version"`
+	}
+	// ageStanza is the data key encrypted for a single age recipient.
+	ageStanza struct {
+		Recipient string `yaml:"recipient"`
+		Enc       string `yaml:"enc"`
+	}
+)
+
+// Encrypt encrypts a plaintext SOPS file in place.
+//
+// Returns ErrAlreadyEncrypted if the file already contains SOPS
+// metadata. Empty files are left untouched.
+func (c *NativeClient) Encrypt(filePath string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read sops file: %w", err)
+	}
+
+	if len(bytes.TrimSpace(content)) == 0 {
+		return nil // If the file is empty, don't worry :)
+	}
+
+	recipients, err := c.recipientsFor(filePath)
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := c.encryptData(content, recipients)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(filePath, encrypted)
+}
+
+// Decrypt decrypts a SOPS file in place.
+//
+// Returns ErrNotEncrypted if the file doesn't contain SOPS metadata.
+func (c *NativeClient) Decrypt(filePath string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read sops file: %w", err)
+	}
+
+	decrypted, err := c.DecryptData(content)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(filePath, decrypted)
+}
+
+// EncryptData encrypts plaintext YAML, returning the SOPS encrypted
+// document. Every value is encrypted with a new data key, which is
+// encrypted for each recipient of the client.
+func (c *NativeClient) EncryptData(plaintext []byte) ([]byte, error) {
+	return c.encryptData(plaintext, c.recipients)
+}
+
+func (c *NativeClient) encryptData(plaintext []byte, recipients []age.Recipient) ([]byte, error) {
+	if len(recipients) == 0 {
+		return nil, errors.New("sops encrypt failed: no age recipients to encrypt for")
+	}
+
+	doc, root, err := parseDocument(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return plaintext, nil
+	}
+
+	if mappingIndex(root, metadataKey) != -1 {
+		return nil, ErrAlreadyEncrypted
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err = rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("generating data key: %w", err)
+	}
+
+	meta := metadata{
+		LastModified:      time.Now().UTC().Format(time.RFC3339),
+		UnencryptedSuffix: defaultUnencryptedSuffix,
+		Version:           metadataVersion,
+	}
+
+	hash := sha512.New()
+	err = walkLeaves(root, nil, func(node *yaml.Node, path []string) error {
+		plain, valueType := scalarPlaintext(node)
+		encrypted := meta.shouldEncrypt(path)
+		if !meta.MACOnlyEncrypted || encrypted {
+			hash.Write(macBytes(plain, valueType))
+		}
+		if !encrypted {
+			return nil
+		}
+
+		value, err := encryptValue(plain, valueType, dataKey, pathAAD(path))
+		if err != nil {
+			return err
+		}
+		setScalar(node, value, valueTypeString)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sops encrypt failed: %w", err)
+	}
+
+	meta.MAC, err = encryptValue(fmt.Sprintf("%X", hash.Sum(nil)), valueTypeString, dataKey, meta.LastModified)
+	if err != nil {
+		return nil, fmt.Errorf("sops encrypt failed: %w", err)
+	}
+
+	for _, recipient := range recipients {
+		stanza, err := encryptDataKey(dataKey, recipient)
+		if err != nil {
+			return nil, fmt.Errorf("sops encrypt failed: %w", err)
+		}
+		meta.Age = append(meta.Age, stanza)
+	}
+
+	var metaNode yaml.Node
+	if err = metaNode.Encode(meta); err != nil {
+		return nil, fmt.Errorf("encoding sops metadata: %w", err)
+	}
+	root.Content = append(root.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: metadataKey},
+		&metaNode,
+	)
+
+	return encodeDocument(doc)
+}
+
+// DecryptData decrypts a SOPS encrypted document, returning the
+// plaintext YAML without the SOPS metadata. The message authentication
+// code is verified so tampered files are rejected.
+//
+// Returns ErrNotEncrypted if the document doesn't contain SOPS metadata.
+func (c *NativeClient) DecryptData(ciphertext []byte) ([]byte, error) {
+	doc, root, err := parseDocument(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, ErrNotEncrypted
+	}
+
+	idx := mappingIndex(root, metadataKey)
+	if idx == -1 {
+		return nil, ErrNotEncrypted
+	}
+
+	var meta metadata
+	if err = root.Content[idx+1].Decode(&meta); err != nil {
+		return nil, fmt.Errorf("parsing sops metadata: %w", err)
+	}
+	root.Content = slices.Delete(root.Content, idx, idx+2)
+
+	dataKey, err := c.decryptDataKey(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha512.New()
+	err = walkLeaves(root, nil, func(node *yaml.Node, path []string) error {
+		encrypted := meta.shouldEncrypt(path)
+
+		var plain, valueType string
+		if encrypted {
+			var err error
+			plain, valueType, err = decryptValue(node.Value, dataKey, pathAAD(path))
+			if err != nil {
+				return err
+			}
+			setScalar(node, plain, valueType)
+		} else {
+			plain, valueType = scalarPlaintext(node)
+		}
+
+		if !meta.MACOnlyEncrypted || encrypted {
+			hash.Write(macBytes(plain, valueType))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sops decrypt failed: %w", err)
+	}
+
+	if err = verifyMAC(meta, dataKey, fmt.Sprintf("%X", hash.Sum(nil))); err != nil {
+		return nil, err
+	}
+
+	return encodeDocument(doc)
+}
+
+// recipientsFor returns the recipients a file is encrypted for, from
+// the config if one of its rules matches the path.
+func (c *NativeClient) recipientsFor(filePath string) ([]age.Recipient, error) {
+	if c.config == nil {
+		return c.recipients, nil
+	}
+
+	keys, err := c.config.Recipients(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return c.recipients, nil
+	}
+
+	return ParseRecipients(keys)
+}
+
+// decryptDataKey returns the data key of the file from the first age
+// stanza that one of the client's identities can decrypt.
+func (c *NativeClient) decryptDataKey(meta metadata) ([]byte, error) {
+	if len(c.identities) == 0 || len(meta.Age) == 0 {
+		return nil, ErrNoMatchingIdentity
+	}
+
+	for _, stanza := range meta.Age {
+		r, err := age.Decrypt(armor.NewReader(strings.NewReader(stanza.Enc)), c.identities...)
+		if err != nil {
+			continue
+		}
+		key, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("reading data key: %w", err)
+		}
+		return key, nil
+	}
+
+	return nil, ErrNoMatchingIdentity
+}
+
+// encryptDataKey encrypts the data key for a single recipient as an
+// armored age file.
+func encryptDataKey(dataKey []byte, recipient age.Recipient) (ageStanza, error) {
+	var buf bytes.Buffer
+	aw := armor.NewWriter(&buf)
+
+	w, err := age.Encrypt(aw, recipient)
+	if err != nil {
+		return ageStanza{}, fmt.Errorf("encrypting data key: %w", err)
+	}
+	if _, err = w.Write(dataKey); err != nil {
+		return ageStanza{}, fmt.Errorf("encrypting data key: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return ageStanza{}, fmt.Errorf("encrypting data key: %w", err)
+	}
+	if err = aw.Close(); err != nil {
+		return ageStanza{}, fmt.Errorf("encrypting data key: %w", err)
+	}
+
+	stanza := ageStanza{Enc: buf.String()}
+	if s, ok := recipient.(fmt.Stringer); ok {
+		stanza.Recipient = s.String()
+	}
+
+	return stanza, nil
+}
+
+// verifyMAC checks the message authentication code of the file against
+// the one computed from the decrypted values.
+func verifyMAC(meta metadata, dataKey []byte, computed string) error {
+	if meta.MAC == "" {
+		return errors.New("sops decrypt failed: no message authentication code in metadata")
+	}
+
+	// SOPS uses the last modified timestamp in RFC3339 as the
+	// additional data of the MAC.
+	lastModified := meta.LastModified
+	if t, err := time.Parse(time.RFC3339, lastModified); err == nil {
+		lastModified = t.Format(time.RFC3339)
+	}
+
+	mac, _, err := decryptValue(meta.MAC, dataKey, lastModified)
+	if err != nil {
+		return fmt.Errorf("sops decrypt failed: decrypting mac: %w", err)
+	}
+	if mac != computed {
+		return errors.New("sops decrypt failed: message authentication code mismatch, the file has been modified")
+	}
+
+	return nil
+}
+
+// shouldEncrypt reports whether the value at path is encrypted,
+// following the key selection rules stored in the metadata.
+func (m metadata) shouldEncrypt(path []string) bool {
+	encrypted := true
+	if m.UnencryptedSuffix != "" {
+		for _, key := range path {
+			if strings.HasSuffix(key, m.UnencryptedSuffix) {
+				encrypted = false
+				break
+			}
+		}
+	}
+	if m.EncryptedSuffix != "" {
+		encrypted = slices.ContainsFunc(path, func(key string) bool {
+			return strings.HasSuffix(key, m.EncryptedSuffix)
+		})
+	}
+	if m.UnencryptedRegex != "" {
+		if re, err := regexp.Compile(m.UnencryptedRegex); err == nil && slices.ContainsFunc(path, re.MatchString) {
+			encrypted = false
+		}
+	}
+	if m.EncryptedRegex != "" {
+		re, err := regexp.Compile(m.EncryptedRegex)
+		encrypted = err == nil && slices.ContainsFunc(path, re.MatchString)
+	}
+	return encrypted
+}
+
+// pathAAD returns the additional data of a value, the keys leading to
+// it joined and terminated with a colon, e.g. "db:password:".
+func pathAAD(path []string) string {
+	return strings.Join(path, ":") + ":"
+}
+
+// walkLeaves calls fn for every non-null scalar in the node along with
+// the path of mapping keys leading to it. Sequence items share the
+// path of the sequence.
+func walkLeaves(node *yaml.Node, path []string, fn func(node *yaml.Node, path []string) error) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyPath := append(slices.Clone(path), node.Content[i].Value)
+			if err := walkLeaves(node.Content[i+1], keyPath, fn); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if err := walkLeaves(item, path, fn); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		if node.ShortTag() == "!!null" {
+			return nil
+		}
+		return fn(node, path)
+	case yaml.AliasNode:
+		return fmt.Errorf("yaml aliases are not supported at %q", pathAAD(path))
+	}
+	return nil
+}
+
+// scalarPlaintext returns the value of a scalar as it's encrypted by
+// SOPS, along with its type.
+func scalarPlaintext(node *yaml.Node) (string, string) {
+	switch node.ShortTag() {
+	case "!!int":
+		var i int
+		if err := node.Decode(&i); err == nil {
+			return strconv.Itoa(i), valueTypeInt
+		}
+	case "!!float":
+		var f float64
+		if err := node.Decode(&f); err == nil {
+			return strconv.FormatFloat(f, 'f', -1, 64), valueTypeFloat
+		}
+	case "!!bool":
+		var b bool
+		if err := node.Decode(&b); err == nil {
+			return strconv.FormatBool(b), valueTypeBool
+		}
+	}
+	return node.Value, valueTypeString
+}
+
+// setScalar replaces the value of a scalar, tagging it with the YAML
+// type of the SOPS value type so it's quoted correctly when encoded.
+func setScalar(node *yaml.Node, value, valueType string) {
+	tag := "!!str"
+	switch valueType {
+	case valueTypeInt:
+		tag = "!!int"
+	case valueTypeFloat:
+		tag = "!!float"
+	case valueTypeBool:
+		tag = "!!bool"
+	}
+	node.Value = value
+	node.Tag = tag
+	node.Style = 0
+}
+
+// parseDocument parses the YAML document, returning the root mapping
+// or nil if the document is empty.
+func parseDocument(content []byte) (*yaml.Node, *yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse sops content: %w", err)
+	}
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		return &doc, nil, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, nil, errors.New("failed to parse sops content: document must be a mapping")
+	}
+	stripComments(&doc)
+
+	return &doc, root, nil
+}
+
+// encodeDocument encodes the document with the indentation used by SOPS.
+func encodeDocument(doc *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(4)
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("encoding yaml: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("encoding yaml: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// mappingIndex returns the index of the key in a mapping node's
+// content, or -1 if it doesn't exist.
+func mappingIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// stripComments removes every comment from the node and its children.
+func stripComments(node *yaml.Node) {
+	node.HeadComment, node.LineComment, node.FootComment = "", "", ""
+	for _, child := range node.Content {
+		stripComments(child)
+	}
+}
+
+// writeFile replaces the contents of a file, keeping its permissions.
+func writeFile(filePath string, content []byte) error {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat sops file: %w", err)
+	}
+	if err = os.WriteFile(filePath, content, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write sops file: %w", err)
+	}
+	return nil
+}
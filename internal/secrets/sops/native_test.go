@@ -0,0 +1,470 @@
+package sops
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func newNativeClient(t *testing.T) (*NativeClient, *age.X25519Identity) {
+	t.Helper()
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	return NewNativeClient([]age.Identity{identity}, []age.Recipient{identity.Recipient()}), identity
+}
+
+func TestNativeClient_EncryptData(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Format", func(t *testing.T) {
+		t.Parallel()
+
+		client, identity := newNativeClient(t)
+
+		got, err := client.EncryptData([]byte("PAYLOAD_SECRET: secret\nPORT: 3000\nDEBUG: true\nEMPTY: \"\"\n"))
+		require.NoError(t, err)
+
+		var out map[string]any
+		require.NoError(t, yaml.Unmarshal(got, &out))
+
+		assert.Regexp(t, `^ENC\[AES256_GCM,data:.+,iv:.+,tag:.+,type:str\]$`, out["PAYLOAD_SECRET"])
+		assert.Regexp(t, `type:int\]$`, out["PORT"])
+		assert.Regexp(t, `type:bool\]$`, out["DEBUG"])
+		assert.Equal(t, "", out["EMPTY"])
+
+		meta, ok := out["sops"].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, defaultUnencryptedSuffix, meta["unencrypted_suffix"])
+		assert.Equal(t, metadataVersion, meta["version"])
+		assert.Regexp(t, `^ENC\[AES256_GCM,.+type:str\]$`, meta["mac"])
+
+		stanzas, ok := meta["age"].([]any)
+		require.True(t, ok)
+		require.Len(t, stanzas, 1)
+		stanza := stanzas[0].(map[string]any)
+		assert.Equal(t, identity.Recipient().String(), stanza["recipient"])
+		assert.True(t, strings.HasPrefix(stanza["enc"].(string), "-----BEGIN AGE ENCRYPTED FILE-----"))
+		assert.NotContains(t, string(got), "secret\n")
+	})
+
+	t.Run("Already Encrypted", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newNativeClient(t)
+
+		encrypted, err := client.EncryptData([]byte("KEY: value\n"))
+		require.NoError(t, err)
+
+		_, err = client.EncryptData(encrypted)
+		assert.ErrorIs(t, err, ErrAlreadyEncrypted)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newNativeClient(t)
+
+		got, err := client.EncryptData([]byte{})
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("No Recipients", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewNativeClient(nil, nil).EncryptData([]byte("KEY: value\n"))
+		assert.ErrorContains(t, err, "no age recipients")
+	})
+
+	t.Run("Not A Mapping", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newNativeClient(t)
+
+		_, err := client.EncryptData([]byte("- one\n- two\n"))
+		assert.ErrorContains(t, err, "document must be a mapping")
+	})
+
+	t.Run("Invalid YAML", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newNativeClient(t)
+
+		_, err := client.EncryptData([]byte("key: value\nunbalanced"))
+		assert.ErrorContains(t, err, "failed to parse sops content")
+	})
+}
+
+func TestNativeClient_DecryptData(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Round Trip", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newNativeClient(t)
+		plaintext := `PAYLOAD_SECRET: secret
+PORT: 3000
+RATIO: 1.5
+DEBUG: false
+QUOTED: "123"
+MULTILINE: |
+    line one
+    line two
+API_unencrypted: visible
+NESTED:
+    DATABASE:
+        PASSWORD: hunter2
+LIST:
+    - one
+    - 2
+NOTHING: null
+`
+
+		encrypted, err := client.EncryptData([]byte(plaintext))
+		require.NoError(t, err)
+		assert.Contains(t, string(encrypted), "API_unencrypted: visible")
+		assert.NotContains(t, string(encrypted), "hunter2")
+
+		got, err := client.DecryptData(encrypted)
+		require.NoError(t, err)
+
+		var want, out map[string]any
+		require.NoError(t, yaml.Unmarshal([]byte(plaintext), &want))
+		require.NoError(t, yaml.Unmarshal(got, &out))
+		assert.Equal(t, want, out)
+		assert.NotContains(t, string(got), "sops:")
+	})
+
+	t.Run("Multiple Recipients", func(t *testing.T) {
+		t.Parallel()
+
+		first, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		second, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+
+		encrypter := NewNativeClient(nil, []age.Recipient{first.Recipient(), second.Recipient()})
+		encrypted, err := encrypter.EncryptData([]byte("KEY: value\n"))
+		require.NoError(t, err)
+
+		for _, identity := range []*age.X25519Identity{first, second} {
+			got, err := NewNativeClient([]age.Identity{identity}, nil).DecryptData(encrypted)
+			require.NoError(t, err)
+			assert.Equal(t, "KEY: value\n", string(got))
+		}
+	})
+
+	t.Run("Wrong Identity", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newNativeClient(t)
+		other, _ := newNativeClient(t)
+
+		encrypted, err := client.EncryptData([]byte("KEY: value\n"))
+		require.NoError(t, err)
+
+		_, err = other.DecryptData(encrypted)
+		assert.ErrorIs(t, err, ErrNoMatchingIdentity)
+	})
+
+	t.Run("Tampered Value", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newNativeClient(t)
+
+		encrypted, err := client.EncryptData([]byte("FIRST: one\nSECOND: two\n"))
+		require.NoError(t, err)
+
+		// Swapping values between keys must fail as the path
+		// is part of the additional data.
+		var doc map[string]any
+		require.NoError(t, yaml.Unmarshal(encrypted, &doc))
+		tampered := strings.Replace(string(encrypted), doc["FIRST"].(string), doc["SECOND"].(string), 1)
+
+		_, err = client.DecryptData([]byte(tampered))
+		assert.ErrorContains(t, err, "sops decrypt failed")
+	})
+
+	t.Run("Tampered Unencrypted Value", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newNativeClient(t)
+
+		encrypted, err := client.EncryptData([]byte("KEY_unencrypted: one\n"))
+		require.NoError(t, err)
+
+		tampered := strings.Replace(string(encrypted), "KEY_unencrypted: one", "KEY_unencrypted: two", 1)
+
+		_, err = client.DecryptData([]byte(tampered))
+		assert.ErrorContains(t, err, "message authentication code mismatch")
+	})
+
+	t.Run("Not Encrypted", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newNativeClient(t)
+
+		_, err := client.DecryptData([]byte("KEY: value\n"))
+		assert.ErrorIs(t, err, ErrNotEncrypted)
+
+		_, err = client.DecryptData(nil)
+		assert.ErrorIs(t, err, ErrNotEncrypted)
+	})
+}
+
+func TestNativeClient_EncryptDecrypt(t *testing.T) {
+	t.Parallel()
+
+	t.Run("In Place", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newNativeClient(t)
+		path := filepath.Join(t.TempDir(), "production.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("KEY: value\n"), 0o600))
+
+		require.NoError(t, client.Encrypt(path))
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.True(t, IsContentEncrypted(content))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+		assert.ErrorIs(t, client.Encrypt(path), ErrAlreadyEncrypted)
+
+		require.NoError(t, client.Decrypt(path))
+		content, err = os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "KEY: value\n", string(content))
+
+		assert.ErrorIs(t, client.Decrypt(path), ErrNotEncrypted)
+	})
+
+	t.Run("Empty File", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newNativeClient(t)
+		path := filepath.Join(t.TempDir(), "staging.yaml")
+		require.NoError(t, os.WriteFile(path, nil, 0o600))
+
+		require.NoError(t, client.Encrypt(path))
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Empty(t, content)
+	})
+
+	t.Run("Missing File", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newNativeClient(t)
+
+		assert.ErrorContains(t, client.Encrypt("missing.yaml"), "failed to read sops file")
+		assert.ErrorContains(t, client.Decrypt("missing.yaml"), "failed to read sops file")
+	})
+
+	t.Run("Config Recipients", func(t *testing.T) {
+		t.Parallel()
+
+		client, identity := newNativeClient(t)
+		production, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+
+		client = client.WithConfig(&Config{CreationRules: []CreationRule{
+			{PathRegex: `secrets/production\.yaml$`, Age: production.Recipient().String()},
+		}})
+
+		dir := filepath.Join(t.TempDir(), "secrets")
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+
+		prod := filepath.Join(dir, "production.yaml")
+		staging := filepath.Join(dir, "staging.yaml")
+		require.NoError(t, os.WriteFile(prod, []byte("KEY: value\n"), 0o600))
+		require.NoError(t, os.WriteFile(staging, []byte("KEY: value\n"), 0o600))
+
+		require.NoError(t, client.Encrypt(prod))
+		require.NoError(t, client.Encrypt(staging))
+
+		content, err := os.ReadFile(prod)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), production.Recipient().String())
+		assert.NotContains(t, string(content), identity.Recipient().String())
+		assert.ErrorIs(t, client.Decrypt(prod), ErrNoMatchingIdentity)
+
+		content, err = os.ReadFile(staging)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), identity.Recipient().String())
+	})
+
+	t.Run("Invalid Config Recipient", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newNativeClient(t)
+		client = client.WithConfig(&Config{CreationRules: []CreationRule{{Age: "not-a-key"}}})
+
+		path := filepath.Join(t.TempDir(), "production.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("KEY: value\n"), 0o600))
+
+		assert.ErrorContains(t, client.Encrypt(path), "invalid age public key")
+	})
+}
+
+func TestNativeClient_SOPSInterop(t *testing.T) {
+	t.Parallel()
+
+	// testdata/secrets.enc.yaml was encrypted by the sops binary with
+	// the test key in testdata/age.key, from the plaintext below.
+	want := map[string]any{
+		"API_KEY": "secret-value",
+		"EMPTY":   "",
+		"PORT":    5432,
+		"RATIO":   1.5,
+		"DEBUG":   true,
+		"DATABASE": map[string]any{
+			"HOST":     "db.internal",
+			"PASSWORD": "hunter2",
+		},
+		"HOSTS":              []any{"one.example.com", "two.example.com"},
+		"REGION_unencrypted": "eu-west-1",
+	}
+
+	key, err := os.ReadFile(filepath.Join("testdata", "age.key"))
+	require.NoError(t, err)
+	identities, err := age.ParseIdentities(strings.NewReader(string(key)))
+	require.NoError(t, err)
+	require.Len(t, identities, 1)
+	identity := identities[0].(*age.X25519Identity)
+
+	client := NewNativeClient(identities, []age.Recipient{identity.Recipient()})
+
+	t.Run("Decrypts SOPS File", func(t *testing.T) {
+		t.Parallel()
+
+		encrypted, err := os.ReadFile(filepath.Join("testdata", "secrets.enc.yaml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(encrypted), "EMPTY: \"\"\n", "SOPS leaves empty strings unencrypted")
+
+		got, err := client.DecryptData(encrypted)
+		require.NoError(t, err)
+
+		var out map[string]any
+		require.NoError(t, yaml.Unmarshal(got, &out))
+		assert.Equal(t, want, out)
+	})
+
+	t.Run("Decrypted By SOPS", func(t *testing.T) {
+		t.Parallel()
+
+		bin, err := exec.LookPath("sops")
+		if err != nil {
+			t.Skip("sops is not installed")
+		}
+
+		plaintext, err := yaml.Marshal(want)
+		require.NoError(t, err)
+		encrypted, err := client.EncryptData(plaintext)
+		require.NoError(t, err)
+
+		keyFile, err := filepath.Abs(filepath.Join("testdata", "age.key"))
+		require.NoError(t, err)
+
+		dir := t.TempDir()
+		path := filepath.Join(dir, "production.yaml")
+		require.NoError(t, os.WriteFile(path, encrypted, 0o600))
+
+		cmd := exec.Command(bin, "decrypt", path)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "SOPS_AGE_KEY_FILE="+keyFile)
+		got, err := cmd.Output()
+		require.NoError(t, err)
+
+		var out map[string]any
+		require.NoError(t, yaml.Unmarshal(got, &out))
+		assert.Equal(t, want, out)
+	})
+}
+
+func TestMetadata_ShouldEncrypt(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]struct {
+		meta metadata
+		path []string
+		want bool
+	}{
+		"Default":                 {meta: metadata{}, path: []string{"KEY"}, want: true},
+		"Unencrypted Suffix":      {meta: metadata{UnencryptedSuffix: "_unencrypted"}, path: []string{"KEY_unencrypted"}, want: false},
+		"Unencrypted Parent":      {meta: metadata{UnencryptedSuffix: "_unencrypted"}, path: []string{"DB_unencrypted", "HOST"}, want: false},
+		"Encrypted Suffix":        {meta: metadata{EncryptedSuffix: "_secret"}, path: []string{"KEY_secret"}, want: true},
+		"Encrypted Suffix Miss":   {meta: metadata{EncryptedSuffix: "_secret"}, path: []string{"KEY"}, want: false},
+		"Unencrypted Regex":       {meta: metadata{UnencryptedRegex: "^PUBLIC_"}, path: []string{"PUBLIC_URL"}, want: false},
+		"Encrypted Regex":         {meta: metadata{EncryptedRegex: "^(data|stringData)$"}, path: []string{"data", "KEY"}, want: true},
+		"Encrypted Regex Miss":    {meta: metadata{EncryptedRegex: "^(data|stringData)$"}, path: []string{"metadata", "name"}, want: false},
+		"Invalid Encrypted Regex": {meta: metadata{EncryptedRegex: "("}, path: []string{"KEY"}, want: false},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.want, test.meta.shouldEncrypt(test.path))
+		})
+	}
+}
+
+func TestDecryptFileToMap_Native(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Decrypts In Memory", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newNativeClient(t)
+		path := filepath.Join(t.TempDir(), "production.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("KEY: value\n"), 0o600))
+		require.NoError(t, client.Encrypt(path))
+
+		before, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		got, err := DecryptFileToMap(client, path)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"KEY": "value"}, got)
+
+		after, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, before, after, "file should not be modified")
+	})
+
+	t.Run("Unencrypted File", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newNativeClient(t)
+		path := filepath.Join(t.TempDir(), "development.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("KEY: value\n"), 0o600))
+
+		got, err := DecryptFileToMap(client, path)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"KEY": "value"}, got)
+	})
+
+	t.Run("Wrong Identity", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newNativeClient(t)
+		other, _ := newNativeClient(t)
+		path := filepath.Join(t.TempDir(), "production.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("KEY: value\n"), 0o600))
+		require.NoError(t, client.Encrypt(path))
+
+		_, err := DecryptFileToMap(other, path)
+		assert.ErrorIs(t, err, ErrNoMatchingIdentity)
+	})
+}
@@ -36,8 +36,47 @@ type (
 		Encrypter
 		Decrypter
 	}
+	// DataEncrypter encrypts plaintext SOPS YAML in memory.
+	DataEncrypter interface {
+		EncryptData(plaintext []byte) ([]byte, error)
+	}
+	// DataDecrypter decrypts SOPS YAML in memory, so the plaintext
+	// never has to be written to disk.
+	DataDecrypter interface {
+		DecryptData(ciphertext []byte) ([]byte, error)
+	}
+	// FileDataDecrypter decrypts the contents of a SOPS file in memory,
+	// given the path they were read from so the client knows which
+	// file is being decrypted, for example to record who accessed it.
+	FileDataDecrypter interface {
+		DecryptFileData(filePath string, ciphertext []byte) ([]byte, error)
+	}
+	// KeyScoper returns a client that reports only the given keys as
+	// accessed when decrypting, for example when recording who read
+	// which secrets. Clients decrypt the whole file regardless.
+	KeyScoper interface {
+		WithKeys(keys ...string) EncrypterDecrypter
+	}
 )
 
+// DecryptFileData decrypts the contents of the SOPS file at filePath in
+// memory, passing the path to clients that implement FileDataDecrypter.
+func DecryptFileData(dd DataDecrypter, filePath string, ciphertext []byte) ([]byte, error) {
+	if fd, ok := dd.(FileDataDecrypter); ok {
+		return fd.DecryptFileData(filePath, ciphertext)
+	}
+	return dd.DecryptData(ciphertext)
+}
+
+// WithKeys scopes the client to the given keys for clients that
+// implement KeyScoper, otherwise the client is returned as is.
+func WithKeys(client EncrypterDecrypter, keys ...string) EncrypterDecrypter {
+	if ks, ok := client.(KeyScoper); ok {
+		return ks.WithKeys(keys...)
+	}
+	return client
+}
+
 // Client executes SOPS operations using a configured provider.
 type Client struct {
 	provider Provider
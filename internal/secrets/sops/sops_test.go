@@ -122,3 +122,10 @@ func TestClient_Encrypt(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestWithKeys(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newClient(&fakeProvider{})
+	assert.Same(t, client, WithKeys(client, "KEY"), "Clients that can't be scoped should be returned as is")
+}
@@ -0,0 +1,290 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/secrets/sops"
+	"github.com/ainsleydev/webkit/internal/state/rotations"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+// sharedAppName is the name reported for secrets referenced by the
+// shared environment rather than an app.
+const sharedAppName = "shared"
+
+type (
+	// ValidateConfig defines the data needed to validate the secret
+	// files of the definition.
+	ValidateConfig struct {
+		FS     afero.Fs
+		AppDef *appdef.Definition
+		// SOPSClient decrypts the files when AllowEncrypted is set.
+		SOPSClient sops.EncrypterDecrypter
+		// CheckOrphans reports keys in the secret files that aren't
+		// referenced by app.json.
+		CheckOrphans bool
+		// AllowEncrypted decrypts encrypted files in memory to verify
+		// they can be decrypted and check their values.
+		AllowEncrypted bool
+		// Now is the time stale keys are checked against, defaults
+		// to the current time.
+		Now time.Time
+	}
+	// ValidationResult is the outcome of validating every secret file.
+	ValidationResult struct {
+		Valid          bool             `json:"valid"`
+		Files          []FileValidation `json:"files"`
+		MissingSecrets []MissingSecret  `json:"missing_secrets"`
+		OrphanedKeys   []OrphanedKey    `json:"orphaned_keys"`
+		// StaleKeys are the environments with secrets whose age key
+		// hasn't been rotated within rotations.MaxAge.
+		StaleKeys []string `json:"stale_keys"`
+		// SharedProductionKeys are the age keys that can decrypt both
+		// production and another environment.
+		SharedProductionKeys []string `json:"shared_production_keys"`
+	}
+	// FileValidation is the outcome of validating a single secret file.
+	FileValidation struct {
+		Environment env.Environment `json:"environment"`
+		FilePath    string          `json:"file_path"`
+		Exists      bool            `json:"exists"`
+		IsEncrypted bool            `json:"is_encrypted"`
+		// Unencrypted is true when the file holds secrets in plain
+		// text, it should be encrypted before it's committed.
+		Unencrypted bool     `json:"unencrypted"`
+		MissingKeys []string `json:"missing_keys,omitempty"`
+		EmptyKeys   []string `json:"empty_keys,omitempty"`
+		Error       string   `json:"error,omitempty"`
+	}
+	// MissingSecret is a secret referenced in app.json that doesn't
+	// exist in the secret file of its environment.
+	MissingSecret struct {
+		Key         string          `json:"key"`
+		Environment env.Environment `json:"environment"`
+		AppName     string          `json:"app_name"`
+		ExpectedIn  string          `json:"expected_in"`
+	}
+	// OrphanedKey is a key in a secret file that isn't referenced by
+	// app.json.
+	OrphanedKey struct {
+		Key         string          `json:"key"`
+		Environment env.Environment `json:"environment"`
+		FilePath    string          `json:"file_path"`
+	}
+)
+
+// HasProblems returns true if the file is missing secrets, holds
+// empty or unencrypted values, or couldn't be read.
+func (f FileValidation) HasProblems() bool {
+	return f.Error != "" || f.Unencrypted || len(f.MissingKeys) > 0 || len(f.EmptyKeys) > 0
+}
+
+// Validate checks the secret file of every environment against the
+// sops references in app.json. The result is invalid if a referenced
+// secret is missing, a value is empty, a file holds secrets without
+// being encrypted or a file can't be read. It's also invalid once
+// production holds secrets that a key of another environment can
+// decrypt.
+//
+// Orphaned keys and stale age keys are reported but don't invalidate
+// the result.
+func Validate(cfg ValidateConfig) (*ValidationResult, error) {
+	if cfg.AppDef == nil {
+		return nil, errors.New("app definition is required")
+	}
+
+	now := cfg.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	refs := secretReferences(cfg.AppDef)
+	result := &ValidationResult{
+		Files:                []FileValidation{},
+		MissingSecrets:       []MissingSecret{},
+		OrphanedKeys:         []OrphanedKey{},
+		StaleKeys:            []string{},
+		SharedProductionKeys: []string{},
+	}
+
+	var withSecrets []string
+	for _, e := range env.All {
+		path := FilePathFromEnv(e)
+		file, values := validateFile(cfg, e, path)
+
+		for _, key := range sortedKeys(refs[e]) {
+			if _, ok := values[key]; ok || file.Error != "" {
+				continue
+			}
+			file.MissingKeys = append(file.MissingKeys, key)
+			for _, app := range refs[e][key] {
+				result.MissingSecrets = append(result.MissingSecrets, MissingSecret{
+					Key:         key,
+					Environment: e,
+					AppName:     app,
+					ExpectedIn:  path,
+				})
+			}
+		}
+
+		if cfg.CheckOrphans {
+			for _, key := range sortedKeys(values) {
+				if _, ok := refs[e][key]; !ok {
+					result.OrphanedKeys = append(result.OrphanedKeys, OrphanedKey{
+						Key:         key,
+						Environment: e,
+						FilePath:    path,
+					})
+				}
+			}
+		}
+
+		if len(values) > 0 {
+			withSecrets = append(withSecrets, e.String())
+		}
+
+		result.Files = append(result.Files, file)
+	}
+
+	rotated, err := rotations.Load(cfg.FS)
+	if err != nil {
+		return nil, err
+	}
+	if stale := rotated.Stale(withSecrets, now, rotations.MaxAge); stale != nil {
+		result.StaleKeys = stale
+	}
+
+	// There's nothing to protect until production holds secrets.
+	if slices.Contains(withSecrets, env.Production.String()) {
+		recipients, err := ReadRecipients(cfg.FS)
+		if err != nil {
+			return nil, err
+		}
+		if shared := recipients.SharedWithProduction(); shared != nil {
+			result.SharedProductionKeys = shared
+		}
+	}
+
+	result.Valid = len(result.SharedProductionKeys) == 0
+	for _, file := range result.Files {
+		if file.HasProblems() {
+			result.Valid = false
+		}
+	}
+
+	return result, nil
+}
+
+// validateFile reads the secret file of an environment, returning its
+// validation along with its top level values, excluding the SOPS
+// metadata.
+func validateFile(cfg ValidateConfig, e env.Environment, path string) (FileValidation, map[string]any) {
+	file := FileValidation{Environment: e, FilePath: path}
+
+	content, err := afero.ReadFile(cfg.FS, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return file, nil
+	} else if err != nil {
+		file.Error = err.Error()
+		return file, nil
+	}
+
+	file.Exists = true
+	file.IsEncrypted = sops.IsContentEncrypted(content)
+
+	if file.IsEncrypted && cfg.AllowEncrypted {
+		content, err = decryptContent(cfg.SOPSClient, path, content)
+		if err != nil {
+			file.Error = fmt.Sprintf("decrypting: %v", err)
+			return file, nil
+		}
+	}
+
+	values := map[string]any{}
+	if err = yaml.Unmarshal(content, &values); err != nil {
+		file.Error = fmt.Sprintf("invalid YAML: %v", err)
+		return file, nil
+	}
+	delete(values, "sops")
+
+	file.Unencrypted = !file.IsEncrypted && len(values) > 0
+
+	for _, key := range sortedKeys(values) {
+		if isEmptyValue(values[key]) {
+			file.EmptyKeys = append(file.EmptyKeys, key)
+		}
+	}
+
+	return file, values
+}
+
+// decryptContent decrypts the contents of a secret file in memory, so
+// the file on disk is never left decrypted.
+func decryptContent(client sops.EncrypterDecrypter, path string, content []byte) ([]byte, error) {
+	decrypter, ok := client.(sops.DataDecrypter)
+	if !ok {
+		return nil, errors.New("sops client can't decrypt in memory")
+	}
+	return sops.DecryptFileData(decrypter, path, content)
+}
+
+// isEmptyValue returns true for values that are missing or blank.
+// SOPS leaves empty strings unencrypted, so they're empty in encrypted
+// files too.
+func isEmptyValue(v any) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(value) == ""
+	default:
+		return false
+	}
+}
+
+// secretReferences returns the keys referenced with source "sops" per
+// environment, along with the names of the apps that use them.
+func secretReferences(def *appdef.Definition) map[env.Environment]map[string][]string {
+	refs := map[env.Environment]map[string][]string{}
+
+	add := func(name string, enviro appdef.Environment) {
+		enviro.Walk(func(entry appdef.EnvWalkEntry) {
+			if entry.Source != appdef.EnvSourceSOPS {
+				return
+			}
+			if refs[entry.Environment] == nil {
+				refs[entry.Environment] = map[string][]string{}
+			}
+			apps := refs[entry.Environment][entry.Key]
+			if len(apps) == 0 || apps[len(apps)-1] != name {
+				refs[entry.Environment][entry.Key] = append(apps, name)
+			}
+		})
+	}
+
+	add(sharedAppName, def.Shared.Env)
+	for _, app := range def.Apps {
+		add(app.Name, app.Env)
+	}
+
+	return refs
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
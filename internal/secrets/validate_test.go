@@ -0,0 +1,267 @@
+package secrets
+
+import (
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ainsleydev/webkit/internal/appdef"
+	"github.com/ainsleydev/webkit/internal/secrets/sops"
+	"github.com/ainsleydev/webkit/internal/state/rotations"
+	"github.com/ainsleydev/webkit/pkg/env"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	client := sops.NewNativeClient([]age.Identity{identity}, []age.Recipient{identity.Recipient()})
+
+	encrypt := func(t *testing.T, plaintext string) string {
+		t.Helper()
+		out, err := client.EncryptData([]byte(plaintext))
+		require.NoError(t, err)
+		return string(out)
+	}
+
+	def := &appdef.Definition{
+		Shared: appdef.Shared{
+			Env: appdef.Environment{
+				Production: appdef.EnvVar{
+					"API_KEY": {Source: appdef.EnvSourceSOPS},
+				},
+			},
+		},
+		Apps: []appdef.App{
+			{
+				Name: "web",
+				Env: appdef.Environment{
+					Production: appdef.EnvVar{
+						"API_KEY":   {Source: appdef.EnvSourceSOPS},
+						"STRIPE":    {Source: appdef.EnvSourceSOPS},
+						"LOG_LEVEL": {Source: appdef.EnvSourceValue, Value: "info"},
+					},
+				},
+			},
+		},
+	}
+
+	// separateProduction writes a .sops.yaml where production has
+	// its own key.
+	separateProduction := func(t *testing.T, fs afero.Fs) {
+		t.Helper()
+		recipients := DefaultRecipients()
+		recipients[env.Production] = []string{"age1production"}
+		out, err := yaml.Marshal(recipients.SOPSConfig())
+		require.NoError(t, err)
+		require.NoError(t, afero.WriteFile(fs, SOPSConfigPath, out, 0o644))
+	}
+
+	tt := map[string]struct {
+		production     string
+		sharedKeys     bool
+		allowEncrypted bool
+		checkOrphans   bool
+		want           func(t *testing.T, result *ValidationResult)
+	}{
+		"Valid": {
+			production: encrypt(t, "API_KEY: key\nSTRIPE: sk_live\n"),
+			want: func(t *testing.T, result *ValidationResult) {
+				assert.True(t, result.Valid)
+				assert.Empty(t, result.MissingSecrets)
+				assert.True(t, result.Files[2].IsEncrypted)
+			},
+		},
+		"Missing File": {
+			want: func(t *testing.T, result *ValidationResult) {
+				assert.False(t, result.Valid)
+				assert.False(t, result.Files[2].Exists)
+				assert.Equal(t, []string{"API_KEY", "STRIPE"}, result.Files[2].MissingKeys)
+				assert.Equal(t, []MissingSecret{
+					{Key: "API_KEY", Environment: env.Production, AppName: "shared", ExpectedIn: "resources/secrets/production.yaml"},
+					{Key: "API_KEY", Environment: env.Production, AppName: "web", ExpectedIn: "resources/secrets/production.yaml"},
+					{Key: "STRIPE", Environment: env.Production, AppName: "web", ExpectedIn: "resources/secrets/production.yaml"},
+				}, result.MissingSecrets)
+			},
+		},
+		"Missing Key In Encrypted File": {
+			production: encrypt(t, "API_KEY: key\n"),
+			want: func(t *testing.T, result *ValidationResult) {
+				assert.False(t, result.Valid)
+				assert.Equal(t, []string{"STRIPE"}, result.Files[2].MissingKeys)
+			},
+		},
+		"Unencrypted": {
+			production: "API_KEY: key\nSTRIPE: sk_live\n",
+			want: func(t *testing.T, result *ValidationResult) {
+				assert.False(t, result.Valid)
+				assert.True(t, result.Files[2].Unencrypted)
+				assert.Empty(t, result.Files[2].MissingKeys)
+			},
+		},
+		"Empty Values": {
+			production: "API_KEY: \"\"\nSTRIPE:\n",
+			want: func(t *testing.T, result *ValidationResult) {
+				assert.False(t, result.Valid)
+				assert.Equal(t, []string{"API_KEY", "STRIPE"}, result.Files[2].EmptyKeys)
+			},
+		},
+		"Empty Encrypted Value": {
+			production: encrypt(t, "API_KEY: \"\"\nSTRIPE: sk_live\n"),
+			want: func(t *testing.T, result *ValidationResult) {
+				assert.False(t, result.Valid)
+				assert.Equal(t, []string{"API_KEY"}, result.Files[2].EmptyKeys)
+			},
+		},
+		"Allow Encrypted": {
+			production:     encrypt(t, "API_KEY: \" \"\nSTRIPE: sk_live\n"),
+			allowEncrypted: true,
+			want: func(t *testing.T, result *ValidationResult) {
+				assert.False(t, result.Valid)
+				assert.Equal(t, []string{"API_KEY"}, result.Files[2].EmptyKeys)
+			},
+		},
+		"Invalid YAML": {
+			production: "wrong\\Yaml: [",
+			want: func(t *testing.T, result *ValidationResult) {
+				assert.False(t, result.Valid)
+				assert.Contains(t, result.Files[2].Error, "invalid YAML")
+				assert.Empty(t, result.MissingSecrets)
+			},
+		},
+		"Orphans": {
+			production:   encrypt(t, "API_KEY: key\nSTRIPE: sk_live\nOLD: value\n"),
+			checkOrphans: true,
+			want: func(t *testing.T, result *ValidationResult) {
+				assert.True(t, result.Valid, "Orphans shouldn't fail validation")
+				assert.Equal(t, []OrphanedKey{
+					{Key: "OLD", Environment: env.Production, FilePath: "resources/secrets/production.yaml"},
+				}, result.OrphanedKeys)
+			},
+		},
+		"Shared Production Key": {
+			production: encrypt(t, "API_KEY: key\nSTRIPE: sk_live\n"),
+			sharedKeys: true,
+			want: func(t *testing.T, result *ValidationResult) {
+				assert.False(t, result.Valid)
+				assert.Equal(t, []string{AgePublicKey}, result.SharedProductionKeys)
+			},
+		},
+		"Shared Key Without Production Secrets": {
+			sharedKeys: true,
+			want: func(t *testing.T, result *ValidationResult) {
+				assert.Empty(t, result.SharedProductionKeys)
+			},
+		},
+		"Orphans Not Checked": {
+			production: encrypt(t, "API_KEY: key\nSTRIPE: sk_live\nOLD: value\n"),
+			want: func(t *testing.T, result *ValidationResult) {
+				assert.Empty(t, result.OrphanedKeys)
+			},
+		},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := afero.NewMemMapFs()
+			if !test.sharedKeys {
+				separateProduction(t, fs)
+			}
+			if test.production != "" {
+				err := afero.WriteFile(fs, FilePathFromEnv(env.Production), []byte(test.production), 0o644)
+				require.NoError(t, err)
+			}
+
+			got, err := Validate(ValidateConfig{
+				FS:             fs,
+				AppDef:         def,
+				SOPSClient:     client,
+				CheckOrphans:   test.checkOrphans,
+				AllowEncrypted: test.allowEncrypted,
+			})
+			require.NoError(t, err)
+			require.Len(t, got.Files, 3)
+			test.want(t, got)
+		})
+	}
+
+	t.Run("Decrypt Error", func(t *testing.T) {
+		t.Parallel()
+
+		other, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+
+		fs := afero.NewMemMapFs()
+		err = afero.WriteFile(fs, FilePathFromEnv(env.Production), []byte(encrypt(t, "API_KEY: key\n")), 0o644)
+		require.NoError(t, err)
+
+		got, err := Validate(ValidateConfig{
+			FS:             fs,
+			AppDef:         def,
+			SOPSClient:     sops.NewNativeClient([]age.Identity{other}, nil),
+			AllowEncrypted: true,
+		})
+		require.NoError(t, err)
+		assert.False(t, got.Valid)
+		assert.Contains(t, got.Files[2].Error, "decrypting")
+	})
+
+	t.Run("Stale Keys", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		fs := afero.NewMemMapFs()
+		separateProduction(t, fs)
+		err := afero.WriteFile(fs, FilePathFromEnv(env.Production), []byte(encrypt(t, "API_KEY: key\nSTRIPE: sk_live\n")), 0o644)
+		require.NoError(t, err)
+		err = afero.WriteFile(fs, FilePathFromEnv(env.Staging), []byte(encrypt(t, "OTHER: value\n")), 0o644)
+		require.NoError(t, err)
+		err = rotations.Record(fs, env.Staging.String(), rotations.Rotation{RotatedAt: now.Add(-24 * time.Hour)})
+		require.NoError(t, err)
+
+		got, err := Validate(ValidateConfig{FS: fs, AppDef: def, Now: now})
+		require.NoError(t, err)
+		assert.True(t, got.Valid, "Stale keys shouldn't fail validation")
+		assert.Equal(t, []string{"production"}, got.StaleKeys)
+	})
+
+	t.Run("Nil Definition", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Validate(ValidateConfig{FS: afero.NewMemMapFs()})
+		assert.Error(t, err)
+	})
+}
+
+func TestIsEmptyValue(t *testing.T) {
+	t.Parallel()
+
+	tt := map[string]struct {
+		input any
+		want  bool
+	}{
+		"Nil":    {input: nil, want: true},
+		"Empty":  {input: "", want: true},
+		"Blank":  {input: "  ", want: true},
+		"String": {input: "sk_live", want: false},
+		"Number": {input: 0, want: false},
+		// SOPS leaves empty strings unencrypted, so any encrypted
+		// value holds data.
+		"Encrypted": {input: "ENC[AES256_GCM,data:c2s=,iv:aXY=,tag:dGFn,type:str]", want: false},
+	}
+
+	for name, test := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.want, isEmptyValue(test.input))
+		})
+	}
+}
@@ -0,0 +1,4 @@
+// Package rotations records when the age key of each environment's
+// secrets was last rotated in the .webkit/rotations.json file, so stale
+// keys can be reported by secrets validate.
+package rotations
@@ -0,0 +1,92 @@
+package rotations
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// FilePath is the path to the rotations file, committed alongside the
+// secrets so every checkout knows when keys were last rotated.
+var FilePath = filepath.Join(".webkit", "rotations.json")
+
+// MaxAge is how long an environment's key can go without being rotated
+// before it's considered stale.
+const MaxAge = 180 * 24 * time.Hour
+
+type (
+	// File is the contents of .webkit/rotations.json, keyed by
+	// environment.
+	File map[string]Rotation
+	// Rotation records the last key rotation of an environment.
+	Rotation struct {
+		// Recipient is the age public key that was generated.
+		Recipient string `json:"recipient"`
+		// GitHubSecret is the Actions secret the private key was
+		// stored in, empty if it wasn't uploaded.
+		GitHubSecret string `json:"github_secret,omitempty"`
+		// RotatedAt is when the key was rotated, in UTC.
+		RotatedAt time.Time `json:"rotated_at"`
+	}
+)
+
+// Load reads .webkit/rotations.json, returning an empty File if it
+// doesn't exist.
+func Load(fs afero.Fs) (File, error) {
+	data, err := afero.ReadFile(fs, FilePath)
+	if os.IsNotExist(err) {
+		return File{}, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "reading rotations file")
+	}
+
+	file := File{}
+	if err = json.Unmarshal(data, &file); err != nil {
+		return nil, errors.Wrap(err, "parsing rotations file")
+	}
+
+	return file, nil
+}
+
+// Record stores the rotation of an environment, replacing the previous
+// one, and writes the file.
+func Record(fs afero.Fs, environment string, rotation Rotation) error {
+	file, err := Load(fs)
+	if err != nil {
+		return err
+	}
+	file[environment] = rotation
+
+	out, err := json.MarshalIndent(file, "", "\t")
+	if err != nil {
+		return errors.Wrap(err, "serializing rotations file")
+	}
+
+	if err = fs.MkdirAll(filepath.Dir(FilePath), os.ModePerm); err != nil {
+		return errors.Wrap(err, "creating rotations dir")
+	}
+
+	if err = afero.WriteFile(fs, FilePath, append(out, '\n'), 0o644); err != nil {
+		return errors.Wrap(err, "writing rotations file")
+	}
+
+	return nil
+}
+
+// Stale returns the environments whose key hasn't been rotated within
+// maxAge of now, including those that have never been rotated, in the
+// order given.
+func (f File) Stale(environments []string, now time.Time, maxAge time.Duration) []string {
+	var stale []string
+	for _, e := range environments {
+		rotation, ok := f[e]
+		if !ok || now.Sub(rotation.RotatedAt) > maxAge {
+			stale = append(stale, e)
+		}
+	}
+	return stale
+}
@@ -0,0 +1,72 @@
+package rotations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	t.Run("File does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := Load(afero.NewMemMapFs())
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		t.Parallel()
+
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, FilePath, []byte("invalid json"), 0o644))
+
+		_, err := Load(fs)
+		assert.ErrorContains(t, err, "parsing rotations file")
+	})
+}
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Round Trip", func(t *testing.T) {
+		t.Parallel()
+
+		fs := afero.NewMemMapFs()
+		first := Rotation{Recipient: "age1first", RotatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+		second := Rotation{Recipient: "age1second", GitHubSecret: "REPO_AGE_SECRET_PRODUCTION", RotatedAt: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)}
+
+		require.NoError(t, Record(fs, "staging", first))
+		require.NoError(t, Record(fs, "production", first))
+		require.NoError(t, Record(fs, "production", second))
+
+		got, err := Load(fs)
+		require.NoError(t, err)
+		assert.Equal(t, File{"staging": first, "production": second}, got)
+	})
+
+	t.Run("FS Error", func(t *testing.T) {
+		t.Parallel()
+
+		err := Record(afero.NewReadOnlyFs(afero.NewMemMapFs()), "production", Rotation{})
+		assert.Error(t, err)
+	})
+}
+
+func TestFile_Stale(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	file := File{
+		"development": {RotatedAt: now.Add(-24 * time.Hour)},
+		"production":  {RotatedAt: now.Add(-MaxAge - time.Hour)},
+	}
+
+	got := file.Stale([]string{"development", "staging", "production"}, now, MaxAge)
+	assert.Equal(t, []string{"staging", "production"}, got)
+}
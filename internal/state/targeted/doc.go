@@ -0,0 +1,7 @@
+// Package targeted records applies that were limited to a subset of apps
+// and resources in the .webkit/targeted_runs.json file, so partial
+// applies can be traced until the next full apply.
+//
+// The file is owned by webkit, unlike .webkit/outputs.json which is
+// written by Terraform and replaced on every apply.
+package targeted
@@ -0,0 +1,67 @@
+package targeted
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// FilePath is the path to the targeted runs file.
+var FilePath = filepath.Join(".webkit", "targeted_runs.json")
+
+// Run records an apply that was limited to a subset of apps and
+// resources, meaning the rest of the project may not reflect app.json
+// until the next full apply.
+type Run struct {
+	Environment string    `json:"environment"`
+	Apps        []string  `json:"apps,omitempty"`
+	Resources   []string  `json:"resources,omitempty"`
+	Targets     []string  `json:"targets"`
+	AppliedAt   time.Time `json:"applied_at"`
+}
+
+// Load reads .webkit/targeted_runs.json, returning no runs if it
+// doesn't exist.
+func Load(fs afero.Fs) ([]Run, error) {
+	data, err := afero.ReadFile(fs, FilePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "reading targeted runs file")
+	}
+
+	var runs []Run
+	if err = json.Unmarshal(data, &runs); err != nil {
+		return nil, errors.Wrap(err, "parsing targeted runs file")
+	}
+
+	return runs, nil
+}
+
+// Record appends a targeted run and writes the file.
+func Record(fs afero.Fs, run Run) error {
+	runs, err := Load(fs)
+	if err != nil {
+		return err
+	}
+	runs = append(runs, run)
+
+	out, err := json.MarshalIndent(runs, "", "\t")
+	if err != nil {
+		return errors.Wrap(err, "serializing targeted runs file")
+	}
+
+	if err = fs.MkdirAll(filepath.Dir(FilePath), os.ModePerm); err != nil {
+		return errors.Wrap(err, "creating targeted runs dir")
+	}
+
+	if err = afero.WriteFile(fs, FilePath, append(out, '\n'), 0o644); err != nil {
+		return errors.Wrap(err, "writing targeted runs file")
+	}
+
+	return nil
+}
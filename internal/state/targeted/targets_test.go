@@ -0,0 +1,86 @@
+package targeted
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	t.Run("File does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := Load(afero.NewMemMapFs())
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		t.Parallel()
+
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, FilePath, []byte("invalid json"), 0o644))
+
+		_, err := Load(fs)
+		assert.ErrorContains(t, err, "parsing targeted runs file")
+	})
+}
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+
+	run := Run{
+		Environment: "production",
+		Apps:        []string{"web"},
+		Targets:     []string{`module.apps["web"]`},
+		AppliedAt:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("Appends", func(t *testing.T) {
+		t.Parallel()
+
+		fs := afero.NewMemMapFs()
+		staging := Run{Environment: "staging", Targets: []string{`module.resources["db"]`}}
+		require.NoError(t, Record(fs, staging))
+		require.NoError(t, Record(fs, run))
+
+		got, err := Load(fs)
+		require.NoError(t, err)
+		assert.Equal(t, []Run{staging, run}, got)
+	})
+
+	t.Run("Leaves Outputs Untouched", func(t *testing.T) {
+		t.Parallel()
+
+		fs := afero.NewMemMapFs()
+		outputs := []byte(`{"slack": {"channel_id": "C123456"}}`)
+		require.NoError(t, afero.WriteFile(fs, ".webkit/outputs.json", outputs, 0o644))
+
+		require.NoError(t, Record(fs, run))
+
+		got, err := afero.ReadFile(fs, ".webkit/outputs.json")
+		require.NoError(t, err)
+		assert.Equal(t, outputs, got)
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		t.Parallel()
+
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, FilePath, []byte("invalid json"), 0o644))
+
+		assert.Error(t, Record(fs, run))
+	})
+
+	t.Run("Write Error", func(t *testing.T) {
+		t.Parallel()
+
+		fs := afero.NewReadOnlyFs(afero.NewMemMapFs())
+		assert.Error(t, Record(fs, run))
+	})
+}
@@ -37,6 +37,7 @@ func templateFuncs() template.FuncMap {
 	funcs["ghSecret"] = githubSecret
 	funcs["ghInput"] = githubInput
 	funcs["ghEnv"] = githubEnv
+	funcs["ghAgeSecrets"] = githubAgeSecrets
 	funcs["prettyConfigKey"] = prettyConfigKey
 	return funcs
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"unicode"
+
+	"github.com/ainsleydev/webkit/pkg/env"
 )
 
 // githubExpression returns a GitHub Actions expression.
@@ -33,6 +35,21 @@ func githubEnv(name string) string {
 	return fmt.Sprintf("${{ env.%s }}", name)
 }
 
+// githubAgeSecrets returns every age key secret, one per line, for a
+// multi-line SOPS_AGE_KEY. Each environment may be encrypted with its
+// own key after `webkit secrets rotate`, so CI needs all of them, with
+// ORG_AGE_SECRET for environments that haven't been rotated. Secrets
+// that aren't set expand to blank lines, which are skipped when the
+// keys are read.
+func githubAgeSecrets() string {
+	lines := make([]string, 0, len(env.All)+1)
+	for _, e := range env.All {
+		lines = append(lines, githubSecret("REPO_AGE_SECRET_"+strings.ToUpper(e.String())))
+	}
+	lines = append(lines, githubSecret("ORG_AGE_SECRET"))
+	return strings.Join(lines, "\n")
+}
+
 // prettyConfigKey converts a snake_case configuration key to Title Case for display.
 // It handles edge cases like empty strings, consecutive underscores, and leading/trailing underscores.
 //
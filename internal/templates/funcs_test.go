@@ -125,6 +125,16 @@ func TestGithubEnv(t *testing.T) {
 	}
 }
 
+func TestGithubAgeSecrets(t *testing.T) {
+	t.Parallel()
+
+	want := "${{ secrets.REPO_AGE_SECRET_DEVELOPMENT }}\n" +
+		"${{ secrets.REPO_AGE_SECRET_STAGING }}\n" +
+		"${{ secrets.REPO_AGE_SECRET_PRODUCTION }}\n" +
+		"${{ secrets.ORG_AGE_SECRET }}"
+	assert.Equal(t, want, githubAgeSecrets())
+}
+
 func TestPrettyConfigKey(t *testing.T) {
 	t.Parallel()
 
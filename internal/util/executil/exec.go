@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"os/signal"
 )
 
 // ExecRunner implements Runner by using cmd.Execute to
@@ -35,6 +36,13 @@ func (e ExecRunner) Run(ctx context.Context, cmd Command) (Result, error) {
 	}
 	c.Env = env
 
+	if cmd.Interactive {
+		c.Stdin = os.Stdin
+		c.Stdout = os.Stdout
+		c.Stderr = os.Stderr
+		return result, run(c, cmd.Signals)
+	}
+
 	var stdoutBuf, stderrBuf bytes.Buffer
 
 	// Set output
@@ -51,7 +59,37 @@ func (e ExecRunner) Run(ctx context.Context, cmd Command) (Result, error) {
 	}
 
 	// Run
-	err := c.Run()
+	err := run(c, cmd.Signals)
 	result.Output = stdoutBuf.String() + stderrBuf.String()
 	return result, err
 }
+
+// run runs the command, relaying the signals to it until it exits.
+func run(c *exec.Cmd, signals []os.Signal) error {
+	if len(signals) == 0 {
+		return c.Run()
+	}
+
+	relay := make(chan os.Signal, 1)
+	signal.Notify(relay, signals...)
+	defer signal.Stop(relay)
+
+	if err := c.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case sig := <-relay:
+				_ = c.Process.Signal(sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return c.Wait()
+}
@@ -2,10 +2,15 @@ package executil
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"syscall"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExecRunner_Run(t *testing.T) {
@@ -126,4 +131,52 @@ func TestExecRunner_Run(t *testing.T) {
 		_, err := runner.Run(t.Context(), cmd)
 		assert.Error(t, err)
 	})
+
+	t.Run("Relays Signals", func(t *testing.T) {
+		t.Parallel()
+
+		ready := &readyWriter{ready: make(chan struct{})}
+		cmd := NewCommand("sh", "-c", `trap 'echo terminated; exit 0' TERM; echo ready; while :; do sleep 0.1; done`)
+		cmd.Stdout = ready
+		cmd.Signals = []os.Signal{syscall.SIGTERM}
+
+		go func() {
+			<-ready.ready
+			if p, err := os.FindProcess(os.Getpid()); err == nil {
+				_ = p.Signal(syscall.SIGTERM)
+			}
+		}()
+
+		got, err := DefaultRunner().Run(t.Context(), cmd)
+		require.NoError(t, err)
+		assert.Contains(t, got.Output, "terminated")
+	})
+
+	t.Run("Interactive", func(t *testing.T) {
+		t.Parallel()
+
+		file := filepath.Join(t.TempDir(), "out")
+		cmd := NewCommand("sh", "-c", "echo hello > "+file+"; echo ignored")
+		cmd.Interactive = true
+
+		got, err := DefaultRunner().Run(t.Context(), cmd)
+		require.NoError(t, err)
+		assert.Empty(t, got.Output, "Interactive output should not be captured")
+
+		b, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, "hello\n", string(b))
+	})
+}
+
+// readyWriter closes ready the first time it's written to, so tests
+// know the command has started.
+type readyWriter struct {
+	once  sync.Once
+	ready chan struct{}
+}
+
+func (w *readyWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() { close(w.ready) })
+	return len(p), nil
 }
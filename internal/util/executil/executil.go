@@ -3,6 +3,7 @@ package executil
 import (
 	"context"
 	"io"
+	"os"
 	"os/exec"
 	"strings"
 )
@@ -22,6 +23,14 @@ type (
 		Stdin  io.Reader
 		Stdout io.Writer
 		Stderr io.Writer
+		// Interactive connects the command directly to the terminal
+		// for editors and shells that need a TTY, the output isn't
+		// captured in the Result.
+		Interactive bool
+		// Signals are relayed to the command while it runs, rather
+		// than being handled by webkit, so the command decides how
+		// to shut down.
+		Signals []os.Signal
 	}
 	// Result captures the outcome of running a command.
 	Result struct {
@@ -0,0 +1,5 @@
+// Package httputil provides helpers for calling JSON APIs.
+//
+// It's shared by the registry and secret backend clients, which only
+// need to send simple authenticated requests and decode the response.
+package httputil
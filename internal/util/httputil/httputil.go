@@ -0,0 +1,66 @@
+package httputil
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// GetJSON sends a GET request with the given headers and decodes the
+// JSON response into out. Empty headers aren't sent.
+func GetJSON(ctx context.Context, client *http.Client, endpoint string, headers map[string]string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	for name, value := range headers {
+		if value != "" {
+			req.Header.Set(name, value)
+		}
+	}
+	return send(client, req, out)
+}
+
+// PostJSON sends body as JSON and decodes the JSON response into out.
+func PostJSON(ctx context.Context, client *http.Client, endpoint string, body, out any) error {
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return errors.Wrap(err, "marshalling request body")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(buf))
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return send(client, req, out)
+}
+
+// send decodes numbers into untyped values as json.Number, so large
+// numbers aren't turned into floats, e.g. 1e+06.
+func send(client *http.Client, req *http.Request, out any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "requesting %s", req.URL.Path)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("requesting %s: unexpected status %d: %s", req.URL.Path, resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err = decoder.Decode(out); err != nil {
+		return errors.Wrapf(err, "decoding %s response", req.URL.Path)
+	}
+
+	return nil
+}
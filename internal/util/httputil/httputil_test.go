@@ -0,0 +1,84 @@
+package httputil
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			_, ok := r.Header["X-Empty"]
+			assert.False(t, ok, "Empty headers should not be sent")
+			_, _ = w.Write([]byte(`{"name": "web", "size": 1000000}`))
+		}))
+		t.Cleanup(server.Close)
+
+		var out map[string]any
+		err := GetJSON(t.Context(), server.Client(), server.URL, map[string]string{
+			"Authorization": "Bearer token",
+			"X-Empty":       "",
+		}, &out)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"name": "web", "size": json.Number("1000000")}, out)
+	})
+
+	t.Run("Unexpected Status", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+		}))
+		t.Cleanup(server.Close)
+
+		var out map[string]any
+		err := GetJSON(t.Context(), server.Client(), server.URL+"/tags", nil, &out)
+		assert.ErrorContains(t, err, "requesting /tags: unexpected status 403: forbidden")
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{`))
+		}))
+		t.Cleanup(server.Close)
+
+		var out map[string]any
+		err := GetJSON(t.Context(), server.Client(), server.URL+"/tags", nil, &out)
+		assert.ErrorContains(t, err, "decoding /tags response")
+	})
+}
+
+func TestPostJSON(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"username": "ainsley"}, body)
+
+		_, _ = w.Write([]byte(`{"token": "abc"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	var out struct {
+		Token string `json:"token"`
+	}
+	err := PostJSON(t.Context(), server.Client(), server.URL, map[string]string{"username": "ainsley"}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", out.Token)
+}
@@ -16,6 +16,22 @@ terraform {
       source  = "hetznercloud/hcloud"
       version = "~> 1.0"
     }
+    hetznerdns = {
+      source  = "germanbrew/hetznerdns"
+      version = "~> 3.0"
+    }
+    cloudflare = {
+      source  = "cloudflare/cloudflare"
+      version = "~> 5.0"
+    }
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 6.0"
+    }
+    random = {
+      source  = "hashicorp/random"
+      version = "~> 3.1"
+    }
     b2 = {
       source  = "Backblaze/b2"
       version = "~> 0.10.0"
@@ -64,6 +80,24 @@ provider "hcloud" {
   token = var.hetzner_token
 }
 
+provider "hetznerdns" {
+  api_token = var.hetzner_dns_token
+}
+
+provider "cloudflare" {
+  api_token = var.cloudflare_api_token
+}
+
+# Resources set their own region, so this is only the fallback.
+# Credentials are optional and only validated when AWS is in use.
+provider "aws" {
+  region                      = var.aws_region
+  access_key                  = var.aws_access_key_id != "" ? var.aws_access_key_id : null
+  secret_key                  = var.aws_secret_access_key != "" ? var.aws_secret_access_key : null
+  skip_credentials_validation = var.aws_access_key_id == ""
+  skip_requesting_account_id  = var.aws_access_key_id == ""
+}
+
 provider "b2" {
   application_key    = var.b2_application_key
   application_key_id = var.b2_application_key_id
@@ -167,6 +201,9 @@ module "resources" {
   platform_provider = each.value.platform_provider
   platform_config   = each.value.config
   tags              = local.common_tags
+  vpc_id            = lookup(local.resource_vpc_ids, each.key, null)
+
+  cloudflare_account_id = var.cloudflare_account_id
 }
 
 #
@@ -183,11 +220,11 @@ module "apps" {
   platform_provider = each.value.platform_provider
   platform_config   = each.value.config
   image_tag         = try(each.value.image_tag, "latest")
-  github_config = {
-    owner = var.github_config.owner
-    repo  = var.github_config.repo
-    token = var.github_token_classic
-  }
+  app_port          = try(each.value.port, null)
+
+  image                = try(each.value.image, null)
+  registry_credentials = var.registry_credentials
+
   do_ssh_key_ids      = local.do_ssh_key_ids
   hetzner_ssh_key_ids = local.hetzner_ssh_key_ids
   domains             = try(each.value.domains, [])
@@ -195,6 +232,8 @@ module "apps" {
   tags                = local.common_tags
   slack_webhook_url   = var.slack_webhook_url
   slack_channel_name  = slack_conversation.project_channel.name
+  vpc_id              = lookup(local.app_vpc_ids, each.key, null)
+  firewall_enabled    = length(try(each.value.network.inbound, [])) == 0
 
   resource_outputs = module.resources
   depends_on       = [module.resources]
@@ -0,0 +1,235 @@
+#
+# Network
+# Firewalls, trusted sources and private networks declared in the
+# network block of apps and resources in the manifest.
+#
+# Rules that reference other apps are defined here rather than in the
+# app and resource modules so they can use the outputs of both without
+# creating a dependency cycle.
+#
+
+locals {
+  network_apps      = { for a in var.apps : a.name => a if try(a.network, null) != null }
+  network_resources = { for r in var.resources : r.name => r if try(r.network, null) != null }
+
+  # Apps that replace the default VM firewall with their own rules.
+  firewall_apps = { for k, a in local.network_apps : k => a if length(a.network.inbound) > 0 }
+
+  # Resources that only accept connections from trusted sources.
+  trusted_resources = {
+    for k, r in local.network_resources : k => r
+    if length(r.network.trusted_addresses) > 0 || length(r.network.trusted_apps) > 0
+  }
+
+  # DigitalOcean VPCs are regional, one is created for every region
+  # that has an app or resource placed in a VPC.
+  do_vpc_apps = {
+    for k, a in local.network_apps : k => try(a.config.region, "lon1")
+    if a.network.vpc && a.platform_provider == "digitalocean"
+  }
+  do_vpc_resources = {
+    for k, r in local.network_resources : k => try(r.config.region, "lon1")
+    if r.network.vpc && r.platform_provider == "digitalocean"
+  }
+  do_vpc_regions = toset(concat(values(local.do_vpc_apps), values(local.do_vpc_resources)))
+
+  app_vpc_ids      = { for k, region in local.do_vpc_apps : k => digitalocean_vpc.this[region].id }
+  resource_vpc_ids = { for k, region in local.do_vpc_resources : k => digitalocean_vpc.this[region].id }
+
+  # Hetzner networks are global with a subnet per network zone, each
+  # zone has a fixed subnet so adding a zone never renumbers another.
+  hetzner_network_zones = {
+    nbg1 = "eu-central"
+    fsn1 = "eu-central"
+    hel1 = "eu-central"
+    ash  = "us-east"
+    hil  = "us-west"
+    sin  = "ap-southeast"
+  }
+  hetzner_subnet_index = {
+    eu-central   = 1
+    us-east      = 2
+    us-west      = 3
+    ap-southeast = 4
+  }
+  hetzner_vpc_apps = {
+    for k, a in local.network_apps : k => lookup(local.hetzner_network_zones, try(a.config.region, "nbg1"), "eu-central")
+    if a.network.vpc && a.platform_provider == "hetzner"
+  }
+  hetzner_vpc_zones = toset(values(local.hetzner_vpc_apps))
+}
+
+#
+# DigitalOcean VPC
+# Ref: https://registry.terraform.io/providers/digitalocean/digitalocean/latest/docs/resources/vpc
+#
+resource "digitalocean_vpc" "this" {
+  for_each = local.do_vpc_regions
+
+  name   = "${var.project_name}-${local.environment_short}-${each.key}"
+  region = each.key
+}
+
+#
+# Hetzner Network
+# Ref: https://registry.terraform.io/providers/hetznercloud/hcloud/latest/docs/resources/network
+#
+resource "hcloud_network" "this" {
+  count = length(local.hetzner_vpc_apps) > 0 ? 1 : 0
+
+  name     = "${var.project_name}-${local.environment_short}"
+  ip_range = "10.0.0.0/16"
+  labels   = { for tag in local.common_tags : tag => "" }
+}
+
+resource "hcloud_network_subnet" "this" {
+  for_each = local.hetzner_vpc_zones
+
+  network_id   = hcloud_network.this[0].id
+  type         = "cloud"
+  network_zone = each.key
+  ip_range     = cidrsubnet("10.0.0.0/16", 8, local.hetzner_subnet_index[each.key])
+}
+
+resource "hcloud_server_network" "apps" {
+  for_each = local.hetzner_vpc_apps
+
+  server_id = module.apps[each.key].server_id
+  subnet_id = hcloud_network_subnet.this[each.value].id
+}
+
+#
+# App Firewalls (DigitalOcean)
+# Ref: https://registry.terraform.io/providers/digitalocean/digitalocean/latest/docs/resources/firewall
+#
+resource "digitalocean_firewall" "apps" {
+  for_each = { for k, a in local.firewall_apps : k => a if a.platform_provider == "digitalocean" }
+
+  name        = "${var.project_name}-${each.key}-network"
+  droplet_ids = [module.apps[each.key].droplet_id]
+
+  dynamic "inbound_rule" {
+    for_each = each.value.network.inbound
+    content {
+      protocol   = inbound_rule.value.protocol
+      port_range = tostring(inbound_rule.value.port)
+      source_addresses = concat(
+        inbound_rule.value.addresses,
+        [for app in inbound_rule.value.apps : "${module.apps[app].ip_address}/32" if module.apps[app].platform_provider != "digitalocean"],
+      )
+      source_droplet_ids = [
+        for app in inbound_rule.value.apps : module.apps[app].droplet_id if module.apps[app].platform_provider == "digitalocean"
+      ]
+    }
+  }
+
+  inbound_rule {
+    protocol         = "icmp"
+    source_addresses = ["0.0.0.0/0", "::/0"]
+  }
+
+  outbound_rule {
+    protocol              = "tcp"
+    port_range            = "1-65535"
+    destination_addresses = ["0.0.0.0/0", "::/0"]
+  }
+
+  outbound_rule {
+    protocol              = "udp"
+    port_range            = "1-65535"
+    destination_addresses = ["0.0.0.0/0", "::/0"]
+  }
+
+  outbound_rule {
+    protocol              = "icmp"
+    destination_addresses = ["0.0.0.0/0", "::/0"]
+  }
+}
+
+#
+# App Firewalls (Hetzner)
+# Ref: https://registry.terraform.io/providers/hetznercloud/hcloud/latest/docs/resources/firewall
+#
+resource "hcloud_firewall" "apps" {
+  for_each = { for k, a in local.firewall_apps : k => a if a.platform_provider == "hetzner" }
+
+  name   = "${var.project_name}-${each.key}-network"
+  labels = { for tag in local.common_tags : tag => "" }
+
+  dynamic "rule" {
+    for_each = each.value.network.inbound
+    content {
+      direction = "in"
+      protocol  = rule.value.protocol
+      port      = tostring(rule.value.port)
+      source_ips = concat(
+        rule.value.addresses,
+        [for app in rule.value.apps : "${module.apps[app].ip_address}/32"],
+      )
+    }
+  }
+
+  rule {
+    direction  = "in"
+    protocol   = "icmp"
+    source_ips = ["0.0.0.0/0", "::/0"]
+  }
+
+  rule {
+    direction       = "out"
+    protocol        = "tcp"
+    port            = "any"
+    destination_ips = ["0.0.0.0/0", "::/0"]
+  }
+
+  rule {
+    direction       = "out"
+    protocol        = "udp"
+    port            = "any"
+    destination_ips = ["0.0.0.0/0", "::/0"]
+  }
+
+  rule {
+    direction       = "out"
+    protocol        = "icmp"
+    destination_ips = ["0.0.0.0/0", "::/0"]
+  }
+
+  apply_to {
+    server = module.apps[each.key].server_id
+  }
+}
+
+#
+# Database Trusted Sources (DigitalOcean)
+# Ref: https://registry.terraform.io/providers/digitalocean/digitalocean/latest/docs/resources/database_firewall
+#
+resource "digitalocean_database_firewall" "resources" {
+  for_each = { for k, r in local.trusted_resources : k => r if r.platform_provider == "digitalocean" }
+
+  cluster_id = module.resources[each.key].id
+
+  dynamic "rule" {
+    for_each = each.value.network.trusted_addresses
+    content {
+      type  = "ip_addr"
+      value = rule.value
+    }
+  }
+
+  dynamic "rule" {
+    for_each = each.value.network.trusted_apps
+    content {
+      type = (
+        module.apps[rule.value].droplet_id != null ? "droplet" :
+        module.apps[rule.value].app_id != null ? "app" :
+        "ip_addr"
+      )
+      value = tostring(coalesce(
+        module.apps[rule.value].droplet_id,
+        module.apps[rule.value].app_id,
+        module.apps[rule.value].ip_address,
+      ))
+    }
+  }
+}
@@ -62,6 +62,46 @@ variable "hetzner_token" {
   default     = ""
 }
 
+variable "cloudflare_api_token" {
+  type        = string
+  description = "Cloudflare API token for managing DNS records"
+  sensitive   = true
+  default     = ""
+}
+
+variable "aws_access_key_id" {
+  type        = string
+  description = "AWS access key ID for S3 and RDS resources"
+  sensitive   = true
+  default     = ""
+}
+
+variable "aws_secret_access_key" {
+  type        = string
+  description = "AWS secret access key for S3 and RDS resources"
+  sensitive   = true
+  default     = ""
+}
+
+variable "aws_region" {
+  type        = string
+  description = "Default AWS region, resources can override it via config.region"
+  default     = "eu-west-2"
+}
+
+variable "cloudflare_account_id" {
+  type        = string
+  description = "Cloudflare account ID for R2 buckets"
+  default     = ""
+}
+
+variable "hetzner_dns_token" {
+  type        = string
+  description = "Hetzner DNS API token for managing DNS records"
+  sensitive   = true
+  default     = ""
+}
+
 variable "b2_application_key" {
   type      = string
   sensitive = true
@@ -89,6 +129,13 @@ variable "github_token_classic" {
   sensitive = true
 }
 
+variable "registry_credentials" {
+  type        = string
+  description = "Credentials used to pull app images from the container registry (username:password)"
+  sensitive   = true
+  default     = null
+}
+
 variable "resources" {
   type = list(object({
     name              = string
@@ -96,6 +143,17 @@ variable "resources" {
     platform_provider = string
     config            = any
     outputs           = optional(list(string), [])
+    network = optional(object({
+      inbound = optional(list(object({
+        port      = number
+        protocol  = optional(string, "tcp")
+        addresses = optional(list(string), [])
+        apps      = optional(list(string), [])
+      })), [])
+      trusted_addresses = optional(list(string), [])
+      trusted_apps      = optional(list(string), [])
+      vpc               = optional(bool, false)
+    }))
   }))
   description = "List of resources from the app.json manifest"
   default     = []
@@ -145,12 +203,20 @@ variable "apps" {
     app_type          = string
     path              = optional(string)
     image_tag         = optional(string, "latest")
+    image = optional(object({
+      registry_type = string
+      registry      = optional(string)
+      repository    = string
+    }))
+    port              = optional(number)
     config            = any
     domains = optional(list(object({
       name     = string
       type     = string
-      zone     = optional(string)
-      wildcard = optional(bool, false)
+      zone         = optional(string)
+      wildcard     = optional(bool, false)
+      dns_provider = optional(string)
+      proxied      = optional(bool, false)
     })), [])
     env_vars = optional(list(object({
       key    = string
@@ -158,6 +224,17 @@ variable "apps" {
       source = string
       type   = optional(string, "GENERAL")
     })), [])
+    network = optional(object({
+      inbound = optional(list(object({
+        port      = number
+        protocol  = optional(string, "tcp")
+        addresses = optional(list(string), [])
+        apps      = optional(list(string), [])
+      })), [])
+      trusted_addresses = optional(list(string), [])
+      trusted_apps      = optional(list(string), [])
+      vpc               = optional(bool, false)
+    }))
   }))
   description = "List of apps from the app.json manifest"
   default     = []
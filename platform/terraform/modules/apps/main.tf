@@ -3,11 +3,70 @@
 # Maps generic infra types to provider-specific resources.
 #
 
+locals {
+  is_do_vm        = var.platform_provider == "digitalocean" && var.platform_type == "vm"
+  is_hetzner_vm   = var.platform_provider == "hetzner" && var.platform_type == "vm"
+  is_do_container = var.platform_provider == "digitalocean" && var.platform_type == "container"
+
+  # The primary domain is used for reverse DNS on VMs.
+  primary_domain = try([for d in var.domains : d.name if upper(d.type) == "PRIMARY"][0], "")
+
+  # Zone for each managed domain. Webkit always sets it, falling back
+  # to the registrable domain from the public suffix list, so zones
+  # such as "example.co.uk" aren't derived here.
+  domain_zones = {
+    for d in var.domains : d.name => d.zone if upper(d.type) != "UNMANAGED" && d.zone != null
+  }
+
+  # DNS provider for each managed domain, defaulting to the platform provider.
+  domain_dns = {
+    for d in var.domains : d.name => coalesce(d.dns_provider, var.platform_provider) if contains(keys(local.domain_zones), d.name)
+  }
+
+  # DNS records keyed by FQDN, including the wildcard record when
+  # requested. The name is relative to the zone (@ for the apex).
+  dns_records = merge([
+    for d in var.domains : {
+      for fqdn in concat([d.name], d.wildcard && !startswith(d.name, "*.") ? ["*.${d.name}"] : []) : fqdn => {
+        zone     = local.domain_zones[d.name]
+        name     = fqdn == local.domain_zones[d.name] ? "@" : trimsuffix(fqdn, ".${local.domain_zones[d.name]}")
+        provider = local.domain_dns[d.name]
+        proxied  = d.proxied
+      }
+    } if contains(keys(local.domain_zones), d.name)
+  ]...)
+
+  # Record values by type: VMs get A/AAAA records pointing at the server
+  # and App Platform gets a CNAME to its default ingress.
+  dns_targets = (
+    local.is_do_vm ? tomap({
+      A    = module.do_droplet[0].ip_address
+      AAAA = module.do_droplet[0].ipv6_address
+    }) :
+    local.is_hetzner_vm ? tomap({
+      A    = module.hetzner_server[0].ip_address
+      AAAA = module.hetzner_server[0].ipv6_address
+    }) :
+    local.is_do_container ? tomap({
+      CNAME = trimsuffix(trimprefix(module.do_app[0].ingress, "https://"), "/")
+    }) :
+    tomap({})
+  )
+
+  # Every record to create keyed by FQDN and type. App Platform creates
+  # its own records in DigitalOcean hosted zones, so they're skipped here.
+  dns_record_set = merge([
+    for fqdn, r in local.dns_records : {
+      for type, value in local.dns_targets : "${fqdn}/${type}" => merge(r, { type = type, value = value })
+    } if !(local.is_do_container && r.provider == "digitalocean")
+  ]...)
+}
+
 #
 # DigitalOcean Droplet (VM)
 #
 module "do_droplet" {
-  count  = var.platform_provider == "digitalocean" && var.platform_type == "vm" ? 1 : 0
+  count  = local.is_do_vm ? 1 : 0
   source = "../../providers/digital_ocean/droplet"
 
   name           = "${var.project_name}-${var.name}"
@@ -16,13 +75,16 @@ module "do_droplet" {
   ssh_key_ids    = var.do_ssh_key_ids
   tags           = try(var.tags, [])
   server_user    = var.server_user
+
+  vpc_id           = var.vpc_id
+  firewall_enabled = var.firewall_enabled
 }
 
 #
 # Hetzner Server (VM)
 #
 module "hetzner_server" {
-  count  = var.platform_provider == "hetzner" && var.platform_type == "vm" ? 1 : 0
+  count  = local.is_hetzner_vm ? 1 : 0
   source = "../../providers/hetzner/server"
 
   name        = "${var.project_name}-${var.name}"
@@ -31,21 +93,42 @@ module "hetzner_server" {
   ssh_key_ids = var.hetzner_ssh_key_ids
   tags        = try(var.tags, [])
   server_user = var.server_user
+  app_port    = var.app_port
+  reverse_dns = local.primary_domain
+
+  firewall_enabled = var.firewall_enabled
+}
+
+#
+# Hetzner Volume (VM)
+# Only created when a volume size is set in the app's infra config.
+#
+module "hetzner_volume" {
+  count  = local.is_hetzner_vm && try(var.platform_config.volume_size, 0) > 0 ? 1 : 0
+  source = "../../providers/hetzner/volume"
+
+  name      = "${var.project_name}-${var.name}-data"
+  size      = var.platform_config.volume_size
+  format    = try(var.platform_config.volume_format, "ext4")
+  location  = try(var.platform_config.region, "nbg1")
+  server_id = module.hetzner_server[0].id
+  tags      = try(var.tags, [])
 }
 
 #
 # DigitalOcean App Platform (Container)
 #
 module "do_app" {
-  count  = var.platform_provider == "digitalocean" && var.platform_type == "container" ? 1 : 0
+  count  = local.is_do_container ? 1 : 0
   source = "../../providers/digital_ocean/app"
 
   # Construct the DigitalOcean App name: project-name-app-name
   name = "${var.project_name}-${var.name}"
 
-  # Construct the GHCR repository path to match what GitHub Actions publishes
-  # GitHub Actions publishes as: ghcr.io/{owner}/{repo-name}-{app-name}
-  repository = "${var.github_config.owner}/${var.github_config.repo}-${var.name}"
+  # The image is resolved from the registry in app.json to match what
+  # the release workflow publishes: {registry}/{namespace}/{repo-name}-{app-name}
+  image                = var.image
+  registry_credentials = var.registry_credentials
 
   service_name       = var.app_type
   region             = try(var.platform_config.region, "lon")
@@ -53,7 +136,6 @@ module "do_app" {
   instance_count     = try(var.platform_config.instance_count, 1)
   http_port          = try(var.platform_config.port, 3000)
   image_tag          = var.image_tag
-  github_config      = var.github_config
   health_check_path  = try(var.platform_config.health_check_path, "/")
   slack_webhook_url  = var.slack_webhook_url
   slack_channel_name = var.slack_channel_name
@@ -74,24 +156,46 @@ module "do_app" {
   domains = [
     for d in var.domains : {
       name     = d.name
-      type     = upper(d.type) # PRIMARY, ALIAS, UNMANAGED
-      zone     = d.zone
+      type = upper(d.type) # PRIMARY, ALIAS, UNMANAGED
+      # Only hand the zone to App Platform when DigitalOcean hosts it,
+      # in which case App Platform creates and owns the records.
+      zone     = lookup(local.domain_dns, d.name, "") == "digitalocean" ? local.domain_zones[d.name] : null
       wildcard = d.wildcard
     }
   ]
 }
 
 #
-# DNS Record for VM-based apps
-# App Platform handles its own DNS, but VMs need A records
-# module "dns_record" {
-#   count  = local.is_do_vm && local.domain != null ? 1 : 0
-#   source = "../../providers/digital_ocean/domain_record"
+# DNS Records
+# Created in whichever provider hosts the domain's zone.
 #
-#   # Extract root domain and subdomain from FQDN
-#   # e.g., "cms.my-website.com" -> domain: "my-website.com", name: "cms"
-#   domain = join(".", slice(split(".", local.domain), length(split(".", local.domain)) - 2, length(split(".", local.domain))))
-#   name   = join(".", slice(split(".", local.domain), 0, length(split(".", local.domain)) - 2))
-#   type   = "A"
-#   value  = module.do_droplet[0].ip_address
-# }
+module "do_dns_record" {
+  for_each = { for k, r in local.dns_record_set : k => r if r.provider == "digitalocean" }
+  source   = "../../providers/digital_ocean/domain_record"
+
+  domain = each.value.zone
+  name   = each.value.name
+  type   = each.value.type
+  value  = each.value.value
+}
+
+module "hetzner_dns_record" {
+  for_each = { for k, r in local.dns_record_set : k => r if r.provider == "hetzner" }
+  source   = "../../providers/hetzner/dns_record"
+
+  zone  = each.value.zone
+  name  = each.value.name
+  type  = each.value.type
+  value = each.value.value
+}
+
+module "cloudflare_dns_record" {
+  for_each = { for k, r in local.dns_record_set : k => r if r.provider == "cloudflare" }
+  source   = "../../providers/cloudflare/dns_record"
+
+  zone    = each.value.zone
+  name    = each.value.name
+  type    = each.value.type
+  value   = each.value.value
+  proxied = each.value.proxied
+}
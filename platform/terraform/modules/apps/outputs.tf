@@ -15,6 +15,28 @@ output "ip_address" {
   )
 }
 
+output "ipv6_address" {
+  description = "IPv6 address of the VM"
+  value = (
+    var.platform_type == "vm" && var.platform_provider == "digitalocean" ? module.do_droplet[0].ipv6_address :
+    var.platform_type == "vm" && var.platform_provider == "hetzner" ? module.hetzner_server[0].ipv6_address :
+    null
+  )
+}
+
+output "server_id" {
+  description = "ID of the Hetzner server"
+  value = (
+    var.platform_type == "vm" && var.platform_provider == "hetzner" ? module.hetzner_server[0].id :
+    null
+  )
+}
+
+output "volume_device" {
+  description = "Device path of the attached volume on the VM"
+  value       = length(module.hetzner_volume) > 0 ? module.hetzner_volume[0].linux_device : null
+}
+
 output "droplet_id" {
   description = "ID of the droplet"
   value = (
@@ -72,13 +94,14 @@ output "app_domain" {
 #
 # DNS Outputs
 #
-# output "dns_fqdn" {
-#   description = "Fully qualified domain name"
-#   value = (
-#     var.platform_type == "vm" && length(module.dns_record) > 0 ? module.dns_record[0].fqdn :
-#     null
-#   )
-# }
+output "dns_records" {
+  description = "Fully qualified domain names that DNS records were created for"
+  value = distinct(concat(
+    [for r in module.do_dns_record : r.fqdn],
+    [for r in module.hetzner_dns_record : r.fqdn],
+    [for r in module.cloudflare_dns_record : r.fqdn],
+  ))
+}
 
 #
 # Common Outputs
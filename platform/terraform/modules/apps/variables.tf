@@ -64,25 +64,41 @@ variable "domains" {
   type = list(object({
     name     = string
     type     = string
-    zone     = optional(string)
-    wildcard = optional(bool, false)
+    zone         = optional(string)
+    wildcard     = optional(bool, false)
+    dns_provider = optional(string)
+    proxied      = optional(bool, false)
   }))
   default = []
 }
 
+variable "app_port" {
+  description = "Port the app listens on (from build.port in the manifest)"
+  type        = number
+  default     = null
+}
+
 variable "image_tag" {
   description = "Docker image tag to deploy"
   type        = string
   default     = "latest"
 }
 
-variable "github_config" {
-  description = "GitHub Container Registry configuration"
+variable "image" {
+  description = "Container registry and repository that App Platform pulls the image from"
   type = object({
-    owner = string
-    repo  = string
-    token = string
+    registry_type = string
+    registry      = optional(string)
+    repository    = string
   })
+  default = null
+}
+
+variable "registry_credentials" {
+  description = "Credentials used to pull the image from the container registry (username:password)"
+  type        = string
+  sensitive   = true
+  default     = null
 }
 
 variable "do_ssh_key_ids" {
@@ -103,6 +119,18 @@ variable "tags" {
   default     = []
 }
 
+variable "vpc_id" {
+  description = "ID of the VPC to place DigitalOcean VMs in (from the network block in the manifest)"
+  type        = string
+  default     = null
+}
+
+variable "firewall_enabled" {
+  description = "Whether VMs get the default firewall, false when the app declares its own inbound rules"
+  type        = bool
+  default     = true
+}
+
 variable "server_user" {
   description = "SSH user for VM deployments"
   type        = string
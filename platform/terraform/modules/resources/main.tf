@@ -25,6 +25,8 @@ module "do_postgres" {
     jsondecode(var.platform_config.allowed_droplet_ips),
     []
   )
+
+  vpc_id = var.vpc_id
 }
 
 #
@@ -53,6 +55,66 @@ module "b2_bucket" {
   lifecycle_rule_file_name_prefix = try(var.platform_config.lifecycle_rule_file_name_prefix, null)
 }
 
+#
+# Cloudflare R2 Bucket
+#
+module "r2_bucket" {
+  count  = var.platform_provider == "cloudflare" && var.platform_type == "s3" ? 1 : 0
+  source = "../../providers/cloudflare/r2_bucket"
+
+  account_id = try(var.platform_config.account_id, var.cloudflare_account_id)
+  name       = "${var.project_name}-${var.name}"
+  location   = try(var.platform_config.location, null)
+  public     = try(var.platform_config.acl, "private") == "public-read"
+}
+
+#
+# AWS Postgres (RDS)
+#
+module "aws_postgres" {
+  count  = var.platform_provider == "aws" && var.platform_type == "postgres" ? 1 : 0
+  source = "../../providers/aws/postgres"
+
+  name                  = "${var.project_name}-${var.name}"
+  pg_version            = try(var.platform_config.pg_version, "17")
+  size                  = try(var.platform_config.size, "db.t4g.micro")
+  region                = try(var.platform_config.region, "eu-west-2")
+  storage_size          = try(var.platform_config.storage_size, 20)
+  max_storage_size      = try(var.platform_config.max_storage_size, 100)
+  multi_az              = try(var.platform_config.multi_az, false)
+  backup_retention_days = try(var.platform_config.backup_retention_days, 7)
+  deletion_protection   = try(var.platform_config.deletion_protection, true)
+  tags                  = try(var.tags, [])
+
+  allowed_ips_addr = try(
+    jsondecode(var.platform_config.allowed_ips_addr),
+    []
+  )
+}
+
+#
+# AWS S3 Bucket
+#
+module "aws_bucket" {
+  count  = var.platform_provider == "aws" && var.platform_type == "s3" ? 1 : 0
+  source = "../../providers/aws/s3_bucket"
+
+  name       = "${var.project_name}-${var.name}"
+  region     = try(var.platform_config.region, "eu-west-2")
+  acl        = try(var.platform_config.acl, "private")
+  versioning = try(var.platform_config.versioning, false)
+  tags       = try(var.tags, [])
+
+  lifecycle_rules = try(
+    jsondecode(var.platform_config.lifecycle_rules),
+    []
+  )
+  cors_rules = try(
+    jsondecode(var.platform_config.cors_rules),
+    []
+  )
+}
+
 #
 # Turso SQLite Database
 #
@@ -10,6 +10,7 @@ output "connection_url" {
   description = "Database connection URL (pool for postgres, libsql URL for sqlite/turso)"
   value = (
     var.platform_type == "postgres" && var.platform_provider == "digitalocean" ? module.do_postgres[0].connection_url :
+    var.platform_type == "postgres" && var.platform_provider == "aws" ? module.aws_postgres[0].connection_url :
     var.platform_type == "sqlite" && var.platform_provider == "turso" ? module.turso_database[0].connection_url_with_token :
     null
   )
@@ -20,6 +21,7 @@ output "host" {
   description = "Database host"
   value = (
     var.platform_type == "postgres" && var.platform_provider == "digitalocean" ? module.do_postgres[0].host :
+    var.platform_type == "postgres" && var.platform_provider == "aws" ? module.aws_postgres[0].host :
     var.platform_type == "sqlite" && var.platform_provider == "turso" ? module.turso_database[0].hostname :
     null
   )
@@ -29,6 +31,7 @@ output "port" {
   description = "Database port"
   value = (
     var.platform_type == "postgres" && var.platform_provider == "digitalocean" ? module.do_postgres[0].port :
+    var.platform_type == "postgres" && var.platform_provider == "aws" ? module.aws_postgres[0].port :
     null
   )
   sensitive = true
@@ -38,6 +41,7 @@ output "database" {
   description = "Database name"
   value = (
     var.platform_type == "postgres" && var.platform_provider == "digitalocean" ? module.do_postgres[0].database :
+    var.platform_type == "postgres" && var.platform_provider == "aws" ? module.aws_postgres[0].database :
     var.platform_type == "sqlite" && var.platform_provider == "turso" ? module.turso_database[0].database :
     null
   )
@@ -60,6 +64,8 @@ output "bucket_name" {
   value = (
     var.platform_provider == "digitalocean" && var.platform_type == "s3" ? module.do_bucket[0].name :
     var.platform_provider == "b2" && var.platform_type == "s3" ? module.b2_bucket[0].name :
+    var.platform_provider == "cloudflare" && var.platform_type == "s3" ? module.r2_bucket[0].name :
+    var.platform_provider == "aws" && var.platform_type == "s3" ? module.aws_bucket[0].name :
     null
   )
 }
@@ -68,6 +74,8 @@ output "bucket_url" {
   description = "S3 bucket URL"
   value = (
     var.platform_provider == "digitalocean" && var.platform_type == "s3" ? module.do_bucket[0].domain_name :
+    var.platform_provider == "cloudflare" && var.platform_type == "s3" ? module.r2_bucket[0].bucket_url :
+    var.platform_provider == "aws" && var.platform_type == "s3" ? "https://${module.aws_bucket[0].domain_name}" :
     null
   )
 }
@@ -76,6 +84,8 @@ output "endpoint" {
   description = "S3 endpoint URL"
   value = (
     var.platform_provider == "digitalocean" && var.platform_type == "s3" ? module.do_bucket[0].domain_name :
+    var.platform_provider == "cloudflare" && var.platform_type == "s3" ? module.r2_bucket[0].endpoint :
+    var.platform_provider == "aws" && var.platform_type == "s3" ? module.aws_bucket[0].endpoint :
     null
   )
 }
@@ -84,6 +94,8 @@ output "region" {
   description = "S3 region"
   value = (
     var.platform_provider == "digitalocean" && var.platform_type == "s3" ? module.do_bucket[0].region :
+    var.platform_provider == "cloudflare" && var.platform_type == "s3" ? module.r2_bucket[0].region :
+    var.platform_provider == "aws" && var.platform_type == "s3" ? module.aws_bucket[0].region :
     null
   )
 }
@@ -95,18 +107,23 @@ output "id" {
   description = "Resource ID (database cluster ID, bucket ID, etc.) - Required for all resources"
   value = (
     var.platform_type == "postgres" && var.platform_provider == "digitalocean" ? module.do_postgres[0].id :
+    var.platform_type == "postgres" && var.platform_provider == "aws" ? module.aws_postgres[0].id :
     var.platform_type == "s3" && var.platform_provider == "digitalocean" ? module.do_bucket[0].id :
     var.platform_type == "s3" && var.platform_provider == "b2" ? module.b2_bucket[0].id :
+    var.platform_type == "s3" && var.platform_provider == "cloudflare" ? module.r2_bucket[0].id :
+    var.platform_type == "s3" && var.platform_provider == "aws" ? module.aws_bucket[0].id :
     var.platform_type == "sqlite" && var.platform_provider == "turso" ? module.turso_database[0].id :
     null
   )
 }
 
 output "urn" {
-  description = "Resource URN (DigitalOcean specific)"
+  description = "Resource URN (DigitalOcean URN or AWS ARN)"
   value = (
     var.platform_type == "postgres" && var.platform_provider == "digitalocean" ? module.do_postgres[0].urn :
     var.platform_type == "s3" && var.platform_provider == "digitalocean" ? module.do_bucket[0].urn :
+    var.platform_type == "postgres" && var.platform_provider == "aws" ? module.aws_postgres[0].urn :
+    var.platform_type == "s3" && var.platform_provider == "aws" ? module.aws_bucket[0].urn :
     null
   )
 }
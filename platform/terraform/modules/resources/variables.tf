@@ -23,8 +23,8 @@ variable "platform_provider" {
   type        = string
 
   validation {
-    condition     = contains(["digitalocean", "backblaze", "turso"], var.platform_provider)
-    error_message = "Provider must be one of: digitalocean, backblaze, turso"
+    condition     = contains(["digitalocean", "backblaze", "turso", "cloudflare", "aws"], var.platform_provider)
+    error_message = "Provider must be one of: digitalocean, backblaze, turso, cloudflare, aws"
   }
 }
 
@@ -38,3 +38,15 @@ variable "tags" {
   type        = list(string)
   default     = []
 }
+
+variable "cloudflare_account_id" {
+  description = "Cloudflare account ID used for R2 buckets"
+  type        = string
+  default     = ""
+}
+
+variable "vpc_id" {
+  description = "ID of the VPC to place DigitalOcean databases in (from the network block in the manifest)"
+  type        = string
+  default     = null
+}
@@ -0,0 +1,97 @@
+#
+# AWS Postgres
+# Provisions an RDS PostgreSQL instance in the region's default VPC.
+#
+
+locals {
+  db_prefix = lower(replace(var.name, "-", "_"))
+  tags      = { for t in var.tags : t => "true" }
+}
+
+data "aws_vpc" "default" {
+  region  = var.region
+  default = true
+}
+
+#
+# Master Password
+#
+# Ref: https://registry.terraform.io/providers/hashicorp/random/latest/docs/resources/password
+#
+resource "random_password" "this" {
+  length           = 32
+  special          = true
+  override_special = "-_"
+}
+
+#
+# Security Group
+# Only allows inbound Postgres traffic from the given CIDR blocks.
+#
+# Ref: https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/security_group
+#
+resource "aws_security_group" "this" {
+  name        = "${var.name}-postgres"
+  description = "Postgres access for ${var.name}"
+  vpc_id      = data.aws_vpc.default.id
+  region      = var.region
+  tags        = local.tags
+
+  dynamic "ingress" {
+    for_each = length(var.allowed_ips_addr) > 0 ? [1] : []
+    content {
+      description = "Postgres"
+      from_port   = 5432
+      to_port     = 5432
+      protocol    = "tcp"
+      cidr_blocks = var.allowed_ips_addr
+    }
+  }
+
+  egress {
+    from_port   = 0
+    to_port     = 0
+    protocol    = "-1"
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+
+  # Rules are added and removed by the backup workflow
+  # for the runner's IP, so don't fight over them.
+  lifecycle {
+    ignore_changes = [ingress]
+  }
+}
+
+#
+# Database Instance
+#
+# Ref: https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/db_instance
+#
+resource "aws_db_instance" "this" {
+  identifier     = var.name
+  region         = var.region
+  engine         = "postgres"
+  engine_version = var.pg_version
+  instance_class = var.size
+
+  allocated_storage     = var.storage_size
+  max_allocated_storage = var.max_storage_size
+  storage_type          = "gp3"
+  storage_encrypted     = true
+
+  db_name  = local.db_prefix
+  username = "${local.db_prefix}_admin"
+  password = random_password.this.result
+
+  multi_az               = var.multi_az
+  publicly_accessible    = var.publicly_accessible
+  vpc_security_group_ids = [aws_security_group.this.id]
+
+  backup_retention_period   = var.backup_retention_days
+  deletion_protection       = var.deletion_protection
+  skip_final_snapshot       = false
+  final_snapshot_identifier = "${var.name}-final"
+  apply_immediately         = true
+
+  tags = local.tags
+}
@@ -0,0 +1,47 @@
+output "id" {
+  description = "The identifier of the RDS instance"
+  value       = aws_db_instance.this.identifier
+}
+
+output "urn" {
+  description = "The ARN of the RDS instance"
+  value       = aws_db_instance.this.arn
+}
+
+output "host" {
+  description = "The host of the RDS instance"
+  value       = aws_db_instance.this.address
+}
+
+output "port" {
+  description = "The port Postgres is running on"
+  value       = aws_db_instance.this.port
+  sensitive   = true
+}
+
+output "database" {
+  description = "The database name"
+  value       = aws_db_instance.this.db_name
+}
+
+output "user" {
+  description = "The database user name"
+  value       = aws_db_instance.this.username
+}
+
+output "password" {
+  description = "The database user password"
+  value       = random_password.this.result
+  sensitive   = true
+}
+
+output "connection_url" {
+  description = "The Postgres connection URI"
+  value       = "postgresql://${aws_db_instance.this.username}:${random_password.this.result}@${aws_db_instance.this.address}:${aws_db_instance.this.port}/${aws_db_instance.this.db_name}?sslmode=require"
+  sensitive   = true
+}
+
+output "security_group_id" {
+  description = "The ID of the security group guarding the instance"
+  value       = aws_security_group.this.id
+}
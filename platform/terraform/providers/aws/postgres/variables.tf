@@ -0,0 +1,66 @@
+variable "name" {
+  description = "The identifier of the RDS instance"
+  type        = string
+}
+
+variable "pg_version" {
+  type        = string
+  default     = "17"
+  description = "The Postgres version of the database, defaults to 17"
+}
+
+variable "size" {
+  type        = string
+  default     = "db.t4g.micro"
+  description = "The RDS instance class"
+}
+
+variable "region" {
+  type    = string
+  default = "eu-west-2"
+}
+
+variable "storage_size" {
+  type        = number
+  default     = 20
+  description = "Allocated storage in GB"
+}
+
+variable "max_storage_size" {
+  type        = number
+  default     = 100
+  description = "Upper limit in GB for storage autoscaling"
+}
+
+variable "multi_az" {
+  type    = bool
+  default = false
+}
+
+variable "publicly_accessible" {
+  type        = bool
+  default     = true
+  description = "Whether the instance has a public endpoint, required for backups from CI"
+}
+
+variable "backup_retention_days" {
+  type    = number
+  default = 7
+}
+
+variable "deletion_protection" {
+  type    = bool
+  default = true
+}
+
+variable "allowed_ips_addr" {
+  type        = list(string)
+  default     = []
+  description = "CIDR blocks allowed to connect to the database"
+}
+
+variable "tags" {
+  description = "List of tags to apply to the resource"
+  type        = list(string)
+  default     = []
+}
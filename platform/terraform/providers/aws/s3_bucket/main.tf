@@ -0,0 +1,165 @@
+#
+# AWS S3 Bucket
+# Provisions an S3 bucket with optional public access, lifecycle rules and CORS.
+#
+
+locals {
+  is_public = var.acl == "public-read"
+}
+
+#
+# Bucket
+#
+# Ref: https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/s3_bucket
+#
+resource "aws_s3_bucket" "this" {
+  bucket = var.name
+  region = var.region
+
+  tags = { for t in var.tags : t => "true" }
+}
+
+#
+# Ownership Controls
+# Disables ACLs so access is controlled by bucket policy alone.
+#
+# Ref: https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/s3_bucket_ownership_controls
+#
+resource "aws_s3_bucket_ownership_controls" "this" {
+  bucket = aws_s3_bucket.this.id
+  region = var.region
+
+  rule {
+    object_ownership = "BucketOwnerEnforced"
+  }
+}
+
+#
+# Public Access Block
+#
+# Ref: https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/s3_bucket_public_access_block
+#
+resource "aws_s3_bucket_public_access_block" "this" {
+  bucket = aws_s3_bucket.this.id
+  region = var.region
+
+  block_public_acls       = true
+  ignore_public_acls      = true
+  block_public_policy     = !local.is_public
+  restrict_public_buckets = !local.is_public
+}
+
+#
+# Public Read Policy
+# Only created when the bucket ACL is public-read.
+#
+# Ref: https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/s3_bucket_policy
+#
+resource "aws_s3_bucket_policy" "public_read" {
+  count  = local.is_public ? 1 : 0
+  bucket = aws_s3_bucket.this.id
+  region = var.region
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid       = "PublicReadGetObject"
+        Effect    = "Allow"
+        Principal = "*"
+        Action    = "s3:GetObject"
+        Resource  = "${aws_s3_bucket.this.arn}/*"
+      }
+    ]
+  })
+
+  depends_on = [aws_s3_bucket_public_access_block.this]
+}
+
+#
+# Versioning
+#
+# Ref: https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/s3_bucket_versioning
+#
+resource "aws_s3_bucket_versioning" "this" {
+  count  = var.versioning ? 1 : 0
+  bucket = aws_s3_bucket.this.id
+  region = var.region
+
+  versioning_configuration {
+    status = "Enabled"
+  }
+}
+
+#
+# Lifecycle Rules
+#
+# Ref: https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/s3_bucket_lifecycle_configuration
+#
+resource "aws_s3_bucket_lifecycle_configuration" "this" {
+  count  = length(var.lifecycle_rules) > 0 ? 1 : 0
+  bucket = aws_s3_bucket.this.id
+  region = var.region
+
+  dynamic "rule" {
+    for_each = var.lifecycle_rules
+    content {
+      id     = rule.value.id
+      status = "Enabled"
+
+      filter {
+        prefix = rule.value.prefix
+      }
+
+      dynamic "transition" {
+        for_each = rule.value.transition_days != null ? [1] : []
+        content {
+          days          = rule.value.transition_days
+          storage_class = rule.value.storage_class
+        }
+      }
+
+      dynamic "expiration" {
+        for_each = rule.value.expiration_days != null ? [1] : []
+        content {
+          days = rule.value.expiration_days
+        }
+      }
+
+      dynamic "noncurrent_version_expiration" {
+        for_each = rule.value.noncurrent_expiration_days != null ? [1] : []
+        content {
+          noncurrent_days = rule.value.noncurrent_expiration_days
+        }
+      }
+
+      abort_incomplete_multipart_upload {
+        days_after_initiation = 7
+      }
+    }
+  }
+
+  depends_on = [aws_s3_bucket_versioning.this]
+}
+
+#
+# CORS
+#
+# Ref: https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/s3_bucket_cors_configuration
+#
+resource "aws_s3_bucket_cors_configuration" "this" {
+  count  = length(var.cors_rules) > 0 ? 1 : 0
+  bucket = aws_s3_bucket.this.id
+  region = var.region
+
+  dynamic "cors_rule" {
+    for_each = var.cors_rules
+    content {
+      allowed_methods = cors_rule.value.allowed_methods
+      allowed_origins = cors_rule.value.allowed_origins
+      allowed_headers = cors_rule.value.allowed_headers
+      expose_headers  = cors_rule.value.expose_headers
+      max_age_seconds = cors_rule.value.max_age_seconds
+    }
+  }
+}
@@ -0,0 +1,29 @@
+output "id" {
+  description = "The name of the bucket"
+  value       = aws_s3_bucket.this.id
+}
+
+output "urn" {
+  description = "The ARN of the bucket"
+  value       = aws_s3_bucket.this.arn
+}
+
+output "name" {
+  description = "The name of the bucket"
+  value       = aws_s3_bucket.this.bucket
+}
+
+output "region" {
+  description = "The region the bucket resides in"
+  value       = aws_s3_bucket.this.region
+}
+
+output "domain_name" {
+  description = "The regional domain name of the bucket"
+  value       = aws_s3_bucket.this.bucket_regional_domain_name
+}
+
+output "endpoint" {
+  description = "The S3 endpoint for the bucket's region"
+  value       = "https://s3.${aws_s3_bucket.this.region}.amazonaws.com"
+}
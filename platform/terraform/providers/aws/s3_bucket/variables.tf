@@ -0,0 +1,58 @@
+variable "name" {
+  type        = string
+  description = "Globally unique name of the S3 bucket"
+}
+
+variable "region" {
+  type        = string
+  description = "AWS region to create the bucket in"
+  default     = "eu-west-2"
+}
+
+variable "acl" {
+  type        = string
+  description = "Access level of the bucket (private or public-read)"
+  default     = "private"
+
+  validation {
+    condition     = contains(["private", "public-read"], var.acl)
+    error_message = "ACL must be one of: private, public-read"
+  }
+}
+
+variable "versioning" {
+  type        = bool
+  description = "Whether object versioning is enabled"
+  default     = false
+}
+
+variable "lifecycle_rules" {
+  type = list(object({
+    id                         = string
+    prefix                     = optional(string, "")
+    expiration_days            = optional(number)
+    noncurrent_expiration_days = optional(number)
+    transition_days            = optional(number)
+    storage_class              = optional(string, "STANDARD_IA")
+  }))
+  description = "Lifecycle rules for expiring or transitioning objects"
+  default     = []
+}
+
+variable "cors_rules" {
+  type = list(object({
+    allowed_methods = list(string)
+    allowed_origins = list(string)
+    allowed_headers = optional(list(string), ["*"])
+    expose_headers  = optional(list(string), [])
+    max_age_seconds = optional(number, 3600)
+  }))
+  description = "CORS rules for browser access to the bucket"
+  default     = []
+}
+
+variable "tags" {
+  type        = list(string)
+  description = "Tags to apply to the bucket"
+  default     = []
+}
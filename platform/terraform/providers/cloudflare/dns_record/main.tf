@@ -0,0 +1,22 @@
+#
+# Cloudflare DNS Record
+# Creates a record within an existing Cloudflare zone.
+#
+# Ref: https://registry.terraform.io/providers/cloudflare/cloudflare/latest/docs/resources/dns_record
+#
+data "cloudflare_zone" "this" {
+  filter = {
+    name = var.zone
+  }
+}
+
+resource "cloudflare_dns_record" "this" {
+  zone_id = data.cloudflare_zone.this.zone_id
+  name    = var.name == "@" ? var.zone : "${var.name}.${var.zone}"
+  type    = var.type
+  content = var.value
+  proxied = var.proxied
+
+  # Cloudflare requires a TTL of 1 (automatic) for proxied records.
+  ttl = var.proxied ? 1 : var.ttl
+}
@@ -0,0 +1,9 @@
+output "id" {
+  description = "The ID of the DNS record"
+  value       = cloudflare_dns_record.this.id
+}
+
+output "fqdn" {
+  description = "The FQDN of the record"
+  value       = cloudflare_dns_record.this.name
+}
@@ -0,0 +1,37 @@
+variable "zone" {
+  description = "The DNS zone (root domain) the record belongs to, e.g. example.com"
+  type        = string
+}
+
+variable "name" {
+  description = "The name of the record relative to the zone (@ for the apex)"
+  type        = string
+}
+
+variable "value" {
+  description = "The value of the record"
+  type        = string
+}
+
+variable "type" {
+  description = "The type of DNS record (A, AAAA, CNAME, etc.)"
+  type        = string
+  default     = "A"
+
+  validation {
+    condition     = contains(["A", "AAAA", "CNAME", "MX", "TXT", "SRV", "NS"], var.type)
+    error_message = "Type must be a valid DNS record type."
+  }
+}
+
+variable "ttl" {
+  description = "Time to live of the record in seconds (ignored when proxied)"
+  type        = number
+  default     = 1800
+}
+
+variable "proxied" {
+  description = "Whether traffic is proxied through Cloudflare"
+  type        = bool
+  default     = false
+}
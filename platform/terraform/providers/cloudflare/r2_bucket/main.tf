@@ -0,0 +1,47 @@
+#
+# Cloudflare R2 Bucket
+# Creates an S3-compatible object storage bucket with zero egress fees.
+#
+
+#
+# R2 Bucket
+#
+# Ref: https://registry.terraform.io/providers/cloudflare/cloudflare/latest/docs/resources/r2_bucket
+#
+resource "cloudflare_r2_bucket" "this" {
+  account_id = var.account_id
+  name       = var.name
+  location   = var.location
+}
+
+#
+# CORS Configuration
+#
+# Ref: https://registry.terraform.io/providers/cloudflare/cloudflare/latest/docs/resources/r2_bucket_cors
+#
+resource "cloudflare_r2_bucket_cors" "this" {
+  account_id  = var.account_id
+  bucket_name = cloudflare_r2_bucket.this.name
+
+  rules = [{
+    allowed = {
+      headers = ["*"]
+      methods = ["GET"]
+      origins = ["*"]
+    }
+    max_age_seconds = 3600
+  }]
+}
+
+#
+# Public Access
+# Enables the r2.dev managed domain when the bucket is public.
+#
+# Ref: https://registry.terraform.io/providers/cloudflare/cloudflare/latest/docs/resources/r2_managed_domain
+#
+resource "cloudflare_r2_managed_domain" "this" {
+  count       = var.public ? 1 : 0
+  account_id  = var.account_id
+  bucket_name = cloudflare_r2_bucket.this.name
+  enabled     = true
+}
@@ -0,0 +1,28 @@
+output "id" {
+  description = "The ID of the R2 bucket"
+  value       = cloudflare_r2_bucket.this.id
+}
+
+output "name" {
+  description = "The name of the R2 bucket"
+  value       = cloudflare_r2_bucket.this.name
+}
+
+output "region" {
+  description = "The S3 region to use with R2, which is always auto"
+  value       = "auto"
+}
+
+output "endpoint" {
+  description = "S3-compatible endpoint for the account"
+  value       = "https://${var.account_id}.r2.cloudflarestorage.com"
+}
+
+output "bucket_url" {
+  description = "Public URL of the bucket, or its S3 URL when private"
+  value = (
+    var.public
+    ? "https://${cloudflare_r2_managed_domain.this[0].domain}"
+    : "https://${var.account_id}.r2.cloudflarestorage.com/${cloudflare_r2_bucket.this.name}"
+  )
+}
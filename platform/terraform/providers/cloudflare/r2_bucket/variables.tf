@@ -0,0 +1,21 @@
+variable "account_id" {
+  description = "Cloudflare account ID that owns the bucket"
+  type        = string
+}
+
+variable "name" {
+  description = "The name of the R2 bucket"
+  type        = string
+}
+
+variable "location" {
+  description = "Location hint for the bucket (apac, eeur, enam, weur, wnam, oc)"
+  type        = string
+  default     = null
+}
+
+variable "public" {
+  description = "Whether objects are publicly readable via the r2.dev domain"
+  type        = bool
+  default     = false
+}
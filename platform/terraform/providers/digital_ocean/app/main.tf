@@ -33,11 +33,12 @@ resource "digitalocean_app" "this" {
       http_port          = var.http_port
 
       image {
-        registry_type        = "GHCR"
-        registry             = "ghcr.io"
-        repository           = var.repository
-        tag                  = var.image_tag
-        registry_credentials = "${var.github_config.owner}:${var.github_config.token}"
+        registry_type = var.image.registry_type
+        registry      = var.image.registry
+        repository    = var.image.repository
+        tag           = var.image_tag
+        # DOCR images are pulled with the account's own access.
+        registry_credentials = var.image.registry_type == "DOCR" ? null : var.registry_credentials
       }
 
       health_check {
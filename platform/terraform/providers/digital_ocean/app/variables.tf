@@ -3,9 +3,13 @@ variable "name" {
   type        = string
 }
 
-variable "repository" {
-  description = "The full GHCR repository path (e.g., 'ainsleydev/player2clubs-cms')."
-  type        = string
+variable "image" {
+  description = "The registry and repository to pull the image from (e.g., GHCR, 'ghcr.io', 'ainsleydev/player2clubs-cms')."
+  type = object({
+    registry_type = string
+    registry      = optional(string)
+    repository    = string
+  })
 }
 
 variable "domains" {
@@ -49,18 +53,16 @@ variable "http_port" {
 }
 
 variable "image_tag" {
-  description = "The image tag to deploy from the GitHub Container Registry."
+  description = "The image tag to deploy from the container registry."
   type        = string
   default     = "latest"
 }
 
-variable "github_config" {
-  description = "GitHub Container Registry config: owner, repo, token."
-  type = object({
-    owner = string
-    repo  = string
-    token = string
-  })
+variable "registry_credentials" {
+  description = "Credentials used to pull the image (username:password), not required for DOCR."
+  type        = string
+  sensitive   = true
+  default     = null
 }
 
 variable "health_check_path" {
@@ -2,6 +2,8 @@ resource "digitalocean_record" "this" {
   domain = var.domain
   type   = var.type
   name   = var.name
-  value  = var.value
   ttl    = 1800
+
+  # DigitalOcean requires CNAME values to be fully qualified.
+  value = var.type == "CNAME" && !endswith(var.value, ".") ? "${var.value}." : var.value
 }
@@ -27,6 +27,11 @@ resource "digitalocean_droplet" "this" {
   size   = var.droplet_size
   region = var.droplet_region
   tags   = var.tags
+  ipv6   = true
+
+  # Only set when the app is placed in the project's VPC,
+  # otherwise the region's default VPC is used.
+  vpc_uuid = var.vpc_id
 
   # Sort to ensure deterministic ordering across Terraform runs
   # Combine personal SSH keys (passed as IDs) with the Terraform-generated key
@@ -47,10 +52,13 @@ resource "digitalocean_droplet" "this" {
 
 #
 # Firewall
+# Disabled when the app declares its own inbound rules in app.json,
+# which are provisioned alongside the other network rules instead.
 #
 # Ref: https://registry.terraform.io/providers/digitalocean/digitalocean/latest/docs/resources/firewall
 #
 resource "digitalocean_firewall" "this" {
+  count       = var.firewall_enabled ? 1 : 0
   name        = "${var.name}-firewall"
   droplet_ids = [digitalocean_droplet.this.id]
 
@@ -83,3 +91,8 @@ resource "digitalocean_firewall" "this" {
     destination_addresses = ["0.0.0.0/0", "::/0"]
   }
 }
+
+moved {
+  from = digitalocean_firewall.this
+  to   = digitalocean_firewall.this[0]
+}
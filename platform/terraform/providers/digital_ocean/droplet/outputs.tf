@@ -8,6 +8,11 @@ output "ip_address" {
   value       = digitalocean_droplet.this.ipv4_address
 }
 
+output "ipv6_address" {
+  description = "The IPv6 address of the Droplet"
+  value       = digitalocean_droplet.this.ipv6_address
+}
+
 output "urn" {
   description = "The URN of the Droplet"
   value       = digitalocean_droplet.this.urn
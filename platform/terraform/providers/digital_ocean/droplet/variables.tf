@@ -32,3 +32,15 @@ variable "server_user" {
   type        = string
   default     = "root"
 }
+
+variable "vpc_id" {
+  description = "ID of the VPC to place the droplet in, defaults to the region's default VPC"
+  type        = string
+  default     = null
+}
+
+variable "firewall_enabled" {
+  description = "Whether to create the default firewall (SSH, HTTP and HTTPS open to anyone)"
+  type        = bool
+  default     = true
+}
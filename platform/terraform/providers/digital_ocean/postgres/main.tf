@@ -21,6 +21,10 @@ resource "digitalocean_database_cluster" "this" {
   region     = var.region
   node_count = var.node_count
   tags       = var.tags
+
+  # Only set when the database is placed in the project's VPC,
+  # otherwise the region's default VPC is used.
+  private_network_uuid = var.vpc_id
 }
 
 #
@@ -38,4 +38,10 @@ variable "tags" {
   description = "List of tags to apply to the resource"
   type        = list(string)
   default     = []
-}
\ No newline at end of file
+}
+
+variable "vpc_id" {
+  description = "ID of the VPC to place the cluster in, defaults to the region's default VPC"
+  type        = string
+  default     = null
+}
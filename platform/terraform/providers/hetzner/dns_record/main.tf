@@ -0,0 +1,17 @@
+#
+# Hetzner DNS Record
+# Creates a record within an existing Hetzner DNS zone.
+#
+# Ref: https://registry.terraform.io/providers/germanbrew/hetznerdns/latest/docs/resources/record
+#
+data "hetznerdns_zone" "this" {
+  name = var.zone
+}
+
+resource "hetznerdns_record" "this" {
+  zone_id = data.hetznerdns_zone.this.id
+  name    = var.name
+  type    = var.type
+  value   = var.value
+  ttl     = var.ttl
+}
@@ -0,0 +1,9 @@
+output "id" {
+  description = "The ID of the DNS record"
+  value       = hetznerdns_record.this.id
+}
+
+output "fqdn" {
+  description = "The FQDN of the record"
+  value       = var.name == "@" ? var.zone : "${var.name}.${var.zone}"
+}
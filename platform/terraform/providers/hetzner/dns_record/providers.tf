@@ -0,0 +1,7 @@
+terraform {
+  required_providers {
+    hetznerdns = {
+      source = "germanbrew/hetznerdns"
+    }
+  }
+}
@@ -47,11 +47,14 @@ resource "hcloud_server" "this" {
 
 #
 # Firewall
+# Disabled when the app declares its own inbound rules in app.json,
+# which are provisioned alongside the other network rules instead.
 #
 # Ref: https://registry.terraform.io/providers/hetznercloud/hcloud/latest/docs/resources/firewall
 #
 resource "hcloud_firewall" "this" {
-  name = "${var.name}-firewall"
+  count = var.firewall_enabled ? 1 : 0
+  name  = "${var.name}-firewall"
 
   rule {
     direction = "in"
@@ -83,6 +86,21 @@ resource "hcloud_firewall" "this" {
     ]
   }
 
+  # Expose the app's port directly when it isn't already
+  # served through the reverse proxy on 80/443.
+  dynamic "rule" {
+    for_each = var.app_port != null && !contains([22, 80, 443], var.app_port) ? [var.app_port] : []
+    content {
+      direction = "in"
+      protocol  = "tcp"
+      port      = tostring(rule.value)
+      source_ips = [
+        "0.0.0.0/0",
+        "::/0"
+      ]
+    }
+  }
+
   rule {
     direction = "in"
     protocol  = "icmp"
@@ -123,6 +141,36 @@ resource "hcloud_firewall" "this" {
 }
 
 resource "hcloud_firewall_attachment" "this" {
-  firewall_id = hcloud_firewall.this.id
+  count       = var.firewall_enabled ? 1 : 0
+  firewall_id = hcloud_firewall.this[0].id
   server_ids  = [hcloud_server.this.id]
 }
+
+moved {
+  from = hcloud_firewall.this
+  to   = hcloud_firewall.this[0]
+}
+
+moved {
+  from = hcloud_firewall_attachment.this
+  to   = hcloud_firewall_attachment.this[0]
+}
+
+#
+# Reverse DNS
+#
+# Ref: https://registry.terraform.io/providers/hetznercloud/hcloud/latest/docs/resources/rdns
+#
+resource "hcloud_rdns" "ipv4" {
+  count      = var.reverse_dns != "" ? 1 : 0
+  server_id  = hcloud_server.this.id
+  ip_address = hcloud_server.this.ipv4_address
+  dns_ptr    = var.reverse_dns
+}
+
+resource "hcloud_rdns" "ipv6" {
+  count      = var.reverse_dns != "" ? 1 : 0
+  server_id  = hcloud_server.this.id
+  ip_address = hcloud_server.this.ipv6_address
+  dns_ptr    = var.reverse_dns
+}
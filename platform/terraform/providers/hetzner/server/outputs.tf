@@ -8,6 +8,11 @@ output "ip_address" {
   value       = hcloud_server.this.ipv4_address
 }
 
+output "ipv6_address" {
+  description = "Server IPv6 address"
+  value       = hcloud_server.this.ipv6_address
+}
+
 output "ssh_private_key" {
   description = "Generated SSH private key"
   value       = tls_private_key.this.private_key_pem
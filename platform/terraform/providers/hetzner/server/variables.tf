@@ -32,3 +32,21 @@ variable "server_user" {
   description = "SSH user for server access"
   default     = "root"
 }
+
+variable "app_port" {
+  type        = number
+  description = "Port the app listens on, opened in the firewall when not 22, 80 or 443"
+  default     = null
+}
+
+variable "reverse_dns" {
+  type        = string
+  description = "Hostname to set as the reverse DNS (PTR) record for the server's IPs"
+  default     = ""
+}
+
+variable "firewall_enabled" {
+  type        = bool
+  description = "Whether to create the default firewall (SSH, HTTP, HTTPS and the app port open to anyone)"
+  default     = true
+}